@@ -32,8 +32,9 @@ var ForceColors = false
 // DisableColors disables all colors and other ANSI sequences.
 var DisableColors = false
 
-// EnvironmentOverrideColors overs coloring based on `CLICOLOR` and
-// `CLICOLOR_FORCE`. Cf. https://bixense.com/clicolors/
+// EnvironmentOverrideColors overs coloring based on `CLICOLOR`,
+// `CLICOLOR_FORCE` and `NO_COLOR`. Cf. https://bixense.com/clicolors/ and
+// https://no-color.org/
 var EnvironmentOverrideColors = true
 
 //
@@ -157,8 +158,10 @@ func StartSpinner(s *spinner.Spinner, msg string, w io.Writer) {
 // StopSpinner stops a spinner with the given message. If the writer is not
 // a terminal or doesn't support colors, it simply prints the message.
 func StopSpinner(s *spinner.Spinner, msg string, w io.Writer) {
-	if !isTerminal(w) || !shouldUseColors(w) {
-		fmt.Fprintln(w, msg)
+	if s == nil || !isTerminal(w) || !shouldUseColors(w) {
+		if msg != "" {
+			fmt.Fprintln(w, msg)
+		}
 		return
 	}
 
@@ -201,6 +204,8 @@ func shouldUseColors(w io.Writer) bool {
 			useColors = false
 		case os.Getenv("CLICOLOR") == "0":
 			useColors = false
+		case os.Getenv("NO_COLOR") != "":
+			useColors = false
 		}
 	}
 
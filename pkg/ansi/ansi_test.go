@@ -0,0 +1,48 @@
+package ansi
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// unsetenv unsets an environment variable for the duration of the test.
+func unsetenv(t *testing.T, key string) {
+	value, ok := os.LookupEnv(key)
+	os.Unsetenv(key)
+	t.Cleanup(func() {
+		if ok {
+			os.Setenv(key, value)
+		}
+	})
+}
+
+func TestNoColorEnvironment(t *testing.T) {
+	unsetenv(t, "CLICOLOR_FORCE")
+	unsetenv(t, "CLICOLOR")
+	unsetenv(t, "NO_COLOR")
+	ForceColors = true
+	defer func() { ForceColors = false }()
+
+	var buf bytes.Buffer
+	require.Equal(t, "\x1b[31mred\x1b[0m", Color(&buf).Red("red").String())
+
+	t.Setenv("NO_COLOR", "1")
+	require.Equal(t, "red", Color(&buf).Red("red").String())
+	require.Equal(t, `{"a":1}`, ColorizeJSON(`{"a":1}`, false, &buf))
+}
+
+func TestDisableColors(t *testing.T) {
+	unsetenv(t, "CLICOLOR_FORCE")
+	ForceColors = true
+	DisableColors = true
+	defer func() {
+		ForceColors = false
+		DisableColors = false
+	}()
+
+	var buf bytes.Buffer
+	require.Equal(t, "red", Color(&buf).Red("red").String())
+}
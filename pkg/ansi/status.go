@@ -0,0 +1,53 @@
+package ansi
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// StatusWriter receives the human-facing status of commands, such as
+// spinners, progress and notices. It's stderr so that status never mixes
+// with the output of commands on stdout, e.g. with `--output json`.
+var StatusWriter io.Writer = os.Stderr
+
+// Status prints a status line to StatusWriter.
+func Status(format string, a ...interface{}) {
+	fmt.Fprintf(StatusWriter, format+"\n", a...)
+}
+
+// Progress reports the progress of a long running operation on
+// StatusWriter. The progress line is updated in place, and only displayed
+// when StatusWriter is a terminal.
+type Progress struct {
+	w       io.Writer
+	enabled bool
+	printed bool
+}
+
+// NewProgress returns a Progress writing to StatusWriter.
+func NewProgress() *Progress {
+	return &Progress{w: StatusWriter, enabled: isTerminal(StatusWriter)}
+}
+
+// Enabled returns whether the progress is displayed.
+func (p *Progress) Enabled() bool {
+	return p.enabled
+}
+
+// Update replaces the progress line.
+func (p *Progress) Update(format string, a ...interface{}) {
+	if !p.enabled {
+		return
+	}
+	fmt.Fprintf(p.w, "\r"+format, a...)
+	p.printed = true
+}
+
+// Done ends the progress line, leaving the last update displayed.
+func (p *Progress) Done() {
+	if p.printed {
+		fmt.Fprintln(p.w)
+		p.printed = false
+	}
+}
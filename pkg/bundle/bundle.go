@@ -0,0 +1,164 @@
+// Package bundle collects the files of a debug bundle, attached to bug
+// reports: the version and platform of the CLI, its redacted config, its
+// logs and the metadata of the recent events.
+package bundle
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	hookdecksdk "github.com/hookdeck/hookdeck-go-sdk"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/redact"
+	"github.com/hookdeck/hookdeck-cli/pkg/version"
+)
+
+// DefaultMaxLogBytes is the size of the end of the log files included.
+const DefaultMaxLogBytes = 1 << 20
+
+// Entry is a file of a bundle.
+type Entry struct {
+	Name string
+	// Description is shown when confirming the files to include
+	Description string
+	Data        []byte
+}
+
+// Info is the entry of the version and platform of the CLI.
+func Info() Entry {
+	info := map[string]string{
+		"version":    version.Version,
+		"os":         runtime.GOOS,
+		"arch":       runtime.GOARCH,
+		"go_version": runtime.Version(),
+		"created_at": time.Now().UTC().Format(time.RFC3339),
+	}
+	data, _ := json.MarshalIndent(info, "", "  ")
+	return Entry{
+		Name:        "info.json",
+		Description: "Version and platform of the CLI",
+		Data:        data,
+	}
+}
+
+// ConfigFile is the entry of a config file, its secrets redacted.
+func ConfigFile(name string, path string) (Entry, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return Entry{}, err
+	}
+	return Entry{
+		Name:        name,
+		Description: fmt.Sprintf("%s, secrets redacted", path),
+		Data:        redact.TOML(data),
+	}, nil
+}
+
+// LogFile is the entry of the last maxBytes of a log file.
+func LogFile(path string, maxBytes int64) (Entry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Entry{}, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return Entry{}, err
+	}
+	description := path
+	if info.Size() > maxBytes {
+		if _, err := f.Seek(-maxBytes, io.SeekEnd); err != nil {
+			return Entry{}, err
+		}
+		description = fmt.Sprintf("%s, last %d KB", path, maxBytes/1024)
+	}
+	data, err := ioutil.ReadAll(f)
+	if err != nil {
+		return Entry{}, err
+	}
+	return Entry{
+		Name:        "logs/" + filepath.Base(path),
+		Description: description,
+		Data:        data,
+	}, nil
+}
+
+// Events is the entry of the metadata of events: their IDs, statuses and
+// timestamps, without their data.
+func Events(events []*hookdecksdk.Event) (Entry, error) {
+	metadata := make([]hookdecksdk.Event, len(events))
+	for i, event := range events {
+		metadata[i] = *event
+		metadata[i].Data = nil
+	}
+	data, err := json.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		return Entry{}, err
+	}
+	return Entry{
+		Name:        "events.json",
+		Description: fmt.Sprintf("Metadata of the last %d events, without their headers and body", len(events)),
+		Data:        data,
+	}, nil
+}
+
+// Errors is the entry of the errors met collecting the other entries, so
+// that a bundle is written even when some of its files can't be collected.
+func Errors(errs []error) Entry {
+	lines := make([]string, len(errs))
+	for i, err := range errs {
+		lines[i] = err.Error()
+	}
+	return Entry{
+		Name:        "errors.txt",
+		Description: "Errors collecting the bundle",
+		Data:        []byte(strings.Join(lines, "\n") + "\n"),
+	}
+}
+
+// Write writes the entries to a zip file. The zip is written to a
+// temporary file renamed once complete, so an interrupted write never
+// leaves a truncated bundle.
+func Write(path string, entries []Entry) error {
+	f, err := ioutil.TempFile(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	err = writeZip(f, entries)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+func writeZip(w io.Writer, entries []Entry) error {
+	archive := zip.NewWriter(w)
+	for _, entry := range entries {
+		file, err := archive.CreateHeader(&zip.FileHeader{
+			Name:     entry.Name,
+			Method:   zip.Deflate,
+			Modified: time.Now(),
+		})
+		if err != nil {
+			return err
+		}
+		if _, err := file.Write(entry.Data); err != nil {
+			return err
+		}
+	}
+	return archive.Close()
+}
@@ -0,0 +1,48 @@
+package bundle
+
+import (
+	"archive/zip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "listen.log")
+	require.NoError(t, ioutil.WriteFile(path, []byte(strings.Repeat("a", 10)+"tail"), 0600))
+
+	entry, err := LogFile(path, 4)
+	require.NoError(t, err)
+	require.Equal(t, "logs/listen.log", entry.Name)
+	require.Equal(t, "tail", string(entry.Data))
+
+	entry, err = LogFile(path, DefaultMaxLogBytes)
+	require.NoError(t, err)
+	require.Len(t, entry.Data, 14)
+}
+
+func TestWrite(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "bundle.zip")
+
+	err := Write(path, []Entry{
+		{Name: "info.json", Data: []byte(`{}`)},
+		{Name: "logs/listen.log", Data: []byte("started")},
+	})
+	require.NoError(t, err)
+
+	archive, err := zip.OpenReader(path)
+	require.NoError(t, err)
+	defer archive.Close()
+	require.Len(t, archive.File, 2)
+	require.Equal(t, "logs/listen.log", archive.File[1].Name)
+
+	// The temporary file is renamed
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+}
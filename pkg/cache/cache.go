@@ -0,0 +1,79 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Cache stores the responses of rarely changing API lookups on disk, such as
+// the list of projects, so that commands don't fetch them on every run.
+type Cache struct {
+	// Dir is the folder of the cache files
+	Dir string
+}
+
+type entry struct {
+	SavedAt time.Time       `json:"saved_at"`
+	Value   json.RawMessage `json:"value"`
+}
+
+// Get decodes the value cached for the key into value. It returns false when
+// there's no value, or when it was saved more than ttl ago.
+func (c Cache) Get(key string, ttl time.Duration, value interface{}) bool {
+	data, err := ioutil.ReadFile(c.path(key))
+	if err != nil {
+		return false
+	}
+
+	e := entry{}
+	if err := json.Unmarshal(data, &e); err != nil {
+		return false
+	}
+	if time.Since(e.SavedAt) > ttl {
+		return false
+	}
+
+	return json.Unmarshal(e.Value, value) == nil
+}
+
+// Set caches the value for the key.
+func (c Cache) Set(key string, value interface{}) error {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(entry{SavedAt: time.Now(), Value: encoded})
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(c.Dir, 0700); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(c.path(key), data, 0600)
+}
+
+// Delete removes the value cached for the key.
+func (c Cache) Delete(key string) error {
+	if err := os.Remove(c.path(key)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// Clear removes all the cached values.
+func (c Cache) Clear() error {
+	return os.RemoveAll(c.Dir)
+}
+
+// path returns the file of a key. Keys are hashed as they may contain
+// credentials.
+func (c Cache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.Dir, hex.EncodeToString(sum[:])+".json")
+}
@@ -0,0 +1,30 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache(t *testing.T) {
+	c := Cache{Dir: t.TempDir()}
+
+	value := []string{}
+	require.False(t, c.Get("projects", time.Minute, &value))
+
+	require.NoError(t, c.Set("projects", []string{"staging", "production"}))
+	require.True(t, c.Get("projects", time.Minute, &value))
+	require.Equal(t, []string{"staging", "production"}, value)
+
+	// Expired values aren't returned
+	require.False(t, c.Get("projects", 0, &value))
+
+	require.NoError(t, c.Delete("projects"))
+	require.False(t, c.Get("projects", time.Minute, &value))
+	require.NoError(t, c.Delete("projects"))
+
+	require.NoError(t, c.Set("projects", []string{"staging"}))
+	require.NoError(t, c.Clear())
+	require.False(t, c.Get("projects", time.Minute, &value))
+}
@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/validators"
+)
+
+type cacheCmd struct {
+	cmd *cobra.Command
+}
+
+func newCacheCmd() *cacheCmd {
+	cc := &cacheCmd{}
+
+	cc.cmd = &cobra.Command{
+		Use:   "cache",
+		Args:  validators.NoArgs,
+		Short: "Manage the cache of API lookups",
+		Long: `Manage the cache of API lookups.
+
+Commands that look up a project by name, such as project use or connection
+promote, cache the list of projects for a few minutes to avoid fetching it on
+every run. Listings such as project list always fetch it from the API.`,
+	}
+
+	cc.cmd.AddCommand(newCacheClearCmd().cmd)
+
+	return cc
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/validators"
+)
+
+type cacheClearCmd struct {
+	cmd *cobra.Command
+}
+
+func newCacheClearCmd() *cacheClearCmd {
+	cc := &cacheClearCmd{}
+
+	cc.cmd = &cobra.Command{
+		Use:   "clear",
+		Args:  validators.NoArgs,
+		Short: "Remove all the cached API lookups",
+		RunE:  cc.runCacheClearCmd,
+	}
+
+	return cc
+}
+
+func (cc *cacheClearCmd) runCacheClearCmd(cmd *cobra.Command, args []string) error {
+	if err := Config.GetCache().Clear(); err != nil {
+		return err
+	}
+
+	printInfo("Cache cleared")
+
+	return nil
+}
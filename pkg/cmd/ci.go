@@ -26,7 +26,7 @@ func newCICmd() *ciCmd {
 		Long:  `Login to your Hookdeck project to forward events in CI`,
 		RunE:  lc.runCICmd,
 	}
-	lc.cmd.Flags().StringVar(&lc.apiKey, "api-key", os.Getenv("HOOKDECK_API_KEY"), "Your API key to use for the command")
+	secretFlagVar(lc.cmd.Flags(), &lc.apiKey, "api-key", os.Getenv("HOOKDECK_API_KEY"), "Your API key to use for the command")
 	lc.cmd.Flags().StringVar(&lc.name, "name", "", "Your CI name (ex: $GITHUB_REF)")
 
 	return lc
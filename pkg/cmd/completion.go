@@ -32,6 +32,8 @@ func newCompletionCmd() *completionCmd {
 
 	cc.cmd.Flags().StringVar(&cc.shell, "shell", "", "The shell to generate completion commands for. Supports \"bash\" or \"zsh\"")
 
+	cc.cmd.AddCommand(newCompletionInstallCmd().cmd)
+
 	return cc
 }
 
@@ -109,6 +111,8 @@ func selectShell(shell string) error {
 			}
 		}
 		return err
+	case selected == "fish" || selected == "powershell":
+		return fmt.Errorf("%s completion is only supported by `hookdeck completion install`", selected)
 	default:
 		return fmt.Errorf("could not automatically detect your shell. Please run the command with the `--shell` flag for either bash or zsh")
 	}
@@ -122,6 +126,10 @@ func detectShell() string {
 		return "zsh"
 	case strings.Contains(shell, "bash"):
 		return "bash"
+	case strings.Contains(shell, "fish"):
+		return "fish"
+	case shell == "" && runtime.GOOS == "windows" && os.Getenv("PSModulePath") != "":
+		return "powershell"
 	default:
 		return ""
 	}
@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/spf13/cobra"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/validators"
+)
+
+type completionInstallCmd struct {
+	cmd *cobra.Command
+
+	shell  string
+	dryRun bool
+}
+
+func newCompletionInstallCmd() *completionInstallCmd {
+	cc := &completionInstallCmd{}
+
+	cc.cmd = &cobra.Command{
+		Use:   "install",
+		Args:  validators.NoArgs,
+		Short: "Install the completion script for your shell",
+		Long: `Install the completion script for your shell, detected from $SHELL unless
+--shell is set.
+
+The script is written to the conventional location of the shell, and the
+line loading it is added to the shell profile when the shell needs one. The
+files changed are printed, use --dry-run to only print them. Running the
+command again updates the script without changing the profile again.`,
+		Example: `  hookdeck completion install
+  hookdeck completion install --shell fish --dry-run`,
+		RunE: cc.runCompletionInstallCmd,
+	}
+
+	cc.cmd.Flags().StringVar(&cc.shell, "shell", "", "The shell to install the completion script for (bash, zsh, fish, powershell)")
+	cc.cmd.Flags().BoolVar(&cc.dryRun, "dry-run", false, "Print the files that would be changed without changing them")
+
+	return cc
+}
+
+// completionInstall is where the completion script of a shell is written,
+// and the line to add to the profile of the shell to load it, if any.
+type completionInstall struct {
+	script      string
+	profile     string
+	profileLine string
+}
+
+func (cc *completionInstallCmd) runCompletionInstallCmd(cmd *cobra.Command, args []string) error {
+	shell := cc.shell
+	if shell == "" {
+		shell = detectShell()
+		if shell == "" {
+			return fmt.Errorf("could not automatically detect your shell. Please run the command with the `--shell` flag for bash, zsh, fish or powershell")
+		}
+	}
+
+	home, err := homedir.Dir()
+	if err != nil {
+		return err
+	}
+	install, err := completionInstallFor(shell, home)
+	if err != nil {
+		return err
+	}
+
+	script := &bytes.Buffer{}
+	switch shell {
+	case "bash":
+		err = rootCmd.GenBashCompletion(script)
+	case "zsh":
+		err = rootCmd.GenZshCompletion(script)
+	case "fish":
+		err = rootCmd.GenFishCompletion(script, true)
+	case "powershell":
+		err = rootCmd.GenPowerShellCompletionWithDesc(script)
+	}
+	if err != nil {
+		return err
+	}
+
+	verb := "Wrote"
+	if cc.dryRun {
+		verb = "Would write"
+	}
+	if !cc.dryRun {
+		if err := os.MkdirAll(filepath.Dir(install.script), 0755); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(install.script, script.Bytes(), 0644); err != nil {
+			return err
+		}
+	}
+	fmt.Printf("%s the %s completion script to %s\n", verb, shell, install.script)
+
+	if install.profile == "" {
+		return nil
+	}
+
+	profile, err := ioutil.ReadFile(install.profile)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if strings.Contains(string(profile), install.profileLine) {
+		fmt.Printf("%s already loads the completion script\n", install.profile)
+		return nil
+	}
+
+	if cc.dryRun {
+		fmt.Printf("Would add to %s:\n%s\n", install.profile, install.profileLine)
+		return nil
+	}
+	if err := appendToProfile(install.profile, profile, install.profileLine); err != nil {
+		return err
+	}
+	fmt.Printf("Added to %s:\n%s\n\nOpen a new terminal session to enable the completion.\n", install.profile, install.profileLine)
+
+	return nil
+}
+
+// completionInstallFor returns where the completion script of shell is
+// installed for the user with the given home folder.
+func completionInstallFor(shell string, home string) (completionInstall, error) {
+	dir := filepath.Join(home, ".hookdeck")
+
+	switch shell {
+	case "bash":
+		// macOS opens login shells, which read .bash_profile but not .bashrc
+		profile := ".bashrc"
+		if runtime.GOOS == "darwin" {
+			profile = ".bash_profile"
+		}
+		script := filepath.Join(dir, "hookdeck-completion.bash")
+		return completionInstall{
+			script:      script,
+			profile:     filepath.Join(home, profile),
+			profileLine: "source " + script,
+		}, nil
+	case "zsh":
+		// zsh autoloads completion functions from the files of fpath named
+		// after them
+		return completionInstall{
+			script:      filepath.Join(dir, "_hookdeck"),
+			profile:     filepath.Join(home, ".zshrc"),
+			profileLine: "fpath=(" + dir + " $fpath)\nautoload -Uz compinit && compinit -i",
+		}, nil
+	case "fish":
+		// fish loads the completions of its completions folder by itself
+		configDir := os.Getenv("XDG_CONFIG_HOME")
+		if configDir == "" {
+			configDir = filepath.Join(home, ".config")
+		}
+		return completionInstall{
+			script: filepath.Join(configDir, "fish", "completions", "hookdeck.fish"),
+		}, nil
+	case "powershell":
+		profile := filepath.Join(home, ".config", "powershell", "Microsoft.PowerShell_profile.ps1")
+		if runtime.GOOS == "windows" {
+			profile = filepath.Join(home, "Documents", "PowerShell", "Microsoft.PowerShell_profile.ps1")
+		}
+		script := filepath.Join(dir, "hookdeck-completion.ps1")
+		return completionInstall{
+			script:      script,
+			profile:     profile,
+			profileLine: ". " + script,
+		}, nil
+	default:
+		return completionInstall{}, fmt.Errorf("unsupported shell %q, expected one of bash, zsh, fish, powershell", shell)
+	}
+}
+
+// appendToProfile appends line to the profile, whose current content is
+// given, creating it if needed.
+func appendToProfile(path string, content []byte, line string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	prefix := ""
+	switch {
+	case len(content) == 0:
+	case bytes.HasSuffix(content, []byte("\n")):
+		prefix = "\n"
+	default:
+		prefix = "\n\n"
+	}
+	_, err = fmt.Fprintf(f, "%s# Hookdeck CLI completion\n%s\n", prefix, line)
+	return err
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/validators"
+)
+
+type configCmd struct {
+	cmd *cobra.Command
+}
+
+func newConfigCmd() *configCmd {
+	cc := &configCmd{}
+
+	cc.cmd = &cobra.Command{
+		Use:   "config",
+		Args:  validators.NoArgs,
+		Short: "Read and update your CLI configuration",
+		Long: `Read and update your CLI configuration.
+
+Values are stored in the global config file ($HOME/.config/hookdeck/config.toml)
+by default. The api_key and project values are stored for the active profile.
+Use --local to store values in the config file of the current directory
+(.hookdeck/config.toml), which takes precedence over the global one.`,
+	}
+
+	cc.cmd.AddCommand(newConfigListCmd().cmd)
+	cc.cmd.AddCommand(newConfigGetCmd().cmd)
+	cc.cmd.AddCommand(newConfigSetCmd().cmd)
+	cc.cmd.AddCommand(newConfigUnsetCmd().cmd)
+
+	return cc
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/config"
+	"github.com/hookdeck/hookdeck-cli/pkg/validators"
+)
+
+type configGetCmd struct {
+	cmd   *cobra.Command
+	local bool
+}
+
+func newConfigGetCmd() *configGetCmd {
+	gc := &configGetCmd{}
+
+	gc.cmd = &cobra.Command{
+		Use:     "get <key>",
+		Args:    validators.ExactArgs(1),
+		Short:   "Print a configuration value",
+		Example: `  hookdeck config get project`,
+		RunE:    gc.runConfigGetCmd,
+	}
+	gc.cmd.Flags().BoolVar(&gc.local, "local", false, "Read the value from the config file of the current directory")
+
+	return gc
+}
+
+func (gc *configGetCmd) runConfigGetCmd(cmd *cobra.Command, args []string) error {
+	key, err := config.GetKey(args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(Config.GetValue(key, gc.local))
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/config"
+	"github.com/hookdeck/hookdeck-cli/pkg/output"
+	"github.com/hookdeck/hookdeck-cli/pkg/validators"
+)
+
+type configListCmd struct {
+	cmd    *cobra.Command
+	local  bool
+	output output.Options
+}
+
+func newConfigListCmd() *configListCmd {
+	lc := &configListCmd{
+		output: output.Options{
+			DefaultColumns:   []string{"key", "value", "description"},
+			AvailableColumns: []string{"key", "value", "description"},
+		},
+	}
+
+	lc.cmd = &cobra.Command{
+		Use:   "list",
+		Args:  validators.NoArgs,
+		Short: "List the configuration values",
+		RunE:  lc.runConfigListCmd,
+	}
+	lc.cmd.Flags().BoolVar(&lc.local, "local", false, "List the values of the config file of the current directory")
+	lc.output.AddFlags(lc.cmd.Flags())
+
+	return lc
+}
+
+func (lc *configListCmd) runConfigListCmd(cmd *cobra.Command, args []string) error {
+	if err := lc.output.Validate(); err != nil {
+		return err
+	}
+
+	values := map[string]string{}
+	rows := make([]output.Row, len(config.Keys))
+	for i, key := range config.Keys {
+		value := Config.GetValue(key, lc.local)
+		if key.Name == "api_key" && value != "" {
+			value = "********"
+		}
+		values[key.Name] = value
+		rows[i] = output.Row{
+			"key":         key.Name,
+			"value":       value,
+			"description": key.Description,
+		}
+	}
+
+	return lc.output.Print(os.Stdout, values, rows)
+}
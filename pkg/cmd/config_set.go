@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/config"
+	"github.com/hookdeck/hookdeck-cli/pkg/validators"
+)
+
+type configSetCmd struct {
+	cmd   *cobra.Command
+	local bool
+}
+
+func newConfigSetCmd() *configSetCmd {
+	sc := &configSetCmd{}
+
+	sc.cmd = &cobra.Command{
+		Use:   "set <key> <value>",
+		Args:  validators.ExactArgs(2),
+		Short: "Set a configuration value",
+		Example: `  hookdeck config set output json
+  hookdeck config set project tm_123 --local`,
+		RunE: sc.runConfigSetCmd,
+	}
+	sc.cmd.Flags().BoolVar(&sc.local, "local", false, "Store the value in the config file of the current directory")
+
+	return sc
+}
+
+func (sc *configSetCmd) runConfigSetCmd(cmd *cobra.Command, args []string) error {
+	key, err := config.GetKey(args[0])
+	if err != nil {
+		return err
+	}
+
+	return Config.SetValue(key, args[1], sc.local)
+}
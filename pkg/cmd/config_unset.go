@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/config"
+	"github.com/hookdeck/hookdeck-cli/pkg/validators"
+)
+
+type configUnsetCmd struct {
+	cmd   *cobra.Command
+	local bool
+}
+
+func newConfigUnsetCmd() *configUnsetCmd {
+	uc := &configUnsetCmd{}
+
+	uc.cmd = &cobra.Command{
+		Use:     "unset <key>",
+		Args:    validators.ExactArgs(1),
+		Short:   "Remove a configuration value",
+		Example: `  hookdeck config unset output`,
+		RunE:    uc.runConfigUnsetCmd,
+	}
+	uc.cmd.Flags().BoolVar(&uc.local, "local", false, "Remove the value from the config file of the current directory")
+
+	return uc
+}
+
+func (uc *configUnsetCmd) runConfigUnsetCmd(cmd *cobra.Command, args []string) error {
+	key, err := config.GetKey(args[0])
+	if err != nil {
+		return err
+	}
+
+	return Config.UnsetValue(key, uc.local)
+}
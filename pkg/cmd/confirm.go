@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/AlecAivazis/survey/v2"
+	"golang.org/x/term"
+)
+
+// errConfirmationRequired is returned when a confirmation is needed but
+// there's no terminal to ask it on.
+var errConfirmationRequired = errors.New("confirmation required, use --yes to run this command non-interactively")
+
+// confirm asks the user to confirm an action. It's skipped with --yes.
+func confirm(message string) error {
+	if Config.Yes {
+		return nil
+	}
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return errConfirmationRequired
+	}
+
+	confirmed := false
+	if err := survey.AskOne(&survey.Confirm{Message: message}, &confirmed); err != nil {
+		return err
+	}
+	if !confirmed {
+		return errors.New("canceled")
+	}
+	return nil
+}
+
+// confirmName asks the user to type the name of what an action that can't be
+// undone applies to, e.g. the project being deleted. It's skipped with --yes.
+func confirmName(message string, name string) error {
+	if Config.Yes {
+		return nil
+	}
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return errConfirmationRequired
+	}
+
+	typed := ""
+	if err := survey.AskOne(&survey.Input{Message: message}, &typed); err != nil {
+		return err
+	}
+	if typed != name {
+		return fmt.Errorf("%q doesn't match %q, canceled", typed, name)
+	}
+	return nil
+}
+
+// infoOut returns where commands print informational messages, such as the
+// result of an action. They're discarded with --quiet, unlike the data
+// commands are asked to print.
+func infoOut() io.Writer {
+	if Config.Quiet {
+		return ioutil.Discard
+	}
+	return os.Stdout
+}
+
+// printInfo prints an informational message, see infoOut.
+func printInfo(format string, a ...interface{}) {
+	fmt.Fprintf(infoOut(), format+"\n", a...)
+}
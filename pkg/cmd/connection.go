@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/validators"
+)
+
+type connectionCmd struct {
+	cmd *cobra.Command
+}
+
+func newConnectionCmd() *connectionCmd {
+	cc := &connectionCmd{}
+
+	cc.cmd = &cobra.Command{
+		Use:     "connection",
+		Aliases: []string{"connections"},
+		Args:    validators.NoArgs,
+		Short:   "Manage your connections",
+	}
+
+	cc.cmd.AddCommand(newConnectionRulesCmd().cmd)
+	cc.cmd.AddCommand(newConnectionPromoteCmd().cmd)
+	cc.cmd.AddCommand(newConnectionRenameCmd().cmd)
+
+	return cc
+}
@@ -0,0 +1,279 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/ansi"
+	"github.com/hookdeck/hookdeck-cli/pkg/connection"
+	"github.com/hookdeck/hookdeck-cli/pkg/destination"
+	"github.com/hookdeck/hookdeck-cli/pkg/hookdeck"
+	"github.com/hookdeck/hookdeck-cli/pkg/project"
+	"github.com/hookdeck/hookdeck-cli/pkg/validators"
+)
+
+type connectionPromoteCmd struct {
+	cmd           *cobra.Command
+	from          string
+	to            string
+	secret        string
+	keepSecret    bool
+	dryRun        bool
+	failIfExists  bool
+	failIfMissing bool
+	output        string
+	validateURL   bool
+	strict        bool
+}
+
+// validateURLTimeout is how long the destination URL has to respond to the
+// health check of --validate-url.
+const validateURLTimeout = 10 * time.Second
+
+func newConnectionPromoteCmd() *connectionPromoteCmd {
+	pc := &connectionPromoteCmd{}
+
+	pc.cmd = &cobra.Command{
+		Use:   "promote <connection>",
+		Args:  validators.ExactArgs(1),
+		Short: "Copy a connection to another project",
+		Long: `Copy a connection, with its source, destination and rules, to another
+project. The connection is created in the target project, or updated if a
+connection with the same full name exists there. The changes are shown before
+they're applied.
+
+Secrets aren't copied. The secret of the destination auth method is read from
+--secret or prompted for, unless --keep-secret is set to keep the one of the
+target project. The verification of the source isn't copied.
+
+With --validate-url, the destination URL is probed with a HEAD request, or
+OPTIONS when HEAD isn't allowed, and a warning is shown when it's unreachable
+or responds with a 5xx. --strict fails instead.`,
+		Example: `  hookdeck connection promote stripe-api --from staging --to production
+  hookdeck connection promote stripe-api --to production --secret @env:PROD_API_TOKEN --yes
+  hookdeck connection promote stripe-api --to production --keep-secret --fail-if-missing --yes
+  hookdeck connection promote stripe-api --to production --dry-run --output json
+  hookdeck connection promote stripe-api --to production --validate-url --strict`,
+		RunE: pc.runConnectionPromoteCmd,
+	}
+	pc.cmd.Flags().StringVar(&pc.from, "from", "", "Name or ID of the project to copy the connection from (default is the active project)")
+	pc.cmd.Flags().StringVar(&pc.to, "to", "", "Name or ID of the project to copy the connection to")
+	pc.cmd.MarkFlagRequired("to")
+	secretFlagVar(pc.cmd.Flags(), &pc.secret, "secret", "", "Secret of the destination auth method in the target project")
+	pc.cmd.Flags().BoolVar(&pc.keepSecret, "keep-secret", false, "Keep the auth method of the destination in the target project")
+	pc.cmd.Flags().BoolVar(&pc.dryRun, "dry-run", false, "Show the changes without applying them")
+	pc.cmd.Flags().BoolVar(&pc.failIfExists, "fail-if-exists", false, "Fail if the connection already exists in the target project, instead of updating it")
+	pc.cmd.Flags().BoolVar(&pc.failIfMissing, "fail-if-missing", false, "Fail if the connection doesn't exist in the target project, instead of creating it")
+	pc.cmd.Flags().StringVarP(&pc.output, "output", "o", "diff", "Output format of the changes with --dry-run (diff, json)")
+	pc.cmd.Flags().BoolVar(&pc.validateURL, "validate-url", false, "Check that the destination URL is reachable and doesn't respond with a 5xx")
+	pc.cmd.Flags().BoolVar(&pc.strict, "strict", false, "Fail instead of warning when the destination URL fails the --validate-url check")
+
+	return pc
+}
+
+func (pc *connectionPromoteCmd) runConnectionPromoteCmd(cmd *cobra.Command, args []string) error {
+	if pc.secret != "" && pc.keepSecret {
+		return errors.New("only one of --secret and --keep-secret can be used")
+	}
+	if pc.failIfExists && pc.failIfMissing {
+		return errors.New("only one of --fail-if-exists and --fail-if-missing can be used")
+	}
+	if pc.strict && !pc.validateURL {
+		return errors.New("--strict can only be used with --validate-url")
+	}
+	switch pc.output {
+	case "diff":
+	case "json":
+		if !pc.dryRun {
+			return errors.New("--output json can only be used with --dry-run")
+		}
+	default:
+		return fmt.Errorf("unsupported output format %q. Expected one of diff, json", pc.output)
+	}
+
+	if err := Config.Profile.ValidateAPIKey(); err != nil {
+		return err
+	}
+
+	projects, err := project.CachedProjects(&Config)
+	if err != nil {
+		return err
+	}
+	from := pc.from
+	if from == "" {
+		from = Config.Profile.TeamID
+	}
+	fromProject, err := project.FindProject(projects, from)
+	if err != nil {
+		return err
+	}
+	toProject, err := project.FindProject(projects, pc.to)
+	if err != nil {
+		return err
+	}
+	if fromProject.Id == toProject.Id {
+		return errors.New("the connection can't be promoted to its own project")
+	}
+
+	conn, err := connection.Get(Config.GetProjectClient(fromProject.Id), args[0])
+	if err != nil {
+		return err
+	}
+	promotion, err := connection.NewPromotion(conn)
+	if err != nil {
+		return err
+	}
+
+	toClient := Config.GetProjectClient(toProject.Id)
+	var current string
+	exists := false
+	if conn.FullName != nil {
+		existing, err := connection.Find(toClient, *conn.FullName)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			exists = true
+			existingPromotion, err := connection.NewPromotion(existing)
+			if err != nil {
+				return err
+			}
+			if current, err = existingPromotion.Definition(); err != nil {
+				return err
+			}
+		}
+	}
+
+	switch {
+	case exists && pc.failIfExists:
+		return fmt.Errorf("the connection %s already exists in %s", args[0], toProject.Name)
+	case !exists && pc.failIfMissing:
+		return fmt.Errorf("the connection %s doesn't exist in %s", args[0], toProject.Name)
+	}
+
+	if url := promotion.DestinationURL(); pc.validateURL && url != "" {
+		if err := destination.CheckURL(url, validateURLTimeout); err != nil {
+			if pc.strict {
+				return fmt.Errorf("the destination URL failed the health check: %w", err)
+			}
+			promotion.Warnings = append(promotion.Warnings, "the destination URL failed the health check: "+err.Error())
+		}
+	}
+
+	if pc.output == "json" {
+		plan, err := promotion.Plan(current)
+		if err != nil {
+			return err
+		}
+		data, err := json.MarshalIndent(plan, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if err := pc.printChanges(promotion, current, exists, fromProject, toProject); err != nil {
+		return err
+	}
+	if pc.dryRun {
+		return nil
+	}
+
+	if err := pc.setSecret(promotion, exists, toProject); err != nil {
+		return err
+	}
+
+	if err := confirm(fmt.Sprintf("Promote the connection to %s?", toProject.Name)); err != nil {
+		return err
+	}
+
+	promoted, err := promotion.Apply(toClient)
+	if err != nil {
+		return err
+	}
+
+	operation := "created"
+	if exists {
+		operation = "updated"
+	}
+	printInfo("Connection %s in %s (%s)", operation, toProject.Name, promoted.Id)
+
+	return nil
+}
+
+func (pc *connectionPromoteCmd) printChanges(promotion *connection.Promotion, current string, exists bool, fromProject, toProject hookdeck.Project) error {
+	definition, err := promotion.Definition()
+	if err != nil {
+		return err
+	}
+
+	// The changes are what a dry run is asked for, otherwise they're only
+	// informational
+	out := infoOut()
+	if pc.dryRun {
+		out = os.Stdout
+	}
+
+	color := ansi.Color(out)
+	action := "create"
+	if exists {
+		action = "update"
+	}
+	fmt.Fprintf(out, "Promoting from %s to %s, this will %s the connection:\n\n", color.Bold(fromProject.Name), color.Bold(toProject.Name), action)
+	for _, line := range connection.Diff(current, definition) {
+		switch {
+		case strings.HasPrefix(line, "+"):
+			fmt.Fprintln(out, color.Green(line))
+		case strings.HasPrefix(line, "-"):
+			fmt.Fprintln(out, color.Red(line))
+		default:
+			fmt.Fprintln(out, line)
+		}
+	}
+	fmt.Fprintln(out)
+
+	for _, warning := range promotion.Warnings {
+		fmt.Fprintln(ansi.StatusWriter, color.Yellow("Warning: "+warning))
+	}
+
+	return nil
+}
+
+// setSecret sets the secret of the destination auth method from the flags,
+// or prompts for it.
+func (pc *connectionPromoteCmd) setSecret(promotion *connection.Promotion, exists bool, toProject hookdeck.Project) error {
+	if promotion.SecretField == "" {
+		return nil
+	}
+
+	switch {
+	case pc.secret != "":
+		return promotion.SetSecret(pc.secret)
+	case pc.keepSecret:
+		if !exists {
+			return fmt.Errorf("the connection doesn't exist in %s yet, set the %s of the destination auth method with --secret", toProject.Name, promotion.SecretField)
+		}
+		promotion.KeepSecret()
+		return nil
+	case !term.IsTerminal(int(os.Stdin.Fd())):
+		return fmt.Errorf("the destination auth method needs a %s, set it with --secret or use --keep-secret", promotion.SecretField)
+	}
+
+	secret := ""
+	err := survey.AskOne(&survey.Password{
+		Message: fmt.Sprintf("%s of the destination auth method in %s:", promotion.SecretField, toProject.Name),
+	}, &secret, survey.WithValidator(survey.Required))
+	if err != nil {
+		return err
+	}
+	return promotion.SetSecret(secret)
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/ansi"
+	"github.com/hookdeck/hookdeck-cli/pkg/connection"
+	"github.com/hookdeck/hookdeck-cli/pkg/validators"
+)
+
+type connectionRenameCmd struct {
+	cmd    *cobra.Command
+	dryRun bool
+}
+
+func newConnectionRenameCmd() *connectionRenameCmd {
+	rc := &connectionRenameCmd{}
+
+	rc.cmd = &cobra.Command{
+		Use:   "rename <connection> <new-name>",
+		Args:  validators.ExactArgs(2),
+		Short: "Rename a connection",
+		Long: `Rename a connection, leaving its other settings unchanged. Connection names
+are unique per source, so the rename fails if another connection of the
+source already has the new name. The full name of the connection changes
+along with its name when it includes it.`,
+		Example: `  hookdeck connection rename stripe_to_cli stripe_to_local
+  hookdeck connection rename "stripe -> orders" orders_v2 --dry-run`,
+		RunE: rc.runConnectionRenameCmd,
+	}
+	rc.cmd.Flags().BoolVar(&rc.dryRun, "dry-run", false, "Show the names that would change without renaming")
+
+	return rc
+}
+
+func (rc *connectionRenameCmd) runConnectionRenameCmd(cmd *cobra.Command, args []string) error {
+	if err := Config.Profile.ValidateAPIKey(); err != nil {
+		return err
+	}
+
+	client := Config.GetClient()
+	conn, err := connection.Get(client, args[0])
+	if err != nil {
+		return err
+	}
+	rename, err := connection.PlanRename(client, conn, args[1])
+	if err != nil {
+		return err
+	}
+
+	oldName := ""
+	if conn.Name != nil {
+		oldName = *conn.Name
+	}
+	changes := []renameChange{{"connection", conn.Id, "name", oldName, rename.Name}}
+	if conn.FullName != nil && *conn.FullName != rename.FullName {
+		changes = append(changes, renameChange{"connection", conn.Id, "full_name", *conn.FullName, rename.FullName})
+	}
+	printRenameChanges(rc.dryRun, fmt.Sprintf("Renaming the connection %s", args[0]), changes)
+	if listen := Config.GetListenDefaults(); listen.Connection != "" && (listen.Connection == oldName || conn.FullName != nil && listen.Connection == *conn.FullName) {
+		warnListenDefault("connection", listen.Connection)
+	}
+	if rc.dryRun {
+		return nil
+	}
+
+	renamed, err := rename.Apply(client)
+	if err != nil {
+		return err
+	}
+	printInfo("Connection %s renamed to %s", args[0], *renamed.Name)
+
+	return nil
+}
+
+// renameChange is a name changed by renaming a resource.
+type renameChange struct {
+	resourceType string
+	id           string
+	field        string
+	before       string
+	after        string
+}
+
+// printRenameChanges prints the names changed by a rename, grouped by
+// resource, to stdout for a dry run and as information otherwise.
+func printRenameChanges(dryRun bool, title string, changes []renameChange) {
+	out := infoOut()
+	if dryRun {
+		out = os.Stdout
+	}
+
+	color := ansi.Color(out)
+	fmt.Fprintf(out, "%s, this will change:\n\n", title)
+	resource := ""
+	for _, change := range changes {
+		if current := change.resourceType + " " + change.id; current != resource {
+			resource = current
+			fmt.Fprintln(out, color.Bold(resource))
+		}
+		fmt.Fprintln(out, color.Red(fmt.Sprintf("- %s: %s", change.field, change.before)))
+		fmt.Fprintln(out, color.Green(fmt.Sprintf("+ %s: %s", change.field, change.after)))
+	}
+	fmt.Fprintln(out)
+}
+
+// warnListenDefault warns that the [listen] table of the local config still
+// refers to a resource by its old name.
+func warnListenDefault(key string, name string) {
+	color := ansi.Color(ansi.StatusWriter)
+	fmt.Fprintln(ansi.StatusWriter, color.Yellow(fmt.Sprintf("Warning: the listen %s default of %s is %q, update it to the new name", key, Config.LocalConfigFile, name)))
+}
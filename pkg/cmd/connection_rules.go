@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+
+	hookdecksdk "github.com/hookdeck/hookdeck-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/connection"
+	"github.com/hookdeck/hookdeck-cli/pkg/output"
+	"github.com/hookdeck/hookdeck-cli/pkg/validators"
+)
+
+type connectionRulesCmd struct {
+	cmd *cobra.Command
+}
+
+func newConnectionRulesCmd() *connectionRulesCmd {
+	rc := &connectionRulesCmd{}
+
+	rc.cmd = &cobra.Command{
+		Use:   "rules",
+		Args:  validators.NoArgs,
+		Short: "Manage the rules of a connection",
+		Long: `Manage the rules of a connection one at a time.
+
+Rules are written in JSON, in the same format as the Hookdeck API, e.g.
+{"type":"retry","strategy":"linear","count":5,"interval":60000}. Rules are
+identified by their index, as shown by "hookdeck connection rules list".`,
+	}
+
+	rc.cmd.AddCommand(newConnectionRulesListCmd().cmd)
+	rc.cmd.AddCommand(newConnectionRulesAddCmd().cmd)
+	rc.cmd.AddCommand(newConnectionRulesUpdateCmd().cmd)
+	rc.cmd.AddCommand(newConnectionRulesRemoveCmd().cmd)
+	rc.cmd.AddCommand(newConnectionRulesMoveCmd().cmd)
+
+	return rc
+}
+
+func newRulesOutputOptions() output.Options {
+	return output.Options{
+		DefaultColumns:   []string{"index", "type", "config"},
+		AvailableColumns: []string{"index", "type", "config"},
+	}
+}
+
+func printRules(options output.Options, rules []*hookdecksdk.Rule) error {
+	rows := make([]output.Row, len(rules))
+	for i, rule := range rules {
+		config := map[string]interface{}{}
+		data, err := json.Marshal(rule)
+		if err != nil {
+			return err
+		}
+		json.Unmarshal(data, &config)
+		delete(config, "type")
+		data, _ = json.Marshal(config)
+
+		rows[i] = output.Row{
+			"index":  strconv.Itoa(i),
+			"type":   rule.Type,
+			"config": string(data),
+		}
+	}
+
+	return options.Print(os.Stdout, rules, rows)
+}
+
+func updateConnectionRules(options output.Options, conn *hookdecksdk.Connection, rules []*hookdecksdk.Rule) error {
+	updated, err := connection.UpdateRules(Config.GetClient(), conn, rules)
+	if err != nil {
+		return err
+	}
+
+	return printRules(options, updated.Rules)
+}
+
+func parseRuleIndex(value string) (int, error) {
+	index, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid rule index %q", value)
+	}
+	return index, nil
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/connection"
+	"github.com/hookdeck/hookdeck-cli/pkg/output"
+	"github.com/hookdeck/hookdeck-cli/pkg/validators"
+)
+
+type connectionRulesAddCmd struct {
+	cmd    *cobra.Command
+	rule   string
+	index  int
+	output output.Options
+}
+
+func newConnectionRulesAddCmd() *connectionRulesAddCmd {
+	ac := &connectionRulesAddCmd{
+		output: newRulesOutputOptions(),
+	}
+
+	ac.cmd = &cobra.Command{
+		Use:   "add <connection>",
+		Args:  validators.ExactArgs(1),
+		Short: "Add a rule to a connection",
+		Example: `  hookdeck connection rules add stripe_to_cli --rule '{"type":"retry","strategy":"linear","count":5,"interval":60000}'
+  hookdeck connection rules add stripe_to_cli --rule @filter.json --index 0`,
+		RunE: ac.runConnectionRulesAddCmd,
+	}
+	ac.cmd.Flags().StringVar(&ac.rule, "rule", "", "The rule in JSON, or @path to read it from a file")
+	ac.cmd.Flags().IntVar(&ac.index, "index", -1, "Position to insert the rule at (default: after the existing rules)")
+	ac.cmd.MarkFlagRequired("rule")
+	ac.output.AddFlags(ac.cmd.Flags())
+
+	return ac
+}
+
+func (ac *connectionRulesAddCmd) runConnectionRulesAddCmd(cmd *cobra.Command, args []string) error {
+	if err := ac.output.Validate(); err != nil {
+		return err
+	}
+
+	if err := Config.Profile.ValidateAPIKey(); err != nil {
+		return err
+	}
+
+	data, err := readBodyFlag(ac.rule)
+	if err != nil {
+		return err
+	}
+	rule, err := connection.ParseRule(data)
+	if err != nil {
+		return err
+	}
+
+	conn, err := connection.Get(Config.GetClient(), args[0])
+	if err != nil {
+		return err
+	}
+
+	rules, err := connection.InsertRule(conn.Rules, rule, ac.index)
+	if err != nil {
+		return err
+	}
+
+	return updateConnectionRules(ac.output, conn, rules)
+}
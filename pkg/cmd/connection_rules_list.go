@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/connection"
+	"github.com/hookdeck/hookdeck-cli/pkg/output"
+	"github.com/hookdeck/hookdeck-cli/pkg/validators"
+)
+
+type connectionRulesListCmd struct {
+	cmd    *cobra.Command
+	output output.Options
+}
+
+func newConnectionRulesListCmd() *connectionRulesListCmd {
+	lc := &connectionRulesListCmd{
+		output: newRulesOutputOptions(),
+	}
+
+	lc.cmd = &cobra.Command{
+		Use:     "list <connection>",
+		Args:    validators.ExactArgs(1),
+		Short:   "List the rules of a connection",
+		Example: `  hookdeck connection rules list stripe_to_cli`,
+		RunE:    lc.runConnectionRulesListCmd,
+	}
+	lc.output.AddFlags(lc.cmd.Flags())
+
+	return lc
+}
+
+func (lc *connectionRulesListCmd) runConnectionRulesListCmd(cmd *cobra.Command, args []string) error {
+	if err := lc.output.Validate(); err != nil {
+		return err
+	}
+
+	if err := Config.Profile.ValidateAPIKey(); err != nil {
+		return err
+	}
+
+	conn, err := connection.Get(Config.GetClient(), args[0])
+	if err != nil {
+		return err
+	}
+
+	return printRules(lc.output, conn.Rules)
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/connection"
+	"github.com/hookdeck/hookdeck-cli/pkg/output"
+	"github.com/hookdeck/hookdeck-cli/pkg/validators"
+)
+
+type connectionRulesMoveCmd struct {
+	cmd    *cobra.Command
+	output output.Options
+}
+
+func newConnectionRulesMoveCmd() *connectionRulesMoveCmd {
+	mc := &connectionRulesMoveCmd{
+		output: newRulesOutputOptions(),
+	}
+
+	mc.cmd = &cobra.Command{
+		Use:     "move <connection> <from> <to>",
+		Args:    validators.ExactArgs(3),
+		Short:   "Change the position of a rule",
+		Example: `  hookdeck connection rules move stripe_to_cli 2 0`,
+		RunE:    mc.runConnectionRulesMoveCmd,
+	}
+	mc.output.AddFlags(mc.cmd.Flags())
+
+	return mc
+}
+
+func (mc *connectionRulesMoveCmd) runConnectionRulesMoveCmd(cmd *cobra.Command, args []string) error {
+	if err := mc.output.Validate(); err != nil {
+		return err
+	}
+
+	from, err := parseRuleIndex(args[1])
+	if err != nil {
+		return err
+	}
+	to, err := parseRuleIndex(args[2])
+	if err != nil {
+		return err
+	}
+
+	if err := Config.Profile.ValidateAPIKey(); err != nil {
+		return err
+	}
+
+	conn, err := connection.Get(Config.GetClient(), args[0])
+	if err != nil {
+		return err
+	}
+
+	rules, err := connection.MoveRule(conn.Rules, from, to)
+	if err != nil {
+		return err
+	}
+
+	return updateConnectionRules(mc.output, conn, rules)
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"errors"
+
+	hookdecksdk "github.com/hookdeck/hookdeck-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/connection"
+	"github.com/hookdeck/hookdeck-cli/pkg/output"
+	"github.com/hookdeck/hookdeck-cli/pkg/validators"
+)
+
+type connectionRulesRemoveCmd struct {
+	cmd      *cobra.Command
+	ruleType string
+	output   output.Options
+}
+
+func newConnectionRulesRemoveCmd() *connectionRulesRemoveCmd {
+	rc := &connectionRulesRemoveCmd{
+		output: newRulesOutputOptions(),
+	}
+
+	rc.cmd = &cobra.Command{
+		Use:   "remove <connection> [index]",
+		Args:  validators.MaximumNArgs(2),
+		Short: "Remove a rule from a connection",
+		Example: `  hookdeck connection rules remove stripe_to_cli 1
+  hookdeck connection rules remove stripe_to_cli --type filter`,
+		RunE: rc.runConnectionRulesRemoveCmd,
+	}
+	rc.cmd.Flags().StringVar(&rc.ruleType, "type", "", "Remove all the rules of this type instead of a single rule")
+	rc.output.AddFlags(rc.cmd.Flags())
+
+	return rc
+}
+
+func (rc *connectionRulesRemoveCmd) runConnectionRulesRemoveCmd(cmd *cobra.Command, args []string) error {
+	if err := rc.output.Validate(); err != nil {
+		return err
+	}
+
+	if len(args) == 0 {
+		return errors.New("a connection is required")
+	}
+	if (len(args) == 2) == (rc.ruleType != "") {
+		return errors.New("either a rule index or --type is required")
+	}
+
+	if err := Config.Profile.ValidateAPIKey(); err != nil {
+		return err
+	}
+
+	conn, err := connection.Get(Config.GetClient(), args[0])
+	if err != nil {
+		return err
+	}
+
+	var rules []*hookdecksdk.Rule
+	if rc.ruleType != "" {
+		rules, err = connection.RemoveRulesByType(conn.Rules, rc.ruleType)
+	} else {
+		var index int
+		index, err = parseRuleIndex(args[1])
+		if err == nil {
+			rules, err = connection.RemoveRule(conn.Rules, index)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	return updateConnectionRules(rc.output, conn, rules)
+}
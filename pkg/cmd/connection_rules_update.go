@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/connection"
+	"github.com/hookdeck/hookdeck-cli/pkg/output"
+	"github.com/hookdeck/hookdeck-cli/pkg/validators"
+)
+
+type connectionRulesUpdateCmd struct {
+	cmd    *cobra.Command
+	rule   string
+	output output.Options
+}
+
+func newConnectionRulesUpdateCmd() *connectionRulesUpdateCmd {
+	uc := &connectionRulesUpdateCmd{
+		output: newRulesOutputOptions(),
+	}
+
+	uc.cmd = &cobra.Command{
+		Use:     "update <connection> <index>",
+		Args:    validators.ExactArgs(2),
+		Short:   "Replace a rule of a connection",
+		Example: `  hookdeck connection rules update stripe_to_cli 0 --rule '{"type":"delay","delay":5000}'`,
+		RunE:    uc.runConnectionRulesUpdateCmd,
+	}
+	uc.cmd.Flags().StringVar(&uc.rule, "rule", "", "The rule in JSON, or @path to read it from a file")
+	uc.cmd.MarkFlagRequired("rule")
+	uc.output.AddFlags(uc.cmd.Flags())
+
+	return uc
+}
+
+func (uc *connectionRulesUpdateCmd) runConnectionRulesUpdateCmd(cmd *cobra.Command, args []string) error {
+	if err := uc.output.Validate(); err != nil {
+		return err
+	}
+
+	index, err := parseRuleIndex(args[1])
+	if err != nil {
+		return err
+	}
+
+	if err := Config.Profile.ValidateAPIKey(); err != nil {
+		return err
+	}
+
+	data, err := readBodyFlag(uc.rule)
+	if err != nil {
+		return err
+	}
+	rule, err := connection.ParseRule(data)
+	if err != nil {
+		return err
+	}
+
+	conn, err := connection.Get(Config.GetClient(), args[0])
+	if err != nil {
+		return err
+	}
+
+	rules, err := connection.ReplaceRule(conn.Rules, rule, index)
+	if err != nil {
+		return err
+	}
+
+	return updateConnectionRules(uc.output, conn, rules)
+}
@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/validators"
+)
+
+type debugCmd struct {
+	cmd *cobra.Command
+}
+
+func newDebugCmd() *debugCmd {
+	dc := &debugCmd{}
+
+	dc.cmd = &cobra.Command{
+		Use:   "debug",
+		Args:  validators.NoArgs,
+		Short: "Collect information to debug the CLI",
+	}
+
+	dc.cmd.AddCommand(newDebugBundleCmd().cmd)
+
+	return dc
+}
@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/ansi"
+	"github.com/hookdeck/hookdeck-cli/pkg/bundle"
+	"github.com/hookdeck/hookdeck-cli/pkg/event"
+	"github.com/hookdeck/hookdeck-cli/pkg/validators"
+)
+
+type debugBundleCmd struct {
+	cmd *cobra.Command
+
+	output string
+	events int
+	logs   []string
+}
+
+func newDebugBundleCmd() *debugBundleCmd {
+	bc := &debugBundleCmd{}
+
+	bc.cmd = &cobra.Command{
+		Use:   "bundle",
+		Args:  validators.NoArgs,
+		Short: "Collect a debug bundle to attach to bug reports",
+		Long: `Collect a debug bundle to attach to bug reports, as a zip file with:
+
+  - the version and platform of the CLI
+  - the global and local config files, secrets redacted
+  - the end of the log file set with --log-file, and of those given with --log
+  - the metadata of the last events of the project, without their data
+
+The files collected are listed, with the content of the redacted config
+files, and you're asked which ones to include. Use --yes to include them all.
+Files that can't be collected are reported in errors.txt instead of failing
+the bundle.`,
+		Example: `  hookdeck debug bundle
+  hookdeck debug bundle --log ~/Library/Logs/Hookdeck/stripe.log --events 50
+  hookdeck debug bundle --yes --output bug.zip`,
+		RunE: bc.runDebugBundleCmd,
+	}
+
+	bc.cmd.Flags().StringVarP(&bc.output, "output", "o", "", "Path of the zip file (default \"hookdeck-debug-<timestamp>.zip\")")
+	bc.cmd.Flags().IntVar(&bc.events, "events", 20, "Number of recent events to include the metadata of, 0 to include none")
+	bc.cmd.Flags().StringArrayVar(&bc.logs, "log", []string{}, "Log file to include the end of, can be repeated")
+
+	return bc
+}
+
+func (bc *debugBundleCmd) runDebugBundleCmd(cmd *cobra.Command, args []string) error {
+	if bc.events < 0 || bc.events > 250 {
+		return fmt.Errorf("--events must be between 0 and 250")
+	}
+	path := bc.output
+	if path == "" {
+		path = fmt.Sprintf("hookdeck-debug-%s.zip", time.Now().Format("20060102-150405"))
+	}
+
+	entries, errs := bc.collect()
+	if len(errs) > 0 {
+		entries = append(entries, bundle.Errors(errs))
+	}
+
+	entries, err := selectBundleEntries(entries)
+	if err != nil {
+		return err
+	}
+
+	if err := bundle.Write(path, entries); err != nil {
+		return err
+	}
+	printInfo("Wrote the debug bundle to %s", ansi.Bold(path))
+
+	return nil
+}
+
+// collect returns the entries of the bundle and the errors collecting
+// the ones missing.
+func (bc *debugBundleCmd) collect() ([]bundle.Entry, []error) {
+	entries := []bundle.Entry{bundle.Info()}
+	var errs []error
+
+	configs := []struct{ name, path string }{
+		{"config/global.toml", Config.GlobalConfigFile},
+		{"config/local.toml", Config.LocalConfigFile},
+	}
+	for _, config := range configs {
+		if config.path == "" {
+			continue
+		}
+		entry, err := bundle.ConfigFile(config.name, config.path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("config %s: %w", config.path, err))
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	logs := bc.logs
+	if Config.LogFile != "" {
+		logs = append([]string{Config.LogFile}, logs...)
+	}
+	for _, path := range logs {
+		entry, err := bundle.LogFile(path, bundle.DefaultMaxLogBytes)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("log %s: %w", path, err))
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	if bc.events > 0 {
+		if err := Config.Profile.ValidateAPIKey(); err != nil {
+			errs = append(errs, fmt.Errorf("events: %w", err))
+			return entries, errs
+		}
+		events, err := event.Recent(context.Background(), Config.GetClient(), bc.events)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("events: %w", err))
+			return entries, errs
+		}
+		entry, err := bundle.Events(events)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("events: %w", err))
+			return entries, errs
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, errs
+}
+
+// selectBundleEntries lists the entries of the bundle, printing the
+// redacted config files so they can be reviewed, and asks which ones to
+// include. They're all included with --yes.
+func selectBundleEntries(entries []bundle.Entry) ([]bundle.Entry, error) {
+	if Config.Yes {
+		return entries, nil
+	}
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return nil, errConfirmationRequired
+	}
+
+	color := ansi.Color(os.Stdout)
+	options := make([]string, len(entries))
+	for i, entry := range entries {
+		options[i] = entry.Name
+		fmt.Printf("%s %s\n", color.Bold(entry.Name), color.Faint(fmt.Sprintf("(%s, %d bytes)", entry.Description, len(entry.Data))))
+		if strings.HasPrefix(entry.Name, "config/") {
+			fmt.Printf("%s\n", strings.TrimRight(string(entry.Data), "\n"))
+		}
+	}
+	fmt.Println()
+
+	selected := []string{}
+	prompt := &survey.MultiSelect{
+		Message: "Files to include in the bundle:",
+		Options: options,
+		Default: options,
+	}
+	if err := survey.AskOne(prompt, &selected); err != nil {
+		return nil, err
+	}
+	if len(selected) == 0 {
+		return nil, errors.New("canceled")
+	}
+
+	included := map[string]bool{}
+	for _, name := range selected {
+		included[name] = true
+	}
+	var result []bundle.Entry
+	for _, entry := range entries {
+		if included[entry.Name] {
+			result = append(result, entry)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/validators"
+)
+
+type destinationCmd struct {
+	cmd *cobra.Command
+}
+
+func newDestinationCmd() *destinationCmd {
+	dc := &destinationCmd{}
+
+	dc.cmd = &cobra.Command{
+		Use:     "destination",
+		Aliases: []string{"destinations"},
+		Args:    validators.NoArgs,
+		Short:   "Manage your destinations",
+	}
+
+	dc.cmd.AddCommand(newDestinationRotateSecretCmd().cmd)
+
+	return dc
+}
@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	hookdecksdk "github.com/hookdeck/hookdeck-go-sdk"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/ansi"
+	"github.com/hookdeck/hookdeck-cli/pkg/destination"
+	"github.com/hookdeck/hookdeck-cli/pkg/validators"
+)
+
+type destinationRotateSecretCmd struct {
+	cmd         *cobra.Command
+	secret      string
+	secretStdin bool
+	secretEnv   string
+}
+
+func newDestinationRotateSecretCmd() *destinationRotateSecretCmd {
+	dc := &destinationRotateSecretCmd{}
+
+	dc.cmd = &cobra.Command{
+		Use:   "rotate-secret <destination>",
+		Args:  validators.ExactArgs(1),
+		Short: "Rotate the secret of a destination's auth method",
+		Long: `Rotate the secret of a destination's auth method: the token of bearer
+token auth, the password of basic auth, the API key of API key auth, or the
+signing secret of custom signature auth.
+
+The new secret is read from --secret-stdin, --secret-env or --secret, and
+prompted for otherwise. Prefer the first two to keep secrets out of your shell
+history.`,
+		Example: `  hookdeck destination rotate-secret my-api --secret-env NEW_API_TOKEN
+  vault read -field=token secret/my-api | hookdeck destination rotate-secret my-api --secret-stdin --yes`,
+		RunE: dc.runDestinationRotateSecretCmd,
+	}
+	secretFlagVar(dc.cmd.Flags(), &dc.secret, "secret", "", "The new secret")
+	dc.cmd.Flags().BoolVar(&dc.secretStdin, "secret-stdin", false, "Read the new secret from stdin")
+	dc.cmd.Flags().StringVar(&dc.secretEnv, "secret-env", "", "Read the new secret from this environment variable")
+
+	return dc
+}
+
+func (dc *destinationRotateSecretCmd) runDestinationRotateSecretCmd(cmd *cobra.Command, args []string) error {
+	if err := Config.Profile.ValidateAPIKey(); err != nil {
+		return err
+	}
+
+	client := Config.GetClient()
+	dest, err := destination.Get(client, args[0])
+	if err != nil {
+		return err
+	}
+	field, err := destination.SecretField(dest.AuthMethod)
+	if err != nil {
+		return fmt.Errorf("destination %q: %w", dest.Name, err)
+	}
+
+	secret, err := dc.readSecret(field)
+	if err != nil {
+		return err
+	}
+
+	previous, err := destination.RotateSecret(dest.AuthMethod, secret)
+	if err != nil {
+		return err
+	}
+	if previous == secret {
+		return errors.New("the new secret is the same as the current one")
+	}
+
+	out := infoOut()
+	color := ansi.Color(out)
+	fmt.Fprintf(out, "Destination %s (%s)\n", color.Bold(dest.Name), dest.AuthMethod.Type)
+	fmt.Fprintln(out, color.Red(fmt.Sprintf("- %s: %s", field, destination.Redact(previous))))
+	fmt.Fprintln(out, color.Green(fmt.Sprintf("+ %s: %s", field, destination.Redact(secret))))
+
+	// stdin is consumed by the secret, it can't be used for the confirmation
+	if dc.secretStdin && !Config.Yes {
+		return errConfirmationRequired
+	}
+	if err := confirm("Rotate the secret?"); err != nil {
+		return err
+	}
+
+	_, err = client.Destination.Update(context.Background(), dest.Id, &hookdecksdk.DestinationUpdateRequest{
+		AuthMethod: hookdecksdk.Optional(*dest.AuthMethod),
+	})
+	if err != nil {
+		return err
+	}
+
+	printInfo("Secret rotated")
+
+	return nil
+}
+
+// readSecret returns the new secret from the flags, or prompts for it.
+func (dc *destinationRotateSecretCmd) readSecret(field string) (string, error) {
+	sources := 0
+	for _, set := range []bool{dc.secret != "", dc.secretStdin, dc.secretEnv != ""} {
+		if set {
+			sources++
+		}
+	}
+	if sources > 1 {
+		return "", errors.New("only one of --secret, --secret-stdin and --secret-env can be used")
+	}
+
+	secret := dc.secret
+	switch {
+	case dc.secretStdin:
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			return "", fmt.Errorf("reading the secret from stdin: %w", err)
+		}
+		secret = strings.TrimRight(line, "\r\n")
+	case dc.secretEnv != "":
+		secret = os.Getenv(dc.secretEnv)
+		if secret == "" {
+			return "", fmt.Errorf("environment variable %s is not set", dc.secretEnv)
+		}
+	case secret == "":
+		if !term.IsTerminal(int(os.Stdin.Fd())) {
+			return "", errors.New("no secret provided, use --secret-stdin or --secret-env")
+		}
+		err := survey.AskOne(&survey.Password{
+			Message: fmt.Sprintf("New %s:", field),
+		}, &secret, survey.WithValidator(survey.Required))
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if secret == "" {
+		return "", errors.New("the new secret can't be empty")
+	}
+
+	return secret, nil
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/docs"
+)
+
+type docsCmd struct {
+	cmd *cobra.Command
+}
+
+func newDocsCmd() *docsCmd {
+	dc := &docsCmd{}
+
+	dc.cmd = &cobra.Command{
+		Use:   "docs [command...]",
+		Short: "Browse the reference of the commands offline",
+		Long: `Show the reference of all the commands, or of the given command and its
+subcommands: their description, usage, flags and examples.
+
+The reference is shown in $PAGER, or less, when the output is a terminal.`,
+		Example: `  hookdeck docs
+  hookdeck docs connection promote`,
+		RunE: dc.runDocsCmd,
+	}
+
+	dc.cmd.AddCommand(newDocsManCmd().cmd)
+
+	return dc
+}
+
+func (dc *docsCmd) runDocsCmd(cmd *cobra.Command, args []string) error {
+	target, rest, err := rootCmd.Find(args)
+	if err != nil {
+		return err
+	}
+	if len(rest) > 0 {
+		return fmt.Errorf("unknown command %q", strings.Join(args, " "))
+	}
+
+	reference := &bytes.Buffer{}
+	if err := docs.WriteReference(reference, target); err != nil {
+		return err
+	}
+
+	if !term.IsTerminal(int(os.Stdout.Fd())) {
+		_, err := os.Stdout.Write(reference.Bytes())
+		return err
+	}
+	return page(reference.Bytes())
+}
+
+// page shows text in $PAGER, or less, falling back to printing it when no
+// pager is available.
+func page(text []byte) error {
+	pager := strings.Fields(os.Getenv("PAGER"))
+	if len(pager) == 0 {
+		pager = []string{"less"}
+	}
+	if _, err := exec.LookPath(pager[0]); err != nil {
+		_, err := os.Stdout.Write(text)
+		return err
+	}
+
+	cmd := exec.Command(pager[0], pager[1:]...)
+	cmd.Stdin = bytes.NewReader(text)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
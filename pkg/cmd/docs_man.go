@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/docs"
+	"github.com/hookdeck/hookdeck-cli/pkg/validators"
+)
+
+type docsManCmd struct {
+	cmd *cobra.Command
+	dir string
+}
+
+func newDocsManCmd() *docsManCmd {
+	dc := &docsManCmd{}
+
+	dc.cmd = &cobra.Command{
+		Use:   "man",
+		Args:  validators.NoArgs,
+		Short: "Generate the man pages of the commands",
+		Long: `Generate a man page for each command to --dir, e.g. hookdeck-listen.1.
+
+The date of the pages is read from SOURCE_DATE_EPOCH when set, for
+reproducible builds.`,
+		Example: `  hookdeck docs man --dir ./manpages
+  man ./manpages/hookdeck-listen.1`,
+		RunE: dc.runDocsManCmd,
+	}
+
+	dc.cmd.Flags().StringVar(&dc.dir, "dir", "manpages", "Folder to write the man pages to")
+
+	return dc
+}
+
+func (dc *docsManCmd) runDocsManCmd(cmd *cobra.Command, args []string) error {
+	date := time.Now()
+	if epoch, err := strconv.ParseInt(os.Getenv("SOURCE_DATE_EPOCH"), 10, 64); err == nil {
+		date = time.Unix(epoch, 0).UTC()
+	}
+
+	if err := docs.GenManTree(rootCmd, dc.dir, date); err != nil {
+		return err
+	}
+
+	printInfo("Man pages written to %s", dc.dir)
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/doctor"
+	"github.com/hookdeck/hookdeck-cli/pkg/validators"
+)
+
+type doctorCmd struct {
+	cmd *cobra.Command
+
+	port    string
+	timeout time.Duration
+	output  string
+}
+
+func newDoctorCmd() *doctorCmd {
+	dc := &doctorCmd{}
+
+	dc.cmd = &cobra.Command{
+		Use:   "doctor",
+		Args:  validators.NoArgs,
+		Short: "Diagnose the connectivity of the CLI to Hookdeck",
+		Long: `Diagnose the connectivity of the CLI to Hookdeck.
+
+Checks that the API and websocket endpoints are reachable, that the API key
+of the profile is valid, that the system clock is in sync with the API, and
+that the local server is listening. The proxy settings in use are listed.
+
+The report includes no secret, so it can be attached to support tickets. The
+command fails when any check fails.`,
+		Example: `  hookdeck doctor
+  hookdeck doctor --port 3000
+  hookdeck doctor --output json > report.json`,
+		RunE: dc.runDoctorCmd,
+	}
+
+	dc.cmd.Flags().StringVar(&dc.port, "port", "", "Port or URL of the local server to check (defaults to the listen port of the local config)")
+	dc.cmd.Flags().DurationVar(&dc.timeout, "timeout", 10*time.Second, "Timeout of each check")
+	dc.cmd.Flags().StringVarP(&dc.output, "output", "o", "text", "Output format of the report (text, json)")
+
+	return dc
+}
+
+func (dc *doctorCmd) runDoctorCmd(cmd *cobra.Command, args []string) error {
+	if dc.output != "text" && dc.output != "json" {
+		return fmt.Errorf("unsupported output %q, expected text or json", dc.output)
+	}
+
+	port := dc.port
+	if port == "" {
+		port = Config.GetListenDefaults().Port
+	}
+	var localURL *url.URL
+	if port != "" {
+		var err error
+		localURL, err = parseForwardURL(port)
+		if err != nil {
+			return err
+		}
+	}
+
+	report := doctor.Run(doctor.Options{
+		APIBaseURL: Config.APIBaseURL,
+		WSBaseURL:  Config.WSBaseURL,
+		APIKey:     Config.Profile.APIKey,
+		TeamID:     Config.Profile.TeamID,
+		Proxy:      Config.Proxy,
+		Insecure:   Config.Insecure,
+		LocalURL:   localURL,
+		Timeout:    dc.timeout,
+	})
+
+	if dc.output == "json" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+	} else {
+		report.Print(os.Stdout)
+	}
+
+	if report.Failed() {
+		return fmt.Errorf("some checks failed")
+	}
+	return nil
+}
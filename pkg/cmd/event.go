@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/validators"
+)
+
+type eventCmd struct {
+	cmd *cobra.Command
+}
+
+func newEventCmd() *eventCmd {
+	ec := &eventCmd{}
+
+	ec.cmd = &cobra.Command{
+		Use:   "event",
+		Args:  validators.NoArgs,
+		Short: "Manage your events",
+	}
+
+	ec.cmd.AddCommand(newEventSendCmd().cmd)
+	ec.cmd.AddCommand(newEventSchemaCmd().cmd)
+	ec.cmd.AddCommand(newEventExportCmd().cmd)
+	ec.cmd.AddCommand(newEventImportCmd().cmd)
+	ec.cmd.AddCommand(newEventStatsCmd().cmd)
+	ec.cmd.AddCommand(newEventRetryCmd().cmd)
+	ec.cmd.AddCommand(newEventDiffCmd().cmd)
+
+	return ec
+}
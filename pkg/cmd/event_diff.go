@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"context"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/event"
+	"github.com/hookdeck/hookdeck-cli/pkg/output"
+	"github.com/hookdeck/hookdeck-cli/pkg/validators"
+)
+
+type eventDiffCmd struct {
+	cmd    *cobra.Command
+	output output.Options
+}
+
+func newEventDiffCmd() *eventDiffCmd {
+	ec := &eventDiffCmd{
+		output: output.Options{
+			DefaultColumns:   []string{"change", "path", "before", "after"},
+			AvailableColumns: []string{"change", "path", "before", "after"},
+		},
+	}
+
+	ec.cmd = &cobra.Command{
+		Use:   "diff <event-id> <event-id>",
+		Args:  validators.ExactArgs(2),
+		Short: "Compare the requests of two events",
+		Long: `Compare the path, query, headers and body of the requests of two events,
+e.g. to find out why one delivery succeeded and a similar one failed.
+
+JSON bodies are compared structurally: each added, removed or changed value is
+listed with its path, such as body.data.items[0].id. Header names are compared
+case-insensitively.`,
+		Example: `  hookdeck event diff evt_2Lq8Vd0mXo evt_9Hc3Ks1pRt
+  hookdeck event diff evt_2Lq8Vd0mXo evt_9Hc3Ks1pRt --output json`,
+		RunE: ec.runEventDiffCmd,
+	}
+	ec.output.AddFlags(ec.cmd.Flags())
+
+	return ec
+}
+
+func (ec *eventDiffCmd) runEventDiffCmd(cmd *cobra.Command, args []string) error {
+	if err := ec.output.Validate(); err != nil {
+		return err
+	}
+
+	if err := Config.Profile.ValidateAPIKey(); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	client := Config.GetClient()
+	before, err := event.Get(ctx, client, args[0])
+	if err != nil {
+		return err
+	}
+	after, err := event.Get(ctx, client, args[1])
+	if err != nil {
+		return err
+	}
+
+	changes := event.Diff(before, after)
+	if changes == nil {
+		changes = []event.Change{}
+	}
+	if len(changes) == 0 && ec.output.Format == output.FormatTable {
+		printInfo("The requests of the events are the same")
+		return nil
+	}
+
+	rows := make([]output.Row, len(changes))
+	for i, change := range changes {
+		rows[i] = output.Row{
+			"change": change.Kind,
+			"path":   change.Path,
+			"before": event.FormatValue(change.Before),
+			"after":  event.FormatValue(change.After),
+		}
+	}
+
+	return ec.output.Print(os.Stdout, changes, rows)
+}
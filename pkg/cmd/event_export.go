@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	hookdecksdk "github.com/hookdeck/hookdeck-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/ansi"
+	"github.com/hookdeck/hookdeck-cli/pkg/event"
+	"github.com/hookdeck/hookdeck-cli/pkg/hookdeck"
+	"github.com/hookdeck/hookdeck-cli/pkg/source"
+	"github.com/hookdeck/hookdeck-cli/pkg/validators"
+)
+
+type eventExportCmd struct {
+	cmd         *cobra.Command
+	source      string
+	status      string
+	since       string
+	format      string
+	file        string
+	concurrency int
+	cursorFile  string
+}
+
+func newEventExportCmd() *eventExportCmd {
+	ec := &eventExportCmd{}
+
+	ec.cmd = &cobra.Command{
+		Use:   "export",
+		Args:  validators.NoArgs,
+		Short: "Export events with their payloads",
+		Long: `Export events with their payloads and the metadata of their delivery
+attempts, most recent first, as NDJSON or CSV.
+
+Large exports can be resumed with --cursor-file: the cursor of the next page
+is saved to the file after each page, and the export starts from it when the
+file exists. The file is removed once the export is complete.`,
+		Example: `  hookdeck event export --since 24h --file events.ndjson
+  hookdeck event export --source stripe --status failed --format csv --file failed.csv
+  hookdeck event export --since 30d --file events.ndjson --cursor-file .export-cursor`,
+		RunE: ec.runEventExportCmd,
+	}
+	ec.cmd.Flags().StringVar(&ec.source, "source", "", "Only export the events of this source")
+	ec.cmd.Flags().StringVar(&ec.status, "status", "", "Only export the events with this status (scheduled, queued, hold, successful, failed)")
+	ec.cmd.Flags().StringVar(&ec.since, "since", "", "Only export the events created since this duration (e.g. 24h, 7d) or date (e.g. 2024-01-31)")
+	ec.cmd.Flags().StringVar(&ec.format, "format", event.FormatNDJSON, "Export format (ndjson, csv)")
+	ec.cmd.Flags().StringVar(&ec.file, "file", "", "File to write the events to (defaults to stdout)")
+	ec.cmd.Flags().IntVar(&ec.concurrency, "concurrency", hookdeck.DefaultBatchConcurrency, "Number of event payloads retrieved concurrently")
+	ec.cmd.Flags().StringVar(&ec.cursorFile, "cursor-file", "", "File to save the export progress to, to resume it if interrupted")
+
+	return ec
+}
+
+func (ec *eventExportCmd) runEventExportCmd(cmd *cobra.Command, args []string) error {
+	if ec.concurrency < 1 {
+		return fmt.Errorf("invalid concurrency %d, expected a positive value", ec.concurrency)
+	}
+	if err := Config.Profile.ValidateAPIKey(); err != nil {
+		return err
+	}
+
+	opts := event.ExportOptions{Concurrency: ec.concurrency}
+
+	if ec.status != "" {
+		status, err := hookdecksdk.NewEventStatusFromString(strings.ToUpper(ec.status))
+		if err != nil {
+			return fmt.Errorf("invalid status %q, expected one of scheduled, queued, hold, successful, failed", ec.status)
+		}
+		opts.Status = string(status)
+	}
+
+	if ec.since != "" {
+		since, err := parseSince(ec.since, time.Now())
+		if err != nil {
+			return err
+		}
+		opts.Since = since
+	}
+
+	client := Config.GetClient()
+	if ec.source != "" {
+		src, err := source.Get(client, ec.source)
+		if err != nil {
+			return err
+		}
+		opts.SourceID = src.Id
+	}
+
+	if ec.cursorFile != "" {
+		data, err := ioutil.ReadFile(ec.cursorFile)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		opts.Cursor = strings.TrimSpace(string(data))
+	}
+	resuming := opts.Cursor != ""
+
+	out := io.Writer(os.Stdout)
+	if ec.file != "" {
+		// A resumed export is appended to the events already exported
+		flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+		if resuming {
+			flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+		}
+		f, err := os.OpenFile(ec.file, flags, 0644)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+
+	writer, err := event.NewWriter(ec.format, out, !resuming)
+	if err != nil {
+		return err
+	}
+
+	count := 0
+	progress := ansi.NewProgress()
+	err = event.Export(context.Background(), client, opts, func(events []*event.Exported, next string) error {
+		if err := writer.Write(events); err != nil {
+			return err
+		}
+		count += len(events)
+
+		if ec.cursorFile != "" {
+			if next == "" {
+				if err := os.Remove(ec.cursorFile); err != nil && !os.IsNotExist(err) {
+					return err
+				}
+			} else if err := ioutil.WriteFile(ec.cursorFile, []byte(next+"\n"), 0644); err != nil {
+				return err
+			}
+		}
+
+		progress.Update("Exported %d events", count)
+		return nil
+	})
+	progress.Done()
+	if err != nil {
+		if ec.cursorFile != "" && count > 0 {
+			return fmt.Errorf("%w\nRun the command again to resume the export", err)
+		}
+		return err
+	}
+
+	if ec.file != "" {
+		ansi.Status("Wrote %d events to %s", count, ec.file)
+	}
+
+	return nil
+}
+
+// parseSince parses a duration before now, or a date.
+func parseSince(value string, now time.Time) (time.Time, error) {
+	if duration, err := parseDuration(value); err == nil && duration >= 0 {
+		return now.Add(-duration), nil
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid --since %q, expected a duration like 24h or 7d, or a date like 2024-01-31", value)
+}
+
+// parseDuration parses a duration, which can also be given in days (e.g.
+// 7d).
+func parseDuration(value string) (time.Duration, error) {
+	if strings.HasSuffix(value, "d") {
+		if days, err := strconv.Atoi(strings.TrimSuffix(value, "d")); err == nil {
+			return time.Duration(days) * 24 * time.Hour, nil
+		}
+	}
+	return time.ParseDuration(value)
+}
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/ansi"
+	"github.com/hookdeck/hookdeck-cli/pkg/event"
+	"github.com/hookdeck/hookdeck-cli/pkg/hookdeck"
+	"github.com/hookdeck/hookdeck-cli/pkg/source"
+	"github.com/hookdeck/hookdeck-cli/pkg/validators"
+)
+
+type eventImportCmd struct {
+	cmd         *cobra.Command
+	source      string
+	rate        float64
+	concurrency int
+}
+
+func newEventImportCmd() *eventImportCmd {
+	ec := &eventImportCmd{}
+
+	ec.cmd = &cobra.Command{
+		Use:   "import <file>",
+		Args:  validators.ExactArgs(1),
+		Short: "Send exported events to a source",
+		Long: `Send the events of an NDJSON file exported with "hookdeck event export"
+to the URL of a source, for example to reproduce production traffic in a
+staging project. Pass - to read the events from stdin.
+
+Events are signed or authenticated again according to the verification
+config of the source, and are sent with the path, query and headers of the
+original request.`,
+		Example: `  hookdeck event import events.ndjson --source stripe-staging
+  hookdeck event import events.ndjson --source stripe-staging --rate 2`,
+		RunE: ec.runEventImportCmd,
+	}
+	ec.cmd.Flags().StringVar(&ec.source, "source", "", "Name of the source to send the events to")
+	ec.cmd.Flags().Float64Var(&ec.rate, "rate", 10, "Maximum number of events sent per second, 0 for no limit")
+	ec.cmd.Flags().IntVar(&ec.concurrency, "concurrency", hookdeck.DefaultBatchConcurrency, "Number of events sent at once")
+	ec.cmd.MarkFlagRequired("source")
+
+	return ec
+}
+
+func (ec *eventImportCmd) runEventImportCmd(cmd *cobra.Command, args []string) error {
+	if ec.rate < 0 {
+		return fmt.Errorf("invalid rate %v, expected a positive value or 0", ec.rate)
+	}
+	if ec.concurrency < 1 {
+		return fmt.Errorf("invalid concurrency %d, expected a positive value", ec.concurrency)
+	}
+	if err := Config.Profile.ValidateAPIKey(); err != nil {
+		return err
+	}
+
+	in := io.Reader(os.Stdin)
+	if args[0] != "-" {
+		f, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	events, err := event.ReadNDJSON(in)
+	if err != nil {
+		return err
+	}
+	if len(events) == 0 {
+		return fmt.Errorf("no events to import in %s", args[0])
+	}
+
+	src, err := source.Get(Config.GetClient(), ec.source)
+	if err != nil {
+		return err
+	}
+
+	opts := event.ImportOptions{Rate: ec.rate, Concurrency: ec.concurrency}
+	progress := ansi.NewProgress()
+	if progress.Enabled() {
+		opts.Progress = func(done, total int) {
+			progress.Update("%s %d/%d", progressBar(done, total, 30), done, total)
+		}
+	}
+
+	err = event.Import(context.Background(), src, events, opts)
+	progress.Done()
+
+	var batchErr *hookdeck.BatchError
+	if errors.As(err, &batchErr) {
+		printInfo("Imported %d of %d events to %s", len(events)-len(batchErr.Errors), len(events), src.Name)
+		return err
+	} else if err != nil {
+		return err
+	}
+
+	printInfo("Imported %d events to %s", len(events), src.Name)
+
+	return nil
+}
+
+// progressBar renders a progress bar of the given width, like [=====>    ].
+func progressBar(done, total, width int) string {
+	filled := width * done / total
+	bar := strings.Repeat("=", filled)
+	if filled < width {
+		bar += ">" + strings.Repeat(" ", width-filled-1)
+	}
+	return "[" + bar + "]"
+}
@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	hookdecksdk "github.com/hookdeck/hookdeck-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/ansi"
+	"github.com/hookdeck/hookdeck-cli/pkg/event"
+	"github.com/hookdeck/hookdeck-cli/pkg/hookdeck"
+	"github.com/hookdeck/hookdeck-cli/pkg/source"
+)
+
+type eventRetryCmd struct {
+	cmd            *cobra.Command
+	bulk           bool
+	source         string
+	status         string
+	since          string
+	rate           string
+	concurrency    int
+	checkpointFile string
+}
+
+func newEventRetryCmd() *eventRetryCmd {
+	ec := &eventRetryCmd{}
+
+	ec.cmd = &cobra.Command{
+		Use:   "retry [event-id...]",
+		Short: "Retry events",
+		Long: `Retry the given events, or with --bulk all the events matching the
+filters, most recent first.
+
+Retries are paced to --rate, and slowed down when the API rate limits them.
+Large bulk retries can be resumed with --checkpoint-file: the progress is saved
+to the file after each retried event, and the bulk retry continues from it
+when the file exists. The file is removed once the bulk retry is complete.`,
+		Example: `  hookdeck event retry evt_2Lq8Vd0mXo evt_9Hc3Ks1pRt
+  hookdeck event retry --bulk --source stripe --status failed --since 24h
+  hookdeck event retry --bulk --status failed --rate 5/s --checkpoint-file .retry-checkpoint --yes`,
+		RunE: ec.runEventRetryCmd,
+	}
+	ec.cmd.Flags().BoolVar(&ec.bulk, "bulk", false, "Retry all the events matching the filters instead of the given events")
+	ec.cmd.Flags().StringVar(&ec.source, "source", "", "With --bulk, only retry the events of this source")
+	ec.cmd.Flags().StringVar(&ec.status, "status", "failed", "With --bulk, only retry the events with this status (scheduled, queued, hold, successful, failed)")
+	ec.cmd.Flags().StringVar(&ec.since, "since", "", "With --bulk, only retry the events created since this duration (e.g. 24h, 7d) or date (e.g. 2024-01-31)")
+	ec.cmd.Flags().StringVar(&ec.rate, "rate", "10/s", "Maximum rate of retries, e.g. 10/s or 300/m")
+	ec.cmd.Flags().IntVar(&ec.concurrency, "concurrency", hookdeck.DefaultBatchConcurrency, "Number of events retried concurrently")
+	ec.cmd.Flags().StringVar(&ec.checkpointFile, "checkpoint-file", "", "With --bulk, file to save the progress to, to resume the bulk retry if interrupted")
+
+	return ec
+}
+
+func (ec *eventRetryCmd) runEventRetryCmd(cmd *cobra.Command, args []string) error {
+	if ec.bulk == (len(args) > 0) {
+		return errors.New("either event IDs or --bulk is required")
+	}
+	if !ec.bulk {
+		for _, name := range []string{"source", "status", "since", "checkpoint-file"} {
+			if cmd.Flags().Changed(name) {
+				return fmt.Errorf("--%s can only be used with --bulk", name)
+			}
+		}
+	}
+	if ec.concurrency < 1 {
+		return fmt.Errorf("invalid concurrency %d, expected a positive value", ec.concurrency)
+	}
+	rate, err := hookdeck.ParseRate(ec.rate)
+	if err != nil {
+		return err
+	}
+
+	if err := Config.Profile.ValidateAPIKey(); err != nil {
+		return err
+	}
+
+	pacer := hookdeck.NewPacer(rate)
+	client := Config.GetClient()
+	ctx := context.Background()
+
+	if !ec.bulk {
+		progress := ansi.NewProgress()
+		err := event.Retry(ctx, client, args, hookdeck.BatchOptions{
+			Concurrency: ec.concurrency,
+			Pacer:       pacer,
+			Progress: func(done, total int) {
+				progress.Update("Retried %d/%d events", done, total)
+			},
+		})
+		progress.Done()
+		if err != nil {
+			return err
+		}
+		printInfo("Retried %d events", len(args))
+		return nil
+	}
+
+	opts := event.BulkRetryOptions{
+		Concurrency: ec.concurrency,
+		Pacer:       pacer,
+	}
+
+	status, err := hookdecksdk.NewEventStatusFromString(strings.ToUpper(ec.status))
+	if err != nil {
+		return fmt.Errorf("invalid status %q, expected one of scheduled, queued, hold, successful, failed", ec.status)
+	}
+	opts.Status = string(status)
+
+	if ec.since != "" {
+		since, err := parseSince(ec.since, time.Now())
+		if err != nil {
+			return err
+		}
+		opts.Since = since
+	}
+
+	description := fmt.Sprintf("the %s events", strings.ToLower(opts.Status))
+	if ec.source != "" {
+		src, err := source.Get(client, ec.source)
+		if err != nil {
+			return err
+		}
+		opts.SourceID = src.Id
+		description += " of " + src.Name
+	}
+	if ec.since != "" {
+		description += " created since " + opts.Since.Format(time.RFC3339)
+	}
+
+	resuming := false
+	if ec.checkpointFile != "" {
+		data, err := ioutil.ReadFile(ec.checkpointFile)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		if len(data) > 0 {
+			if err := json.Unmarshal(data, &opts.Checkpoint); err != nil {
+				return fmt.Errorf("invalid checkpoint file %s: %w", ec.checkpointFile, err)
+			}
+			resuming = true
+		}
+		opts.Save = func(checkpoint event.Checkpoint) error {
+			data, err := json.Marshal(checkpoint)
+			if err != nil {
+				return err
+			}
+			return ioutil.WriteFile(ec.checkpointFile, data, 0644)
+		}
+	}
+
+	message := fmt.Sprintf("Retry all %s?", description)
+	if resuming {
+		message = fmt.Sprintf("Resume the retry of %s from %s?", description, ec.checkpointFile)
+	}
+	if err := confirm(message); err != nil {
+		return err
+	}
+
+	progress := ansi.NewProgress()
+	opts.Progress = func(retried int) {
+		progress.Update("Retried %d events (%.1f/s)", retried, pacer.Rate())
+	}
+	count, err := event.BulkRetry(ctx, client, opts)
+	progress.Done()
+	if err != nil {
+		if ec.checkpointFile != "" {
+			return fmt.Errorf("%w\nRun the command again to resume the bulk retry", err)
+		}
+		return err
+	}
+
+	if ec.checkpointFile != "" {
+		if err := os.Remove(ec.checkpointFile); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	printInfo("Retried %d events", count)
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/ansi"
+	"github.com/hookdeck/hookdeck-cli/pkg/schema"
+	"github.com/hookdeck/hookdeck-cli/pkg/source"
+	"github.com/hookdeck/hookdeck-cli/pkg/validators"
+)
+
+const (
+	schemaFormatJSONSchema = "jsonschema"
+	schemaFormatTypeScript = "typescript"
+)
+
+type eventSchemaCmd struct {
+	cmd    *cobra.Command
+	source string
+	limit  int
+	format string
+	name   string
+	file   string
+}
+
+func newEventSchemaCmd() *eventSchemaCmd {
+	ec := &eventSchemaCmd{}
+
+	ec.cmd = &cobra.Command{
+		Use:   "schema",
+		Args:  validators.NoArgs,
+		Short: "Generate a schema from the recent events of a source",
+		Long: `Generate a JSON Schema or TypeScript types from the payloads of the
+most recent requests received by a source.
+
+The schema matches all the sampled payloads: properties present in every
+payload are required, and values seen with different types get a union type.
+Payloads that aren't JSON are skipped.`,
+		Example: `  hookdeck event schema --source stripe-prod --file stripe.schema.json
+  hookdeck event schema --source github --format typescript --name GitHubEvent --file github.ts`,
+		RunE: ec.runEventSchemaCmd,
+	}
+	ec.cmd.Flags().StringVar(&ec.source, "source", "", "Name of the source to sample events from")
+	ec.cmd.Flags().IntVar(&ec.limit, "limit", 20, "Number of recent events to sample")
+	ec.cmd.Flags().StringVar(&ec.format, "format", schemaFormatJSONSchema, "Format of the generated schema (jsonschema, typescript)")
+	ec.cmd.Flags().StringVar(&ec.name, "name", "", "Name of the generated type (defaults to a name derived from the source)")
+	ec.cmd.Flags().StringVar(&ec.file, "file", "", "File to write the schema to (defaults to stdout)")
+	ec.cmd.MarkFlagRequired("source")
+
+	return ec
+}
+
+func (ec *eventSchemaCmd) runEventSchemaCmd(cmd *cobra.Command, args []string) error {
+	if ec.format != schemaFormatJSONSchema && ec.format != schemaFormatTypeScript {
+		return fmt.Errorf("invalid format %q, expected one of %s, %s", ec.format, schemaFormatJSONSchema, schemaFormatTypeScript)
+	}
+	if ec.limit < 1 || ec.limit > 250 {
+		return fmt.Errorf("invalid limit %d, expected a value between 1 and 250", ec.limit)
+	}
+	if err := Config.Profile.ValidateAPIKey(); err != nil {
+		return err
+	}
+
+	client := Config.GetClient()
+	src, err := source.Get(client, ec.source)
+	if err != nil {
+		return err
+	}
+
+	samples, err := source.SampleBodies(client, src, ec.limit)
+	if err != nil {
+		return err
+	}
+	if len(samples) == 0 {
+		return fmt.Errorf("source %q has no recent events with a JSON payload", ec.source)
+	}
+
+	name := ec.name
+	if name == "" {
+		name = typeName(src.Name) + "Event"
+	}
+
+	s := schema.Infer(name, samples)
+	var out []byte
+	if ec.format == schemaFormatTypeScript {
+		out = []byte(schema.TypeScript(name, s))
+	} else {
+		out, err = json.MarshalIndent(s, "", "  ")
+		if err != nil {
+			return err
+		}
+		out = append(out, '\n')
+	}
+
+	if ec.file == "" {
+		_, err = os.Stdout.Write(out)
+		return err
+	}
+	if err := ioutil.WriteFile(ec.file, out, 0644); err != nil {
+		return err
+	}
+
+	ansi.Status("Wrote the schema of %d events to %s", len(samples), ec.file)
+
+	return nil
+}
+
+var typeNameSeparatorRegexp = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+// typeName converts a source name such as "stripe-prod" to "StripeProd".
+func typeName(name string) string {
+	var b strings.Builder
+	for _, part := range typeNameSeparatorRegexp.Split(name, -1) {
+		if part != "" {
+			b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+		}
+	}
+	return b.String()
+}
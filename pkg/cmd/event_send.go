@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/ansi"
+	"github.com/hookdeck/hookdeck-cli/pkg/source"
+	"github.com/hookdeck/hookdeck-cli/pkg/validators"
+)
+
+type eventSendCmd struct {
+	cmd     *cobra.Command
+	source  string
+	body    string
+	method  string
+	path    string
+	headers []string
+}
+
+func newEventSendCmd() *eventSendCmd {
+	ec := &eventSendCmd{}
+
+	ec.cmd = &cobra.Command{
+		Use:   "send",
+		Args:  validators.NoArgs,
+		Short: "Send a test event to a source",
+		Long: `Send a test event to the URL of one of your sources.
+
+The request is signed or authenticated according to the source's
+verification config, so it goes through your full pipeline: the source,
+its connection rules, and your destinations (including the CLI).`,
+		Example: `  hookdeck event send --source stripe --body @payload.json
+  hookdeck event send --source github --body '{"action":"opened"}' --header "X-GitHub-Event: pull_request"`,
+		RunE: ec.runEventSendCmd,
+	}
+	ec.cmd.Flags().StringVar(&ec.source, "source", "", "Name of the source to send the event to")
+	ec.cmd.Flags().StringVar(&ec.body, "body", "", "The request body, or @path to read it from a file")
+	ec.cmd.Flags().StringVar(&ec.method, "method", http.MethodPost, "HTTP method of the request")
+	ec.cmd.Flags().StringVar(&ec.path, "path", "", "Path appended to the source URL")
+	ec.cmd.Flags().StringArrayVarP(&ec.headers, "header", "H", []string{}, "Request header in the \"Name: value\" format, can be repeated")
+	ec.cmd.MarkFlagRequired("source")
+
+	return ec
+}
+
+func (ec *eventSendCmd) runEventSendCmd(cmd *cobra.Command, args []string) error {
+	if err := Config.Profile.ValidateAPIKey(); err != nil {
+		return err
+	}
+
+	body, err := readBodyFlag(ec.body)
+	if err != nil {
+		return err
+	}
+
+	src, err := source.Get(Config.GetClient(), ec.source)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(strings.ToUpper(ec.method), src.Url+ec.path, strings.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	headers, err := parseHeaderFlags(ec.headers)
+	if err != nil {
+		return err
+	}
+	for name := range headers {
+		req.Header.Set(name, headers.Get(name))
+	}
+
+	if err := source.Authenticate(src, req, body); err != nil {
+		return err
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	resBody, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%d %s %s\n", ansi.ColorizeStatus(res.StatusCode), req.Method, req.URL)
+	if len(resBody) > 0 {
+		fmt.Println(string(resBody))
+	}
+
+	return nil
+}
+
+// parseHeaderFlags parses headers given in the "Name: value" format.
+func parseHeaderFlags(values []string) (http.Header, error) {
+	headers := http.Header{}
+	for _, value := range values {
+		parts := strings.SplitN(value, ":", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid header %q, expected the \"Name: value\" format", value)
+		}
+		headers.Set(strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1]))
+	}
+	return headers, nil
+}
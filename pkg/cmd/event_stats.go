@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/event"
+	"github.com/hookdeck/hookdeck-cli/pkg/output"
+	"github.com/hookdeck/hookdeck-cli/pkg/source"
+	"github.com/hookdeck/hookdeck-cli/pkg/validators"
+)
+
+type eventStatsCmd struct {
+	cmd         *cobra.Command
+	source      string
+	since       string
+	granularity string
+	output      output.Options
+}
+
+func newEventStatsCmd() *eventStatsCmd {
+	ec := &eventStatsCmd{
+		output: output.Options{
+			DefaultColumns:   []string{"connection", "events", "success_rate", "avg_delivery", "volume"},
+			AvailableColumns: []string{"id", "connection", "events", "successful", "failed", "success_rate", "avg_delivery", "volume"},
+		},
+	}
+
+	ec.cmd = &cobra.Command{
+		Use:   "stats",
+		Args:  validators.NoArgs,
+		Short: "Show the event metrics of your connections",
+		Long: `Show the number of events, success rate and average delivery time of each
+connection over a window of time, with the volume of events per interval of
+the window as a sparkline.
+
+The success rate only counts the events that are done, and the delivery time
+is the time between the creation and the successful delivery of an event.`,
+		Example: `  hookdeck event stats
+  hookdeck event stats --since 7d --granularity 1d
+  hookdeck event stats --source stripe --since 1h --granularity 5m --output json`,
+		RunE: ec.runEventStatsCmd,
+	}
+	ec.cmd.Flags().StringVar(&ec.source, "source", "", "Only count the events of this source")
+	ec.cmd.Flags().StringVar(&ec.since, "since", "24h", "Start of the window, as a duration (e.g. 24h, 7d) or a date (e.g. 2024-01-31)")
+	ec.cmd.Flags().StringVar(&ec.granularity, "granularity", "1h", "Interval of the event volume (e.g. 5m, 1h, 1d)")
+	ec.output.AddFlags(ec.cmd.Flags())
+
+	return ec
+}
+
+func (ec *eventStatsCmd) runEventStatsCmd(cmd *cobra.Command, args []string) error {
+	if err := ec.output.Validate(); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	since, err := parseSince(ec.since, now)
+	if err != nil {
+		return err
+	}
+	granularity, err := parseDuration(ec.granularity)
+	if err != nil || granularity <= 0 {
+		return fmt.Errorf("invalid --granularity %q, expected a duration like 5m, 1h or 1d", ec.granularity)
+	}
+	if !since.Before(now) {
+		return fmt.Errorf("invalid --since %q, the window must start in the past", ec.since)
+	}
+
+	if err := Config.Profile.ValidateAPIKey(); err != nil {
+		return err
+	}
+
+	opts := event.StatsOptions{Since: since, Until: now, Granularity: granularity}
+	if opts.Buckets() > event.MaxStatsBuckets {
+		return fmt.Errorf("the window has %d intervals of %s, use a larger --granularity to have at most %d", opts.Buckets(), ec.granularity, event.MaxStatsBuckets)
+	}
+
+	client := Config.GetClient()
+	if ec.source != "" {
+		src, err := source.Get(client, ec.source)
+		if err != nil {
+			return err
+		}
+		opts.SourceID = src.Id
+	}
+
+	stats, err := event.Stats(context.Background(), client, opts)
+	if err != nil {
+		return err
+	}
+
+	rows := make([]output.Row, len(stats))
+	for i, s := range stats {
+		name := s.Name
+		if name == "" {
+			name = s.ConnectionID
+		}
+		rows[i] = output.Row{
+			"id":           s.ConnectionID,
+			"connection":   name,
+			"events":       strconv.Itoa(s.Events),
+			"successful":   strconv.Itoa(s.Successful),
+			"failed":       strconv.Itoa(s.Failed),
+			"success_rate": fmt.Sprintf("%.1f%%", s.SuccessRate*100),
+			"avg_delivery": (time.Duration(s.AvgDeliveryMs) * time.Millisecond).String(),
+			"volume":       sparkline(s.Volume),
+		}
+	}
+
+	return ec.output.Print(os.Stdout, stats, rows)
+}
+
+var sparklineBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// sparkline renders values as a line of blocks scaled to the largest value.
+func sparkline(values []int) string {
+	max := 0
+	for _, value := range values {
+		if value > max {
+			max = value
+		}
+	}
+
+	var b strings.Builder
+	for _, value := range values {
+		if max == 0 {
+			b.WriteRune(sparklineBlocks[0])
+			continue
+		}
+		b.WriteRune(sparklineBlocks[value*(len(sparklineBlocks)-1)/max])
+	}
+	return b.String()
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"errors"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/hookdeck"
+	"github.com/hookdeck/hookdeck-cli/pkg/proxy"
+)
+
+// Process exit codes, so scripts can react to specific failures.
+const (
+	exitCodeError      = 1
+	exitCodeAuth       = 2
+	exitCodeNotFound   = 3
+	exitCodeValidation = 4
+	exitCodeRateLimit  = 5
+	exitCodeFailOn     = 6
+)
+
+func exitCode(err error) int {
+	var failOnErr *proxy.FailOnError
+	if errors.As(err, &failOnErr) {
+		return exitCodeFailOn
+	}
+
+	apiErr, ok := hookdeck.AsAPIError(err)
+	if !ok {
+		return exitCodeError
+	}
+
+	switch {
+	case apiErr.IsAuthError():
+		return exitCodeAuth
+	case apiErr.IsNotFound():
+		return exitCodeNotFound
+	case apiErr.IsValidationError():
+		return exitCodeValidation
+	case apiErr.IsRateLimited():
+		return exitCodeRateLimit
+	default:
+		return exitCodeError
+	}
+}
+
+// errorClass returns the kind of error err is, for telemetry.
+func errorClass(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	switch exitCode(err) {
+	case exitCodeAuth:
+		return "auth"
+	case exitCodeNotFound:
+		return "not_found"
+	case exitCodeValidation:
+		return "validation"
+	case exitCodeRateLimit:
+		return "rate_limit"
+	case exitCodeFailOn:
+		return "fail_on"
+	default:
+		return "error"
+	}
+}
@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/validators"
+)
+
+type filterCmd struct {
+	cmd *cobra.Command
+}
+
+func newFilterCmd() *filterCmd {
+	fc := &filterCmd{}
+
+	fc.cmd = &cobra.Command{
+		Use:   "filter",
+		Args:  validators.NoArgs,
+		Short: "Work with connection filter rules",
+	}
+
+	fc.cmd.AddCommand(newFilterTestCmd().cmd)
+
+	return fc
+}
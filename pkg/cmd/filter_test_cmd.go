@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/ansi"
+	"github.com/hookdeck/hookdeck-cli/pkg/filter"
+	"github.com/hookdeck/hookdeck-cli/pkg/validators"
+)
+
+type filterTestCmd struct {
+	cmd         *cobra.Command
+	filter      string
+	body        string
+	headers     []string
+	queryString string
+	path        string
+}
+
+func newFilterTestCmd() *filterTestCmd {
+	fc := &filterTestCmd{}
+
+	fc.cmd = &cobra.Command{
+		Use:   "test",
+		Args:  validators.NoArgs,
+		Short: "Test a filter against a sample request",
+		Long: `Evaluate a filter locally against a sample request and explain why it
+doesn't match.
+
+The filter can either be a full filter rule with body, headers, query and
+path filters, or a body filter. The command exits with a non-zero status
+when the request doesn't match.`,
+		Example: `  hookdeck filter test --filter '{"type":{"$in":["order.created","order.paid"]}}' --body @payload.json
+  hookdeck filter test --filter @filter.json --body @payload.json --header "X-Shopify-Topic: orders/create" --path /webhooks`,
+		RunE: fc.runFilterTestCmd,
+	}
+	fc.cmd.Flags().StringVar(&fc.filter, "filter", "", "The filter in JSON, or @path to read it from a file")
+	fc.cmd.Flags().StringVar(&fc.body, "body", "", "The request body, or @path to read it from a file")
+	fc.cmd.Flags().StringArrayVarP(&fc.headers, "header", "H", []string{}, "Request header in the \"Name: value\" format, can be repeated")
+	fc.cmd.Flags().StringVar(&fc.queryString, "query-string", "", "The request query string, e.g. \"shop=acme&topic=orders\"")
+	fc.cmd.Flags().StringVar(&fc.path, "path", "/", "The request path")
+	fc.cmd.MarkFlagRequired("filter")
+
+	return fc
+}
+
+func (fc *filterTestCmd) runFilterTestCmd(cmd *cobra.Command, args []string) error {
+	data, err := readBodyFlag(fc.filter)
+	if err != nil {
+		return err
+	}
+	rule, err := filter.ParseRule(data)
+	if err != nil {
+		return err
+	}
+
+	body, err := readBodyFlag(fc.body)
+	if err != nil {
+		return err
+	}
+	headers, err := parseHeaderFlags(fc.headers)
+	if err != nil {
+		return err
+	}
+	headerValues := map[string]string{}
+	for name := range headers {
+		headerValues[name] = headers.Get(name)
+	}
+
+	req, err := filter.NewRequest(body, headerValues, fc.queryString, fc.path)
+	if err != nil {
+		return err
+	}
+
+	result := rule.Evaluate(req)
+	color := ansi.Color(cmd.OutOrStdout())
+
+	if result.Match {
+		fmt.Fprintf(cmd.OutOrStdout(), "%s The request matches the filter\n", color.Green("✔"))
+		return nil
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "%s The request doesn't match the filter:\n", color.Red("✘"))
+	for _, reason := range result.Reasons {
+		fmt.Fprintf(cmd.OutOrStdout(), "  - %s\n", reason)
+	}
+
+	return errors.New("no match")
+}
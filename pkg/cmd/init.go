@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/ansi"
+	"github.com/hookdeck/hookdeck-cli/pkg/scaffold"
+	"github.com/hookdeck/hookdeck-cli/pkg/validators"
+)
+
+type initCmd struct {
+	cmd            *cobra.Command
+	source         string
+	port           string
+	path           string
+	transformation bool
+	workflow       bool
+	force          bool
+}
+
+func newInitCmd() *initCmd {
+	ic := &initCmd{}
+
+	ic.cmd = &cobra.Command{
+		Use:   "init",
+		Args:  validators.NoArgs,
+		Short: "Set up the Hookdeck CLI in the current project",
+		Long: `Set up the Hookdeck CLI in the current project. The command asks a few
+questions and generates:
+
+  - .hookdeck/config.toml with the defaults of "hookdeck listen"
+  - a sample transformation in hookdeck/transformations
+  - a GitHub Actions workflow forwarding events with "hookdeck ci"
+  - .gitignore entries for the local config
+
+Existing files are kept unless --force is set.`,
+		Example: `  hookdeck init
+  hookdeck init --source stripe --port 3000 --path /webhooks/stripe --yes`,
+		RunE: ic.runInitCmd,
+	}
+	ic.cmd.Flags().StringVar(&ic.source, "source", "webhooks", "Name of the source to listen to")
+	ic.cmd.Flags().StringVar(&ic.port, "port", "3000", "Port of your local server")
+	ic.cmd.Flags().StringVar(&ic.path, "path", "/webhooks", "Path events are forwarded to on your local server")
+	ic.cmd.Flags().BoolVar(&ic.transformation, "transformation", true, "Generate a sample transformation")
+	ic.cmd.Flags().BoolVar(&ic.workflow, "workflow", true, "Generate a GitHub Actions workflow")
+	ic.cmd.Flags().BoolVar(&ic.force, "force", false, "Overwrite existing files")
+
+	return ic
+}
+
+func (ic *initCmd) runInitCmd(cmd *cobra.Command, args []string) error {
+	if !Config.Yes && term.IsTerminal(int(os.Stdin.Fd())) {
+		if err := ic.ask(); err != nil {
+			return err
+		}
+	}
+
+	if !strings.HasPrefix(ic.path, "/") {
+		return fmt.Errorf("invalid path %q, it must start with /", ic.path)
+	}
+
+	dir, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	written, err := scaffold.Write(dir, scaffold.Files(scaffold.Options{
+		Source:         ic.source,
+		Port:           ic.port,
+		Path:           ic.path,
+		Transformation: ic.transformation,
+		Workflow:       ic.workflow,
+	}), ic.force)
+	if err != nil {
+		return err
+	}
+
+	if len(written) == 0 {
+		printInfo("The project is already set up, use --force to overwrite the existing files.")
+		return nil
+	}
+
+	color := ansi.Color(infoOut())
+	for _, file := range written {
+		printInfo("%s %s", color.Green("✔"), file)
+	}
+	printInfo("\nRun %s to start forwarding events to your local server.", color.Bold("hookdeck listen"))
+	if ic.workflow {
+		printInfo("Add your API key as the %s secret of your GitHub repository for the workflow to run.", color.Bold("HOOKDECK_API_KEY"))
+	}
+
+	return nil
+}
+
+// ask asks the questions whose flags weren't set.
+func (ic *initCmd) ask() error {
+	flags := ic.cmd.Flags()
+	qs := []*survey.Question{}
+	if !flags.Changed("source") {
+		qs = append(qs, &survey.Question{
+			Name:     "source",
+			Prompt:   &survey.Input{Message: "Name of the source to listen to:", Default: ic.source},
+			Validate: survey.Required,
+		})
+	}
+	if !flags.Changed("port") {
+		qs = append(qs, &survey.Question{
+			Name:     "port",
+			Prompt:   &survey.Input{Message: "Port of your local server:", Default: ic.port},
+			Validate: survey.Required,
+		})
+	}
+	if !flags.Changed("path") {
+		qs = append(qs, &survey.Question{
+			Name:     "path",
+			Prompt:   &survey.Input{Message: "Path events are forwarded to:", Default: ic.path},
+			Validate: survey.Required,
+		})
+	}
+	if !flags.Changed("transformation") {
+		qs = append(qs, &survey.Question{
+			Name:   "transformation",
+			Prompt: &survey.Confirm{Message: "Generate a sample transformation?", Default: ic.transformation},
+		})
+	}
+	if !flags.Changed("workflow") {
+		qs = append(qs, &survey.Question{
+			Name:   "workflow",
+			Prompt: &survey.Confirm{Message: "Generate a GitHub Actions workflow?", Default: ic.workflow},
+		})
+	}
+
+	answers := struct {
+		Source         string `survey:"source"`
+		Port           string `survey:"port"`
+		Path           string `survey:"path"`
+		Transformation bool   `survey:"transformation"`
+		Workflow       bool   `survey:"workflow"`
+	}{ic.source, ic.port, ic.path, ic.transformation, ic.workflow}
+	if err := survey.Ask(qs, &answers); err != nil {
+		return err
+	}
+
+	ic.source, ic.port, ic.path = answers.Source, answers.Port, answers.Path
+	ic.transformation, ic.workflow = answers.Transformation, answers.Workflow
+	return nil
+}
@@ -18,19 +18,60 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
+	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hookdeck/hookdeck-cli/pkg/listen"
+	"github.com/hookdeck/hookdeck-cli/pkg/mockapi"
+	"github.com/hookdeck/hookdeck-cli/pkg/proxy"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 )
 
 type listenCmd struct {
-	cmd   *cobra.Command
-	noWSS bool
-	path  string
+	cmd            *cobra.Command
+	noWSS          bool
+	path           string
+	signingSecret  string
+	resignSecret   string
+	output         string
+	filterPaths    []string
+	filterHeaders  []string
+	filterBody     string
+	respondStatus  int
+	respondBody    string
+	noAck          bool
+	expectStatus   int
+	expectBody     string
+	expectNack     bool
+	alsoForward    []string
+	tunnelInspect  bool
+	inspectAddr    string
+	inspectMaxBody string
+	notify         bool
+	http2          bool
+	idleTimeout    time.Duration
+	headerTimeout  time.Duration
+	connectTimeout time.Duration
+	failOn         string
+	breaker        string
+	throttle       string
+	throttleBurst  int
+	overflow       string
+	throttleQueue  int
+	onLocalError   string
+	maxEvents      int
+	timeout        time.Duration
+	summaryFile    string
+	sinks          []string
+	noForward      bool
+	echo           bool
+	exec           string
+	noMetadata     bool
 }
 
 // Map --cli-path to --path
@@ -56,32 +97,19 @@ This command will create a new Hookdeck Source if it doesn't exist.
 By default the Hookdeck Destination will be named "{source}-cli", and the
 Destination CLI path will be "/". To set the CLI path, use the "--path" flag.`,
 		Args: func(cmd *cobra.Command, args []string) error {
+			args = listenArgsWithDefaults(args, noForward(cmd))
+			if noForward(cmd) {
+				if len(args) > 3 {
+					return errors.New("invalid extra argument provided")
+				}
+				return nil
+			}
 			if len(args) < 1 {
 				return errors.New("requires a port or forwarding URL to forward the events to")
 			}
 
-			_, err_port := strconv.ParseInt(args[0], 10, 64)
-
-			var parsed_url *url.URL
-			var err_url error
-			if strings.HasPrefix(args[0], "http") {
-				parsed_url, err_url = url.Parse(args[0])
-			} else {
-				parsed_url, err_url = url.Parse("http://" + args[0])
-			}
-
-			if err_port != nil && err_url != nil {
-				return errors.New("argument is not a valid port or forwading URL")
-			}
-
-			if err_port != nil {
-				if parsed_url.Host == "" {
-					return errors.New("forwarding URL must contain a host")
-				}
-
-				if parsed_url.RawQuery != "" {
-					return errors.New("forwarding URL cannot contain query params")
-				}
+			if _, err := parseForwardURL(args[0]); err != nil {
+				return err
 			}
 
 			if len(args) > 3 {
@@ -97,6 +125,53 @@ Destination CLI path will be "/". To set the CLI path, use the "--path" flag.`,
 
 	lc.cmd.Flags().StringVar(&lc.path, "path", "", "Sets the path to which events are forwarded e.g., /webhooks or /api/stripe")
 
+	secretFlagVar(lc.cmd.Flags(), &lc.signingSecret, "signing-secret", "", "Verify the Hookdeck signature of events with your project signing secret before forwarding them")
+	secretFlagVar(lc.cmd.Flags(), &lc.resignSecret, "resign-secret", "", "Re-sign forwarded events with this secret so your local signature verification can run")
+
+	lc.cmd.Flags().StringVarP(&lc.output, "output", "o", proxy.OutputCompact, "Output format of forwarded events (compact, json)")
+
+	lc.cmd.Flags().StringArrayVar(&lc.filterPaths, "filter-path", []string{}, "Only forward events whose path matches this glob, e.g. /stripe/*, can be repeated")
+	lc.cmd.Flags().StringArrayVar(&lc.filterHeaders, "filter-header", []string{}, "Only forward events with a header matching this \"Name: glob\", can be repeated")
+	lc.cmd.Flags().StringVar(&lc.filterBody, "filter-body", "", "Only forward events whose JSON body matches this jq expression, e.g. '.type == \"invoice.paid\"'")
+
+	lc.cmd.Flags().IntVar(&lc.respondStatus, "respond-status", 0, "Respond to Hookdeck with this status code instead of the local server's, even when the local server can't be reached")
+	lc.cmd.Flags().StringVar(&lc.respondBody, "respond-body", "", "Respond to Hookdeck with this body instead of the local server's, implies --respond-status 200 if not set")
+	lc.cmd.Flags().BoolVar(&lc.noAck, "no-ack", false, "Report every event as not delivered to Hookdeck, so that it keeps retrying them, e.g. to observe production traffic")
+
+	lc.cmd.Flags().StringArrayVar(&lc.alsoForward, "also-forward", []string{}, "Also forward each event to this port or URL, e.g. to compare two implementations. Only its responses are printed, can be repeated")
+
+	lc.cmd.Flags().IntVar(&lc.expectStatus, "expect-status", 0, "Mark events as failed when your local server responds with another status")
+	lc.cmd.Flags().StringVar(&lc.expectBody, "expect-body-contains", "", "Mark events as failed when the response of your local server doesn't contain this string")
+	lc.cmd.Flags().BoolVar(&lc.expectNack, "expect-nack", false, "Report the events that don't match --expect-status or --expect-body-contains as not delivered to Hookdeck")
+
+	lc.cmd.Flags().BoolVar(&lc.tunnelInspect, "tunnel-inspect", false, "Serve a local web UI to inspect and replay the forwarded events")
+	lc.cmd.Flags().StringVar(&lc.inspectAddr, "inspect-addr", proxy.DefaultInspectorAddr, "Address of the web UI served with --tunnel-inspect")
+	lc.cmd.Flags().StringVar(&lc.inspectMaxBody, "inspect-max-body", "1MB", "Size above which the web UI keeps bodies on disk and truncates them for display (e.g. 512KB, 4MB)")
+
+	lc.cmd.Flags().BoolVar(&lc.noMetadata, "no-metadata-headers", false, "Don't add the X-Hookdeck-Event-Id, X-Hookdeck-Attempt-Id, X-Hookdeck-Source-Name and X-Hookdeck-CLI-Session headers to forwarded requests")
+
+	lc.cmd.Flags().BoolVar(&lc.notify, "notify", false, "Show a desktop notification when your local server fails to handle an event")
+
+	lc.cmd.Flags().BoolVar(&lc.http2, "http2", false, "Attempt HTTP/2 when forwarding to an HTTPS local server")
+	lc.cmd.Flags().DurationVar(&lc.idleTimeout, "idle-conn-timeout", proxy.DefaultIdleConnTimeout, "How long idle keep-alive connections to your local server are kept open")
+	lc.cmd.Flags().DurationVar(&lc.headerTimeout, "response-header-timeout", 0, "Maximum time to wait for your local server's response headers, e.g. 2m (defaults to the event timeout)")
+	lc.cmd.Flags().DurationVar(&lc.connectTimeout, "connect-timeout", 0, "Maximum time to wait for a connection to your local server, e.g. 2s (defaults to the event timeout)")
+
+	lc.cmd.Flags().IntVar(&lc.maxEvents, "max-events", 0, "Stop listening once this number of events is handled")
+	lc.cmd.Flags().DurationVar(&lc.timeout, "timeout", 0, "Stop listening after this duration, e.g. 10m")
+	lc.cmd.Flags().StringVar(&lc.summaryFile, "summary-file", "", "Write the summary of the session to this file as JSON when it ends, e.g. for CI artifacts")
+	lc.cmd.Flags().StringArrayVar(&lc.sinks, "sink", []string{}, "Also write each event as a JSON line to this sink: stdout, or file://path. Can be repeated")
+	lc.cmd.Flags().BoolVar(&lc.noForward, "no-forward", false, "Only write the events to the sinks and acknowledge them, without a local server. The port or forwarding URL argument is left out")
+	lc.cmd.Flags().BoolVar(&lc.echo, "echo", false, "Print and acknowledge each event with a 200 without a local server, to see what a provider sends. The port or forwarding URL argument is left out")
+	lc.cmd.Flags().StringVar(&lc.exec, "exec", "", "Run this command for each event instead of forwarding it, with the body on stdin. It responds 200 when it exits with 0, 500 otherwise, with its output as body. The port or forwarding URL argument is left out")
+	lc.cmd.Flags().StringVar(&lc.failOn, "fail-on", "", "Exit with an error when the rate of events your local server fails to handle exceeds a threshold, e.g. error-rate=0.2,window=5m")
+	lc.cmd.Flags().StringVar(&lc.onLocalError, "on-local-error", proxy.LocalErrorNack, "What to report to Hookdeck when an event can't be forwarded to your local server: error to have it retried, status:<code> to respond with a status code, e.g. status:503, or skip to not respond")
+	lc.cmd.Flags().StringVar(&lc.throttle, "throttle", "", "Maximum rate of the events forwarded to your local server, per second, minute or hour, e.g. 20/s")
+	lc.cmd.Flags().IntVar(&lc.throttleBurst, "throttle-burst", 1, "Number of events forwarded at once after a pause with --throttle")
+	lc.cmd.Flags().StringVar(&lc.overflow, "throttle-overflow", proxy.ThrottleBuffer, "What to do with the events received faster than --throttle: buffer them, drop them (acknowledged without being forwarded) or nack them for Hookdeck to retry")
+	lc.cmd.Flags().IntVar(&lc.throttleQueue, "throttle-queue", proxy.DefaultThrottleQueue, "Maximum number of events buffered by --throttle, the next ones are NACKed")
+	lc.cmd.Flags().StringVar(&lc.breaker, "circuit-breaker", "", "Fail events without forwarding them for a cool-down once your local server is unreachable for consecutive events, e.g. failures=5,cooldown=30s")
+
 	// --cli-path is an alias for
 	lc.cmd.Flags().SetNormalizeFunc(normalizeCliPathFlag)
 
@@ -129,6 +204,18 @@ Examples:
   Forward events to the path "/webhooks" on local server running on port %[1]d:
 
     hookdeck listen %[1]d --path /webhooks
+
+  Write the events of a Hookdeck Source named "stripe" to a file, without a local server:
+
+    hookdeck listen stripe --no-forward --sink file://events.ndjson
+
+  Print the events of a Hookdeck Source named "stripe" without a local server:
+
+    hookdeck listen stripe --echo
+
+  Handle the events of a Hookdeck Source named "stripe" with a script:
+
+    hookdeck listen stripe --exec './handle-event.sh'
 		`, 3000)
 
 	lc.cmd.SetUsageTemplate(usage)
@@ -138,6 +225,33 @@ Examples:
 
 // listenCmd represents the listen command
 func (lc *listenCmd) runListenCmd(cmd *cobra.Command, args []string) error {
+	if os.Getenv(mockapi.EnvVar) != "" {
+		return fmt.Errorf("hookdeck listen can't be used with the mock server, unset %s to listen with your Hookdeck account", mockapi.EnvVar)
+	}
+	if lc.echo {
+		if lc.output == proxy.OutputJSON {
+			return errors.New("--echo can't be used with --output json, use --sink stdout to print the events as JSON")
+		}
+		lc.noForward = true
+	}
+	if lc.exec != "" {
+		if lc.echo || lc.noForward {
+			return errors.New("--exec can't be used with --echo or --no-forward")
+		}
+		if lc.respondStatus != 0 || lc.respondBody != "" {
+			return errors.New("--exec can't be used with --respond-status or --respond-body, the command's exit code and output are the response")
+		}
+		lc.noForward = true
+	}
+	args = listenArgsWithDefaults(args, lc.noForward)
+	defaults := Config.GetListenDefaults()
+	if !cmd.Flags().Changed("path") && defaults.Path != "" {
+		lc.path = defaults.Path
+	}
+	if !cmd.Flags().Changed("output") && defaults.Output != "" {
+		lc.output = defaults.Output
+	}
+
 	var sourceQuery, connectionQuery string
 	if len(args) > 1 {
 		sourceQuery = args[1]
@@ -146,24 +260,226 @@ func (lc *listenCmd) runListenCmd(cmd *cobra.Command, args []string) error {
 		connectionQuery = args[2]
 	}
 
-	_, err_port := strconv.ParseInt(args[0], 10, 64)
-	var url *url.URL
-	if err_port != nil {
-		if strings.HasPrefix(args[0], "http") {
-			url, _ = url.Parse(args[0])
-		} else {
-			url, _ = url.Parse("http://" + args[0])
+	var err error
+	var forwardURL *url.URL
+	if !lc.noForward {
+		forwardURL, err = parseForwardURL(args[0])
+		if err != nil {
+			return err
+		}
+	}
+	mirrors := make([]*url.URL, len(lc.alsoForward))
+	for i, value := range lc.alsoForward {
+		mirrors[i], err = parseForwardURL(value)
+		if err != nil {
+			return fmt.Errorf("invalid --also-forward: %w", err)
+		}
+	}
+
+	if lc.output != proxy.OutputCompact && lc.output != proxy.OutputJSON {
+		return fmt.Errorf("invalid output format %q, expected one of compact, json", lc.output)
+	}
+
+	if lc.noAck && (lc.respondStatus != 0 || lc.respondBody != "") {
+		return errors.New("--no-ack can't be used with --respond-status or --respond-body")
+	}
+	onLocalError, err := proxy.ParseOnLocalError(lc.onLocalError)
+	if err != nil {
+		return err
+	}
+	if cmd.Flags().Changed("on-local-error") && (lc.noAck || lc.respondStatus != 0 || lc.respondBody != "") {
+		return errors.New("--on-local-error can't be used with --no-ack, --respond-status or --respond-body")
+	}
+	var expect *proxy.Expectation
+	if lc.expectStatus != 0 || lc.expectBody != "" {
+		if lc.expectStatus != 0 && (lc.expectStatus < 100 || lc.expectStatus > 599) {
+			return fmt.Errorf("invalid expected status %d", lc.expectStatus)
 		}
-	} else {
-		url, _ = url.Parse("http://localhost:" + args[0])
+		expect = &proxy.Expectation{Status: lc.expectStatus, BodyContains: lc.expectBody, Nack: lc.expectNack}
+	} else if lc.expectNack {
+		return errors.New("--expect-nack requires --expect-status or --expect-body-contains")
+	}
+	if lc.respondBody != "" && lc.respondStatus == 0 {
+		lc.respondStatus = http.StatusOK
+	}
+	if lc.respondStatus != 0 && (lc.respondStatus < 100 || lc.respondStatus > 599) {
+		return fmt.Errorf("invalid response status %d", lc.respondStatus)
 	}
 
-	if url.Scheme == "" {
-		url.Scheme = "http"
+	filter, err := proxy.NewEventFilter(lc.filterPaths, lc.filterHeaders, lc.filterBody)
+	if err != nil {
+		return err
 	}
 
-	return listen.Listen(url, sourceQuery, connectionQuery, listen.Flags{
-		NoWSS: lc.noWSS,
-		Path:  lc.path,
+	inspectAddr := ""
+	if lc.tunnelInspect {
+		inspectAddr = lc.inspectAddr
+	}
+	inspectMaxBody, err := parseSize(lc.inspectMaxBody)
+	if err != nil {
+		return err
+	}
+	if lc.maxEvents < 0 {
+		return fmt.Errorf("invalid max events %d, expected a positive value", lc.maxEvents)
+	}
+	if lc.idleTimeout < 0 || lc.headerTimeout < 0 || lc.connectTimeout < 0 || lc.timeout < 0 {
+		return errors.New("timeouts can't be negative")
+	}
+	var failOn *proxy.FailOn
+	if lc.failOn != "" {
+		if failOn, err = proxy.ParseFailOn(lc.failOn); err != nil {
+			return err
+		}
+	}
+	var throttle *proxy.Throttle
+	if lc.throttle != "" {
+		rate, err := proxy.ParseThrottleRate(lc.throttle)
+		if err != nil {
+			return err
+		}
+		switch lc.overflow {
+		case proxy.ThrottleBuffer, proxy.ThrottleDrop, proxy.ThrottleNack:
+		default:
+			return fmt.Errorf("invalid throttle overflow %q, expected one of buffer, drop, nack", lc.overflow)
+		}
+		if lc.throttleBurst < 1 || lc.throttleQueue < 0 {
+			return errors.New("--throttle-burst must be at least 1 and --throttle-queue can't be negative")
+		}
+		throttle = &proxy.Throttle{Rate: rate, Burst: lc.throttleBurst, Overflow: lc.overflow, MaxQueue: lc.throttleQueue}
+	}
+	var breaker *proxy.CircuitBreaker
+	if lc.breaker != "" {
+		if breaker, err = proxy.ParseCircuitBreaker(lc.breaker); err != nil {
+			return err
+		}
+	}
+	if lc.noForward {
+		if len(lc.sinks) == 0 && !lc.echo && lc.exec == "" {
+			return errors.New("--no-forward requires at least one --sink")
+		}
+		if len(mirrors) > 0 || lc.expectStatus != 0 || lc.expectBody != "" {
+			return errors.New("--also-forward and the --expect flags require a local server to forward the events to")
+		}
+	}
+	sinks := make([]proxy.Sink, 0, len(lc.sinks))
+	for _, value := range lc.sinks {
+		sink, err := proxy.OpenSink(value)
+		if err != nil {
+			for _, opened := range sinks {
+				opened.Close()
+			}
+			return err
+		}
+		sinks = append(sinks, sink)
+	}
+
+	return listen.Listen(forwardURL, sourceQuery, connectionQuery, listen.Flags{
+		NoWSS:                 lc.noWSS,
+		Path:                  lc.path,
+		SigningSecret:         lc.signingSecret,
+		ResignSecret:          lc.resignSecret,
+		Output:                lc.output,
+		Filter:                filter,
+		RespondStatus:         lc.respondStatus,
+		RespondBody:           lc.respondBody,
+		NoAck:                 lc.noAck,
+		Expect:                expect,
+		Mirrors:               mirrors,
+		InspectAddr:           inspectAddr,
+		InspectMaxBody:        inspectMaxBody,
+		Notify:                lc.notify,
+		HTTP2:                 lc.http2,
+		IdleConnTimeout:       lc.idleTimeout,
+		ResponseHeaderTimeout: lc.headerTimeout,
+		ConnectTimeout:        lc.connectTimeout,
+		FailOn:                failOn,
+		CircuitBreaker:        breaker,
+		Throttle:              throttle,
+		OnLocalError:          onLocalError,
+		MaxEvents:             lc.maxEvents,
+		Timeout:               lc.timeout,
+		SummaryFile:           lc.summaryFile,
+		Sinks:                 sinks,
+		NoForward:             lc.noForward,
+		Echo:                  lc.echo,
+		Exec:                  lc.exec,
+		NoMetadataHeaders:     lc.noMetadata,
 	}, &Config)
 }
+
+// noForward returns whether --no-forward, --echo or --exec is set, for the
+// validation of the arguments.
+func noForward(cmd *cobra.Command) bool {
+	noForward, _ := cmd.Flags().GetBool("no-forward")
+	echo, _ := cmd.Flags().GetBool("echo")
+	exec, _ := cmd.Flags().GetString("exec")
+	return noForward || echo || exec != ""
+}
+
+// parseForwardURL parses the port, host or URL events are forwarded to. URLs
+// must contain a host and no query string.
+func parseForwardURL(value string) (*url.URL, error) {
+	if _, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return url.Parse("http://localhost:" + value)
+	}
+
+	raw := value
+	if !strings.HasPrefix(value, "http") {
+		raw = "http://" + value
+	}
+	forwardURL, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%q is not a valid port or forwarding URL: %w", value, err)
+	}
+	if forwardURL.Host == "" {
+		return nil, errors.New("forwarding URL must contain a host")
+	}
+	if forwardURL.RawQuery != "" {
+		return nil, errors.New("forwarding URL cannot contain query params")
+	}
+
+	if forwardURL.Scheme == "" {
+		forwardURL.Scheme = "http"
+	}
+	return forwardURL, nil
+}
+
+// listenArgsWithDefaults completes the positional arguments with the listen
+// defaults of the local config. With --no-forward, the port or forwarding
+// URL is left out of the arguments, so it's returned empty.
+func listenArgsWithDefaults(args []string, noForward bool) []string {
+	if noForward {
+		args = append([]string{""}, args...)
+	}
+	defaults := Config.GetListenDefaults()
+	for i, value := range []string{defaults.Port, defaults.Source, defaults.Connection} {
+		if len(args) != i || value == "" {
+			break
+		}
+		args = append(args, value)
+	}
+	return args
+}
+
+// parseSize parses a size in bytes, which can have a KB or MB unit.
+func parseSize(value string) (int, error) {
+	units := []struct {
+		suffix string
+		size   int
+	}{{"MB", 1 << 20}, {"KB", 1 << 10}, {"B", 1}}
+
+	upper := strings.ToUpper(strings.TrimSpace(value))
+	for _, unit := range units {
+		if strings.HasSuffix(upper, unit.suffix) {
+			n, err := strconv.Atoi(strings.TrimSpace(strings.TrimSuffix(upper, unit.suffix)))
+			if err != nil || n < 0 {
+				break
+			}
+			return n * unit.size, nil
+		}
+	}
+	if n, err := strconv.Atoi(upper); err == nil && n >= 0 {
+		return n, nil
+	}
+	return 0, fmt.Errorf("invalid size %q, expected a size like 512KB or 4MB", value)
+}
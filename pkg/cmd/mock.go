@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/validators"
+)
+
+type mockCmd struct {
+	cmd *cobra.Command
+}
+
+func newMockCmd() *mockCmd {
+	mc := &mockCmd{}
+
+	mc.cmd = &cobra.Command{
+		Use:   "mock",
+		Args:  validators.NoArgs,
+		Short: "Run a mock of the Hookdeck API",
+	}
+
+	mc.cmd.AddCommand(newMockServeCmd().cmd)
+
+	return mc
+}
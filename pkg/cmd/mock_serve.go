@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/ansi"
+	"github.com/hookdeck/hookdeck-cli/pkg/mockapi"
+	"github.com/hookdeck/hookdeck-cli/pkg/validators"
+)
+
+type mockServeCmd struct {
+	cmd  *cobra.Command
+	port int
+}
+
+func newMockServeCmd() *mockServeCmd {
+	mc := &mockServeCmd{}
+
+	mc.cmd = &cobra.Command{
+		Use:   "serve",
+		Args:  validators.NoArgs,
+		Short: "Start a local mock of the Hookdeck API",
+		Long: `Start an in-memory mock of the Hookdeck API endpoints used by the CLI.
+
+Point the CLI to the mock server with the HOOKDECK_MOCK_SERVER environment
+variable to run commands without a Hookdeck account. Resources created on
+the mock server are lost when it stops.`,
+		Example: `  hookdeck mock serve --port 8910
+  HOOKDECK_MOCK_SERVER=http://localhost:8910 hookdeck project list`,
+		RunE: mc.runMockServeCmd,
+	}
+	mc.cmd.Flags().IntVar(&mc.port, "port", 8910, "Port to listen on")
+
+	return mc
+}
+
+func (mc *mockServeCmd) runMockServeCmd(cmd *cobra.Command, args []string) error {
+	addr := fmt.Sprintf("localhost:%d", mc.port)
+	color := ansi.Color(cmd.OutOrStdout())
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Mock Hookdeck API listening on %s\n", color.Bold("http://"+addr))
+	fmt.Fprintf(cmd.OutOrStdout(), "Run %s to use it\n", color.Bold(fmt.Sprintf("export %s=http://%s", mockapi.EnvVar, addr)))
+
+	return http.ListenAndServe(addr, mockapi.New())
+}
@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/validators"
+)
+
+type orgCmd struct {
+	cmd *cobra.Command
+}
+
+func newOrgCmd() *orgCmd {
+	oc := &orgCmd{}
+
+	oc.cmd = &cobra.Command{
+		Use:     "org",
+		Aliases: []string{"orgs", "organization"},
+		Args:    validators.NoArgs,
+		Short:   "Manage your organizations",
+	}
+
+	oc.cmd.AddCommand(newOrgListCmd().cmd)
+
+	return oc
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/output"
+	"github.com/hookdeck/hookdeck-cli/pkg/project"
+	"github.com/hookdeck/hookdeck-cli/pkg/validators"
+)
+
+type orgListCmd struct {
+	cmd    *cobra.Command
+	output output.Options
+}
+
+func newOrgListCmd() *orgListCmd {
+	lc := &orgListCmd{
+		output: output.Options{
+			DefaultColumns:   []string{"name", "id", "projects"},
+			AvailableColumns: []string{"id", "name", "projects"},
+		},
+	}
+
+	lc.cmd = &cobra.Command{
+		Use:   "list",
+		Args:  validators.NoArgs,
+		Short: "List your organizations",
+		RunE:  lc.runOrgListCmd,
+	}
+	lc.output.AddFlags(lc.cmd.Flags())
+
+	return lc
+}
+
+func (lc *orgListCmd) runOrgListCmd(cmd *cobra.Command, args []string) error {
+	if err := lc.output.Validate(); err != nil {
+		return err
+	}
+
+	if err := Config.Profile.ValidateAPIKey(); err != nil {
+		return err
+	}
+
+	organizations, err := project.ListOrganizations(&Config)
+	if err != nil {
+		return err
+	}
+	projects, err := project.ListProjects(&Config)
+	if err != nil {
+		return err
+	}
+
+	counts := map[string]int{}
+	for _, project := range projects {
+		counts[project.OrganizationId]++
+	}
+
+	rows := make([]output.Row, len(organizations))
+	for i, organization := range organizations {
+		rows[i] = output.Row{
+			"id":       organization.Id,
+			"name":     organization.Name,
+			"projects": strconv.Itoa(counts[organization.Id]),
+		}
+	}
+
+	return lc.output.Print(os.Stdout, organizations, rows)
+}
@@ -21,6 +21,9 @@ func newProjectCmd() *projectCmd {
 
 	lc.cmd.AddCommand(newProjectListCmd().cmd)
 	lc.cmd.AddCommand(newProjectUseCmd().cmd)
+	lc.cmd.AddCommand(newProjectCreateCmd().cmd)
+	lc.cmd.AddCommand(newProjectRenameCmd().cmd)
+	lc.cmd.AddCommand(newProjectDeleteCmd().cmd)
 
 	return lc
 }
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/project"
+	"github.com/hookdeck/hookdeck-cli/pkg/validators"
+)
+
+type projectCreateCmd struct {
+	cmd   *cobra.Command
+	org   string
+	use   bool
+	local bool
+}
+
+func newProjectCreateCmd() *projectCreateCmd {
+	pc := &projectCreateCmd{}
+
+	pc.cmd = &cobra.Command{
+		Use:   "create <name>",
+		Args:  validators.ExactArgs(1),
+		Short: "Create a project",
+		Example: `  hookdeck project create staging
+  hookdeck project create staging --org "Acme Inc" --use`,
+		RunE: pc.runProjectCreateCmd,
+	}
+	pc.cmd.Flags().StringVar(&pc.org, "org", "", "Name or ID of the organization to create the project in (default is the organization of your API key)")
+	pc.cmd.Flags().BoolVar(&pc.use, "use", false, "Select the new project as your active project")
+	pc.cmd.Flags().BoolVar(&pc.local, "local", false, "With --use, pin the new project to the current directory")
+
+	return pc
+}
+
+func (pc *projectCreateCmd) runProjectCreateCmd(cmd *cobra.Command, args []string) error {
+	if err := Config.Profile.ValidateAPIKey(); err != nil {
+		return err
+	}
+
+	organizationID := ""
+	if pc.org != "" {
+		organizations, err := project.ListOrganizations(&Config)
+		if err != nil {
+			return err
+		}
+		organization, err := project.FindOrganization(organizations, pc.org)
+		if err != nil {
+			return err
+		}
+		organizationID = organization.Id
+	}
+
+	created, err := project.CreateProject(&Config, args[0], organizationID)
+	if err != nil {
+		return err
+	}
+
+	printInfo("Project %s created (%s)", created.Name, created.Id)
+
+	if pc.use {
+		return Config.UseProject(pc.local, created.Id, created.Mode)
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/ansi"
+	"github.com/hookdeck/hookdeck-cli/pkg/project"
+	"github.com/hookdeck/hookdeck-cli/pkg/validators"
+)
+
+type projectDeleteCmd struct {
+	cmd *cobra.Command
+}
+
+func newProjectDeleteCmd() *projectDeleteCmd {
+	pc := &projectDeleteCmd{}
+
+	pc.cmd = &cobra.Command{
+		Use:   "delete <project>",
+		Args:  validators.ExactArgs(1),
+		Short: "Delete a project and all its resources",
+		Long: `Delete a project with all its sources, connections, destinations and
+events. This can't be undone.
+
+You're asked to type the name of the project to confirm, use --yes to delete
+it non-interactively. The active project can't be deleted, select another one
+with "hookdeck project use" first.`,
+		Example: `  hookdeck project delete staging
+  hookdeck project delete staging --yes`,
+		RunE: pc.runProjectDeleteCmd,
+	}
+
+	return pc
+}
+
+func (pc *projectDeleteCmd) runProjectDeleteCmd(cmd *cobra.Command, args []string) error {
+	if err := Config.Profile.ValidateAPIKey(); err != nil {
+		return err
+	}
+
+	projects, err := project.ListProjects(&Config)
+	if err != nil {
+		return err
+	}
+	target, err := project.FindProject(projects, args[0])
+	if err != nil {
+		return err
+	}
+	if target.Id == Config.Profile.TeamID {
+		return fmt.Errorf("%s is the active project, select another one with `hookdeck project use` before deleting it", target.Name)
+	}
+
+	if !Config.Yes {
+		color := ansi.Color(infoOut())
+		printInfo("Project %s (%s) will be deleted with all its resources. This can't be undone.", color.Bold(target.Name), target.Id)
+	}
+	if err := confirmName("Type the name of the project to confirm:", target.Name); err != nil {
+		return err
+	}
+
+	if err := project.DeleteProject(&Config, target.Id); err != nil {
+		return err
+	}
+
+	printInfo("Project %s deleted", target.Name)
+
+	return nil
+}
@@ -1,22 +1,27 @@
 package cmd
 
 import (
-	"fmt"
 	"os"
 
 	"github.com/spf13/cobra"
 
-	"github.com/hookdeck/hookdeck-cli/pkg/ansi"
-	"github.com/hookdeck/hookdeck-cli/pkg/validators"
+	"github.com/hookdeck/hookdeck-cli/pkg/output"
 	"github.com/hookdeck/hookdeck-cli/pkg/project"
+	"github.com/hookdeck/hookdeck-cli/pkg/validators"
 )
 
 type projectListCmd struct {
-	cmd *cobra.Command
+	cmd    *cobra.Command
+	output output.Options
 }
 
 func newProjectListCmd() *projectListCmd {
-	lc := &projectListCmd{}
+	lc := &projectListCmd{
+		output: output.Options{
+			DefaultColumns:   []string{"name", "id", "current"},
+			AvailableColumns: []string{"id", "name", "mode", "current"},
+		},
+	}
 
 	lc.cmd = &cobra.Command{
 		Use:   "list",
@@ -24,11 +29,16 @@ func newProjectListCmd() *projectListCmd {
 		Short: "List your projects",
 		RunE:  lc.runProjectListCmd,
 	}
+	lc.output.AddFlags(lc.cmd.Flags())
 
 	return lc
 }
 
 func (lc *projectListCmd) runProjectListCmd(cmd *cobra.Command, args []string) error {
+	if err := lc.output.Validate(); err != nil {
+		return err
+	}
+
 	if err := Config.Profile.ValidateAPIKey(); err != nil {
 		return err
 	}
@@ -38,15 +48,19 @@ func (lc *projectListCmd) runProjectListCmd(cmd *cobra.Command, args []string) e
 		return err
 	}
 
-	color := ansi.Color(os.Stdout)
-
-	for _, project := range projects {
+	rows := make([]output.Row, len(projects))
+	for i, project := range projects {
+		current := ""
 		if project.Id == Config.Profile.TeamID {
-			fmt.Printf("%s (current)\n", color.Green(project.Name))
-		} else {
-			fmt.Printf("%s\n", project.Name)
+			current = "yes"
+		}
+		rows[i] = output.Row{
+			"id":      project.Id,
+			"name":    project.Name,
+			"mode":    project.Mode,
+			"current": current,
 		}
 	}
 
-	return nil
+	return lc.output.Print(os.Stdout, projects, rows)
 }
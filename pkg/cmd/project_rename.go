@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/project"
+	"github.com/hookdeck/hookdeck-cli/pkg/validators"
+)
+
+type projectRenameCmd struct {
+	cmd *cobra.Command
+}
+
+func newProjectRenameCmd() *projectRenameCmd {
+	pc := &projectRenameCmd{}
+
+	pc.cmd = &cobra.Command{
+		Use:     "rename <project> <new-name>",
+		Args:    validators.ExactArgs(2),
+		Short:   "Rename a project",
+		Example: `  hookdeck project rename staging "Staging (EU)"`,
+		RunE:    pc.runProjectRenameCmd,
+	}
+
+	return pc
+}
+
+func (pc *projectRenameCmd) runProjectRenameCmd(cmd *cobra.Command, args []string) error {
+	if err := Config.Profile.ValidateAPIKey(); err != nil {
+		return err
+	}
+
+	projects, err := project.CachedProjects(&Config)
+	if err != nil {
+		return err
+	}
+	target, err := project.FindProject(projects, args[0])
+	if err != nil {
+		return err
+	}
+
+	renamed, err := project.RenameProject(&Config, target.Id, args[1])
+	if err != nil {
+		return err
+	}
+
+	printInfo("Project %s renamed to %s", target.Name, renamed.Name)
+
+	return nil
+}
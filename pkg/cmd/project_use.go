@@ -33,7 +33,7 @@ func (lc *projectUseCmd) runProjectUseCmd(cmd *cobra.Command, args []string) err
 		return err
 	}
 
-	projects, err := project.ListProjects(&Config)
+	projects, err := project.CachedProjects(&Config)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/output"
+	"github.com/hookdeck/hookdeck-cli/pkg/search"
+	"github.com/hookdeck/hookdeck-cli/pkg/validators"
+)
+
+type searchCmd struct {
+	cmd    *cobra.Command
+	output output.Options
+	types  []string
+}
+
+func newSearchCmd() *searchCmd {
+	sc := &searchCmd{
+		output: output.Options{
+			DefaultColumns:   []string{"type", "name", "id", "field", "value"},
+			AvailableColumns: []string{"type", "id", "name", "field", "value"},
+		},
+	}
+
+	sc.cmd = &cobra.Command{
+		Use:   "search <term>",
+		Args:  validators.ExactArgs(1),
+		Short: "Search the connections, sources, destinations and transformations of the project",
+		Long: `Search the connections, sources, destinations and transformations of the
+project for a term, ignoring case. Their names, descriptions and URLs are
+searched, along with the destination URL of connections and the code of
+transformations, so searching a URL finds everything referencing it.
+
+Each resource matching is listed once, with the field the term was found in.`,
+		Example: `  hookdeck search api.example.com
+  hookdeck search orders --type connection,destination
+  hookdeck search api.example.com --query '.[].id'`,
+		RunE: sc.runSearchCmd,
+	}
+	sc.cmd.Flags().StringSliceVar(&sc.types, "type", nil, fmt.Sprintf("Types of resources to search (%s) (default all)", strings.Join(search.Types, ", ")))
+	sc.output.AddFlags(sc.cmd.Flags())
+
+	return sc
+}
+
+func (sc *searchCmd) runSearchCmd(cmd *cobra.Command, args []string) error {
+	if err := sc.output.Validate(); err != nil {
+		return err
+	}
+	if err := search.ValidateTypes(sc.types); err != nil {
+		return err
+	}
+
+	if err := Config.Profile.ValidateAPIKey(); err != nil {
+		return err
+	}
+
+	results, err := search.Search(context.Background(), Config.GetClient(), args[0], sc.types)
+	if err != nil {
+		return err
+	}
+
+	rows := make([]output.Row, len(results))
+	for i, result := range results {
+		rows[i] = output.Row{
+			"type":  result.Type,
+			"id":    result.ID,
+			"name":  result.Name,
+			"field": result.Field,
+			"value": result.Value,
+		}
+	}
+
+	return sc.output.Print(os.Stdout, results, rows)
+}
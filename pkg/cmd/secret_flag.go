@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/pflag"
+)
+
+// secretFlagUsage is appended to the usage of secret flags.
+const secretFlagUsage = " (use @env:VAR_NAME or @file:path to read it, or - to be prompted)"
+
+// secretFlag is the value of flags holding a secret. To keep secrets out of
+// the shell history, besides a literal value it accepts:
+//
+//   - @env:VAR_NAME to read the secret from an environment variable
+//   - @file:path to read the secret from a file
+//   - - to be prompted for the secret with masked input
+type secretFlag struct {
+	name   string
+	target *string
+}
+
+// secretFlagVar defines a secret flag, see secretFlag.
+func secretFlagVar(flags *pflag.FlagSet, p *string, name string, value string, usage string) {
+	*p = value
+	flags.Var(&secretFlag{name: name, target: p}, name, usage+secretFlagUsage)
+}
+
+func (f *secretFlag) Set(value string) error {
+	switch {
+	case value == "-":
+		secret := ""
+		err := survey.AskOne(&survey.Password{
+			Message: fmt.Sprintf("Enter the value of --%s:", f.name),
+		}, &secret, survey.WithValidator(survey.Required))
+		if err != nil {
+			return err
+		}
+		*f.target = secret
+
+	case strings.HasPrefix(value, "@env:"):
+		name := strings.TrimPrefix(value, "@env:")
+		secret, ok := os.LookupEnv(name)
+		if !ok || secret == "" {
+			return fmt.Errorf("environment variable %s is not set", name)
+		}
+		*f.target = secret
+
+	case strings.HasPrefix(value, "@file:"):
+		data, err := ioutil.ReadFile(strings.TrimPrefix(value, "@file:"))
+		if err != nil {
+			return err
+		}
+		*f.target = strings.TrimRight(string(data), "\r\n")
+
+	default:
+		*f.target = value
+	}
+
+	return nil
+}
+
+// String never returns the secret, so that it isn't printed as the default
+// value of the flag in the help.
+func (f *secretFlag) String() string {
+	if f.target == nil || *f.target == "" {
+		return ""
+	}
+	return "********"
+}
+
+func (f *secretFlag) Type() string {
+	return "secret"
+}
@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/validators"
+)
+
+type serviceCmd struct {
+	cmd *cobra.Command
+}
+
+func newServiceCmd() *serviceCmd {
+	sc := &serviceCmd{}
+
+	sc.cmd = &cobra.Command{
+		Use:   "service",
+		Args:  validators.NoArgs,
+		Short: "Run hookdeck listen as a background service",
+	}
+
+	sc.cmd.AddCommand(newServiceInstallCmd().cmd)
+	sc.cmd.AddCommand(newServiceUninstallCmd().cmd)
+	sc.cmd.AddCommand(newServiceRunCmd().cmd)
+
+	return sc
+}
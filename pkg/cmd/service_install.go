@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/ansi"
+	"github.com/hookdeck/hookdeck-cli/pkg/service"
+)
+
+var serviceNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+type serviceInstallCmd struct {
+	cmd     *cobra.Command
+	logFile string
+	print   bool
+}
+
+func newServiceInstallCmd() *serviceInstallCmd {
+	sc := &serviceInstallCmd{}
+
+	sc.cmd = &cobra.Command{
+		Use:   "install <name> -- <listen arguments>",
+		Short: "Install a service running hookdeck listen",
+		Long: `Install a service that runs "hookdeck listen" in the background and
+restarts it when it exits, using the current profile.
+
+On Linux, a systemd user unit is written to ~/.config/systemd/user. On
+macOS, a launchd agent is written to ~/Library/LaunchAgents. On Windows, a
+PowerShell script registering a Windows service is written to
+%LOCALAPPDATA%\Hookdeck\services, to run from an administrator prompt. The
+Windows service runs as the LocalSystem account with your hookdeck config.
+
+The service runs in the current directory so local configuration is picked up.`,
+		Example: `  hookdeck service install shopify -- 3000 shopify --path /webhooks
+  hookdeck service install stripe --log-file /var/log/hookdeck/stripe.log -- 4242 stripe`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			dash := cmd.ArgsLenAtDash()
+			if dash != 1 || len(args) < 2 {
+				return errors.New("a service name and the listen arguments are required, e.g. `hookdeck service install shopify -- 3000 shopify`")
+			}
+			return nil
+		},
+		RunE: sc.runServiceInstallCmd,
+	}
+	sc.cmd.Flags().StringVar(&sc.logFile, "log-file", "", "File to write the listener output to (default: the service manager logs)")
+	sc.cmd.Flags().BoolVar(&sc.print, "print", false, "Print the service definition instead of installing it")
+
+	return sc
+}
+
+func (sc *serviceInstallCmd) runServiceInstallCmd(cmd *cobra.Command, args []string) error {
+	if err := Config.Profile.ValidateAPIKey(); err != nil {
+		return err
+	}
+
+	svc, err := newService(args[0])
+	if err != nil {
+		return err
+	}
+	svc.Args = append([]string{"--profile", Config.Profile.Name, "listen"}, args[1:]...)
+
+	if sc.logFile != "" {
+		svc.LogFile, err = filepath.Abs(sc.logFile)
+		if err != nil {
+			return err
+		}
+	} else if svc.OS == "darwin" {
+		home, _ := os.UserHomeDir()
+		svc.LogFile = filepath.Join(home, "Library", "Logs", "Hookdeck", svc.Name+".log")
+	} else if svc.OS == "windows" {
+		// Windows services have no output of their own
+		home, _ := os.UserHomeDir()
+		svc.LogFile = filepath.Join(home, "AppData", "Local", "Hookdeck", "Logs", svc.Name+".log")
+	}
+	if svc.OS == "windows" {
+		svc.ConfigHome = filepath.Dir(filepath.Dir(Config.GlobalConfigFile))
+	}
+
+	if sc.print {
+		definition, err := svc.Definition()
+		if err != nil {
+			return err
+		}
+		fmt.Print(definition)
+		return nil
+	}
+
+	path, err := svc.Install()
+	if err != nil {
+		return err
+	}
+
+	printInfo("Installed service %s in %s", ansi.Bold(svc.Label()), path)
+	printInfo("Start it with:")
+	for _, command := range svc.StartCommands(path) {
+		printInfo("  %s", command)
+	}
+	if svc.LogFile != "" {
+		printInfo("Logs will be written to %s", svc.LogFile)
+	}
+
+	return nil
+}
+
+func newService(name string) (*service.Service, error) {
+	if !serviceNameRegexp.MatchString(name) {
+		return nil, fmt.Errorf("invalid service name %q, only letters, digits, dashes and underscores are allowed", name)
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+	executable, err = filepath.EvalSymlinks(executable)
+	if err != nil {
+		return nil, err
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+
+	return &service.Service{
+		Name:             name,
+		Executable:       executable,
+		WorkingDirectory: wd,
+		OS:               runtime.GOOS,
+	}, nil
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/service"
+)
+
+type serviceRunCmd struct {
+	cmd     *cobra.Command
+	options service.RunOptions
+}
+
+func newServiceRunCmd() *serviceRunCmd {
+	sc := &serviceRunCmd{}
+
+	sc.cmd = &cobra.Command{
+		Use:    "run -- <command>",
+		Short:  "Run a command as a Windows service",
+		Hidden: true,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if cmd.ArgsLenAtDash() != 0 || len(args) == 0 {
+				return errors.New("a command is required, e.g. `hookdeck service run -- hookdeck listen 3000 shopify`")
+			}
+			return nil
+		},
+		RunE: sc.runServiceRunCmd,
+	}
+	sc.cmd.Flags().StringVar(&sc.options.WorkingDirectory, "working-directory", "", "Directory the command runs in")
+	sc.cmd.Flags().StringVar(&sc.options.ConfigHome, "config-home", "", "Directory of the hookdeck config of the user")
+	sc.cmd.Flags().StringVar(&sc.options.OutputFile, "output-file", "", "File the output of the command is appended to")
+
+	return sc
+}
+
+func (sc *serviceRunCmd) runServiceRunCmd(cmd *cobra.Command, args []string) error {
+	return service.Run(args, sc.options)
+}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/validators"
+)
+
+type serviceUninstallCmd struct {
+	cmd *cobra.Command
+}
+
+func newServiceUninstallCmd() *serviceUninstallCmd {
+	sc := &serviceUninstallCmd{}
+
+	sc.cmd = &cobra.Command{
+		Use:     "uninstall <name>",
+		Args:    validators.ExactArgs(1),
+		Short:   "Remove a service installed with hookdeck service install",
+		Example: `  hookdeck service uninstall shopify`,
+		RunE:    sc.runServiceUninstallCmd,
+	}
+
+	return sc
+}
+
+func (sc *serviceUninstallCmd) runServiceUninstallCmd(cmd *cobra.Command, args []string) error {
+	svc, err := newService(args[0])
+	if err != nil {
+		return err
+	}
+
+	path, err := svc.Path()
+	if err != nil {
+		return err
+	}
+
+	printInfo("Stop the service first if it is running:")
+	for _, command := range svc.StopCommands(path) {
+		printInfo("  %s", command)
+	}
+	if err := confirm(fmt.Sprintf("Remove the service %s?", svc.Label())); err != nil {
+		return err
+	}
+
+	if _, err := svc.Uninstall(); err != nil {
+		return err
+	}
+
+	printInfo("Removed %s", path)
+
+	return nil
+}
@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/validators"
+)
+
+type sessionCmd struct {
+	cmd *cobra.Command
+}
+
+func newSessionCmd() *sessionCmd {
+	sc := &sessionCmd{}
+
+	sc.cmd = &cobra.Command{
+		Use:   "session",
+		Args:  validators.NoArgs,
+		Short: "Manage your CLI session",
+	}
+
+	sc.cmd.AddCommand(newSessionClaimCmd().cmd)
+
+	return sc
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/open"
+	"github.com/hookdeck/hookdeck-cli/pkg/validators"
+)
+
+type sessionClaimCmd struct {
+	cmd *cobra.Command
+}
+
+func newSessionClaimCmd() *sessionClaimCmd {
+	sc := &sessionClaimCmd{}
+
+	sc.cmd = &cobra.Command{
+		Use:   "claim",
+		Args:  validators.NoArgs,
+		Short: "Attach your guest session to a Hookdeck account",
+		Long: `Open the Console URL of your guest account to sign up, so your sources,
+connections and event URLs are kept in your Hookdeck account.`,
+		RunE: sc.runSessionClaimCmd,
+	}
+
+	return sc
+}
+
+func (sc *sessionClaimCmd) runSessionClaimCmd(cmd *cobra.Command, args []string) error {
+	if Config.Profile.GuestURL == "" {
+		return errors.New("the current profile isn't using a guest account")
+	}
+
+	fmt.Printf("Sign up to claim your guest account: %s\n", Config.Profile.GuestURL)
+
+	if open.CanOpenBrowser() {
+		return open.Browser(Config.Profile.GuestURL)
+	}
+
+	return nil
+}
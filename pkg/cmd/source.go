@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/validators"
+)
+
+type sourceCmd struct {
+	cmd *cobra.Command
+}
+
+func newSourceCmd() *sourceCmd {
+	sc := &sourceCmd{}
+
+	sc.cmd = &cobra.Command{
+		Use:   "source",
+		Args:  validators.NoArgs,
+		Short: "Manage your sources",
+	}
+
+	sc.cmd.AddCommand(newSourceSetVerificationCmd().cmd)
+	sc.cmd.AddCommand(newSourceVerifySampleCmd().cmd)
+	sc.cmd.AddCommand(newSourceRenameCmd().cmd)
+
+	return sc
+}
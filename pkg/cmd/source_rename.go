@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/source"
+	"github.com/hookdeck/hookdeck-cli/pkg/validators"
+)
+
+type sourceRenameCmd struct {
+	cmd    *cobra.Command
+	dryRun bool
+}
+
+func newSourceRenameCmd() *sourceRenameCmd {
+	rc := &sourceRenameCmd{}
+
+	rc.cmd = &cobra.Command{
+		Use:   "rename <source> <new-name>",
+		Args:  validators.ExactArgs(2),
+		Short: "Rename a source",
+		Long: `Rename a source, leaving its other settings and its URL unchanged. The
+rename fails if another source already has the new name. The full names of
+the connections of the source change along with its name, and are listed
+before the rename.`,
+		Example: `  hookdeck source rename stripe stripe-eu
+  hookdeck source rename stripe stripe-eu --dry-run`,
+		RunE: rc.runSourceRenameCmd,
+	}
+	rc.cmd.Flags().BoolVar(&rc.dryRun, "dry-run", false, "Show the names that would change without renaming")
+
+	return rc
+}
+
+func (rc *sourceRenameCmd) runSourceRenameCmd(cmd *cobra.Command, args []string) error {
+	if err := Config.Profile.ValidateAPIKey(); err != nil {
+		return err
+	}
+
+	client := Config.GetClient()
+	src, err := source.Get(client, args[0])
+	if err != nil {
+		return err
+	}
+	rename, err := source.PlanRename(client, src, args[1])
+	if err != nil {
+		return err
+	}
+
+	changes := []renameChange{{"source", src.Id, "name", src.Name, rename.Name}}
+	for _, conn := range rename.Connections {
+		if conn.FullName == nil {
+			continue
+		}
+		changes = append(changes, renameChange{"connection", conn.Id, "full_name", *conn.FullName, rename.FullName(conn)})
+	}
+	printRenameChanges(rc.dryRun, fmt.Sprintf("Renaming the source %s", src.Name), changes)
+	if listen := Config.GetListenDefaults(); listen.Source == src.Name {
+		warnListenDefault("source", listen.Source)
+	}
+	if rc.dryRun {
+		return nil
+	}
+
+	renamed, err := rename.Apply(client)
+	if err != nil {
+		return err
+	}
+	printInfo("Source %s renamed to %s", src.Name, renamed.Name)
+
+	return nil
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/AlecAivazis/survey/v2"
+	hookdecksdk "github.com/hookdeck/hookdeck-go-sdk"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/source"
+	"github.com/hookdeck/hookdeck-cli/pkg/validators"
+)
+
+type sourceSetVerificationCmd struct {
+	cmd *cobra.Command
+
+	opts     source.VerificationOptions
+	fromJSON string
+}
+
+func newSourceSetVerificationCmd() *sourceSetVerificationCmd {
+	sc := &sourceSetVerificationCmd{}
+
+	sc.cmd = &cobra.Command{
+		Use:   "set-verification <source>",
+		Args:  validators.ExactArgs(1),
+		Short: "Set the verification of a source",
+		Long: `Set how Hookdeck verifies the requests received by a source, leaving its
+other settings and its connections unchanged.
+
+--type is hmac, basic_auth, api_key, or a provider such as stripe, github or
+shopify, set up with its webhook secret. The secret is read from --secret, or
+prompted for. For the providers needing other settings, pass the whole
+verification config with --from-json, in the format of the Hookdeck API.
+
+The existing verification of the source is replaced, after a confirmation.`,
+		Example: `  hookdeck source set-verification stripe --type stripe --secret @env:STRIPE_WEBHOOK_SECRET
+  hookdeck source set-verification my-app --type hmac --header-key X-Signature --algorithm sha256 --encoding hex --secret -
+  hookdeck source set-verification partner --type basic_auth --username partner --secret @file:password.txt
+  hookdeck source set-verification twitter --from-json @verification.json --yes`,
+		RunE: sc.runSourceSetVerificationCmd,
+	}
+	sc.cmd.Flags().StringVar(&sc.opts.Type, "type", "", "Verification type: hmac, basic_auth, api_key, or a provider such as stripe")
+	secretFlagVar(sc.cmd.Flags(), &sc.opts.Secret, "secret", "", "Webhook secret, password of basic_auth, or API key of api_key")
+	sc.cmd.Flags().StringVar(&sc.opts.Username, "username", "", "Username of basic_auth verification")
+	sc.cmd.Flags().StringVar(&sc.opts.HeaderKey, "header-key", "", "Header holding the signature of hmac, or the key of api_key verification")
+	sc.cmd.Flags().StringVar(&sc.opts.Algorithm, "algorithm", "", "Algorithm of hmac verification: sha1, sha256, sha512 or md5 (default \"sha256\")")
+	sc.cmd.Flags().StringVar(&sc.opts.Encoding, "encoding", "", "Encoding of the hmac signature: base64, base64url or hex (default \"base64\")")
+	sc.cmd.Flags().StringVar(&sc.fromJSON, "from-json", "", "Verification config as JSON, or @path to read it from a file, instead of the other flags")
+
+	return sc
+}
+
+func (sc *sourceSetVerificationCmd) runSourceSetVerificationCmd(cmd *cobra.Command, args []string) error {
+	if (sc.opts.Type == "") == (sc.fromJSON == "") {
+		return errors.New("either --type or --from-json is required")
+	}
+	if sc.fromJSON != "" {
+		for _, name := range []string{"secret", "username", "header-key", "algorithm", "encoding"} {
+			if cmd.Flags().Changed(name) {
+				return fmt.Errorf("--%s can't be used with --from-json", name)
+			}
+		}
+	}
+
+	if err := Config.Profile.ValidateAPIKey(); err != nil {
+		return err
+	}
+
+	verification, err := sc.verification()
+	if err != nil {
+		return err
+	}
+
+	client := Config.GetClient()
+	src, err := source.Get(client, args[0])
+	if err != nil {
+		return err
+	}
+
+	if src.Verification != nil && src.Verification.VerificationConfig != nil {
+		message := fmt.Sprintf("Replace the %s verification of source %s with %s?", src.Verification.VerificationConfig.Type, src.Name, verification.Type)
+		if err := confirm(message); err != nil {
+			return err
+		}
+	}
+
+	if _, err := source.SetVerification(client, src, verification); err != nil {
+		return err
+	}
+
+	printInfo("Verification of source %s set to %s", src.Name, verification.Type)
+
+	return nil
+}
+
+// verification returns the verification config of the flags, prompting for
+// the secret when it isn't set.
+func (sc *sourceSetVerificationCmd) verification() (*hookdecksdk.VerificationConfig, error) {
+	if sc.fromJSON != "" {
+		data, err := readBodyFlag(sc.fromJSON)
+		if err != nil {
+			return nil, err
+		}
+		return source.ParseVerification([]byte(data))
+	}
+
+	if sc.opts.Secret == "" {
+		if !term.IsTerminal(int(os.Stdin.Fd())) {
+			return nil, fmt.Errorf("%s verification needs a secret, set it with --secret", sc.opts.Type)
+		}
+		err := survey.AskOne(&survey.Password{
+			Message: fmt.Sprintf("Secret of the %s verification:", sc.opts.Type),
+		}, &sc.opts.Secret, survey.WithValidator(survey.Required))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return source.NewVerification(sc.opts)
+}
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/ansi"
+	"github.com/hookdeck/hookdeck-cli/pkg/signature"
+	"github.com/hookdeck/hookdeck-cli/pkg/source"
+	"github.com/hookdeck/hookdeck-cli/pkg/validators"
+)
+
+type sourceVerifySampleCmd struct {
+	cmd        *cobra.Command
+	sourceType string
+	secret     string
+	body       string
+	source     string
+	send       string
+}
+
+func newSourceVerifySampleCmd() *sourceVerifySampleCmd {
+	sc := &sourceVerifySampleCmd{}
+
+	sc.cmd = &cobra.Command{
+		Use:   "verify-sample",
+		Args:  validators.NoArgs,
+		Short: "Generate a signed sample webhook request",
+		Long: fmt.Sprintf(`Generate a correctly signed sample webhook request for a source type.
+
+The request is printed to stdout. Use --source to send it to the URL of one
+of your Hookdeck sources, or --send to send it to any URL, such as your
+local server.
+
+Supported source types: %s`, strings.Join(signature.Providers(), ", ")),
+		RunE: sc.runSourceVerifySampleCmd,
+	}
+	sc.cmd.Flags().StringVar(&sc.sourceType, "type", "", "The source type to sign the request for")
+	secretFlagVar(sc.cmd.Flags(), &sc.secret, "secret", "", "The webhook secret used to sign the request")
+	sc.cmd.Flags().StringVar(&sc.body, "body", "", "The request body, or @path to read it from a file (defaults to a sample payload)")
+	sc.cmd.Flags().StringVar(&sc.source, "source", "", "Name of the Hookdeck source to send the request to")
+	sc.cmd.Flags().StringVar(&sc.send, "send", "", "URL to send the request to")
+	sc.cmd.MarkFlagRequired("type")
+	sc.cmd.MarkFlagRequired("secret")
+
+	return sc
+}
+
+func (sc *sourceVerifySampleCmd) runSourceVerifySampleCmd(cmd *cobra.Command, args []string) error {
+	if sc.source != "" && sc.send != "" {
+		return fmt.Errorf("only one of --source or --send can be provided")
+	}
+
+	body, err := readBodyFlag(sc.body)
+	if err != nil {
+		return err
+	}
+	if body == "" {
+		body = signature.SampleBody(sc.sourceType)
+	}
+
+	header, err := signature.Sign(sc.sourceType, sc.secret, body, time.Now())
+	if err != nil {
+		return err
+	}
+
+	target := sc.send
+	if sc.source != "" {
+		if err := Config.Profile.ValidateAPIKey(); err != nil {
+			return err
+		}
+
+		src, err := source.Get(Config.GetClient(), sc.source)
+		if err != nil {
+			return err
+		}
+		target = src.Url
+	}
+
+	printSampleRequest(target, header, body)
+
+	if target == "" {
+		return nil
+	}
+
+	return sendSampleRequest(target, header, body)
+}
+
+func printSampleRequest(target string, header http.Header, body string) {
+	if target == "" {
+		target = "/"
+	}
+	fmt.Printf("POST %s\n", target)
+
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Printf("%s: %s\n", name, header.Get(name))
+	}
+
+	fmt.Printf("\n%s\n", body)
+}
+
+func sendSampleRequest(target string, header http.Header, body string) error {
+	req, err := http.NewRequest(http.MethodPost, target, strings.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header = header
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	fmt.Printf("\n%d %s\n", ansi.ColorizeStatus(res.StatusCode), http.StatusText(res.StatusCode))
+
+	return nil
+}
+
+// readBodyFlag returns the flag value, or the content of the file when the
+// value is prefixed with "@".
+func readBodyFlag(value string) (string, error) {
+	if !strings.HasPrefix(value, "@") {
+		return value, nil
+	}
+
+	content, err := ioutil.ReadFile(strings.TrimPrefix(value, "@"))
+	if err != nil {
+		return "", err
+	}
+
+	return string(content), nil
+}
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/validators"
+)
+
+type telemetryCmd struct {
+	cmd *cobra.Command
+}
+
+func newTelemetryCmd() *telemetryCmd {
+	tc := &telemetryCmd{}
+
+	tc.cmd = &cobra.Command{
+		Use:   "telemetry",
+		Args:  validators.NoArgs,
+		Short: "Manage anonymous usage metrics",
+		Long: `Manage anonymous usage metrics.
+
+Telemetry is disabled unless you enable it. When enabled, the API requests made
+by the CLI include the name of the command you run and your device name, along
+with the duration and error class (e.g. auth or not_found) of the previous
+command. Payloads, arguments, flags and credentials are never sent.
+
+Set the HOOKDECK_CLI_TELEMETRY_OPTOUT=1 environment variable to disable telemetry
+regardless of the config.`,
+	}
+
+	tc.cmd.AddCommand(newTelemetryStatusCmd().cmd)
+	tc.cmd.AddCommand(newTelemetryEnableCmd().cmd)
+	tc.cmd.AddCommand(newTelemetryDisableCmd().cmd)
+
+	return tc
+}
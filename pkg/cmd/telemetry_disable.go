@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/config"
+	"github.com/hookdeck/hookdeck-cli/pkg/validators"
+)
+
+type telemetryDisableCmd struct {
+	cmd *cobra.Command
+}
+
+func newTelemetryDisableCmd() *telemetryDisableCmd {
+	tc := &telemetryDisableCmd{}
+
+	tc.cmd = &cobra.Command{
+		Use:   "disable",
+		Args:  validators.NoArgs,
+		Short: "Disable anonymous usage metrics",
+		RunE:  tc.runTelemetryDisableCmd,
+	}
+
+	return tc
+}
+
+func (tc *telemetryDisableCmd) runTelemetryDisableCmd(cmd *cobra.Command, args []string) error {
+	key, err := config.GetKey("telemetry")
+	if err != nil {
+		return err
+	}
+	if err := Config.SetValue(key, "false", false); err != nil {
+		return err
+	}
+
+	printInfo("Telemetry disabled")
+
+	return nil
+}
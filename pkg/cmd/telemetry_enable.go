@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/config"
+	"github.com/hookdeck/hookdeck-cli/pkg/validators"
+)
+
+type telemetryEnableCmd struct {
+	cmd *cobra.Command
+}
+
+func newTelemetryEnableCmd() *telemetryEnableCmd {
+	tc := &telemetryEnableCmd{}
+
+	tc.cmd = &cobra.Command{
+		Use:   "enable",
+		Args:  validators.NoArgs,
+		Short: "Enable anonymous usage metrics",
+		RunE:  tc.runTelemetryEnableCmd,
+	}
+
+	return tc
+}
+
+func (tc *telemetryEnableCmd) runTelemetryEnableCmd(cmd *cobra.Command, args []string) error {
+	key, err := config.GetKey("telemetry")
+	if err != nil {
+		return err
+	}
+	if err := Config.SetValue(key, "true", false); err != nil {
+		return err
+	}
+
+	printInfo("Telemetry enabled")
+
+	return nil
+}
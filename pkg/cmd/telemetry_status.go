@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/hookdeck"
+	"github.com/hookdeck/hookdeck-cli/pkg/validators"
+)
+
+type telemetryStatusCmd struct {
+	cmd *cobra.Command
+}
+
+func newTelemetryStatusCmd() *telemetryStatusCmd {
+	tc := &telemetryStatusCmd{}
+
+	tc.cmd = &cobra.Command{
+		Use:   "status",
+		Args:  validators.NoArgs,
+		Short: "Show whether telemetry is enabled",
+		RunE:  tc.runTelemetryStatusCmd,
+	}
+
+	return tc
+}
+
+func (tc *telemetryStatusCmd) runTelemetryStatusCmd(cmd *cobra.Command, args []string) error {
+	switch {
+	case hookdeck.TelemetryOptedOut():
+		fmt.Printf("Telemetry is disabled by the %s environment variable\n", hookdeck.TelemetryOptOutEnvVar)
+	case Config.Telemetry:
+		fmt.Println("Telemetry is enabled")
+	default:
+		fmt.Println("Telemetry is disabled")
+	}
+
+	return nil
+}
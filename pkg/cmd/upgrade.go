@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/ansi"
+	"github.com/hookdeck/hookdeck-cli/pkg/upgrade"
+	"github.com/hookdeck/hookdeck-cli/pkg/validators"
+	"github.com/hookdeck/hookdeck-cli/pkg/version"
+)
+
+type upgradeCmd struct {
+	cmd   *cobra.Command
+	check bool
+	force bool
+}
+
+func newUpgradeCmd() *upgradeCmd {
+	uc := &upgradeCmd{}
+
+	uc.cmd = &cobra.Command{
+		Use:   "upgrade",
+		Args:  validators.NoArgs,
+		Short: "Upgrade the CLI to the latest version",
+		Long: `Upgrade the CLI to the latest version.
+
+The release archive is downloaded from GitHub and its checksum verified before
+the current binary is replaced. The checksum comes from the same GitHub release,
+so it only protects against corrupted downloads: releases aren't signed, and
+their authenticity relies on the HTTPS connection to GitHub. If you installed
+the CLI with a package manager (Homebrew, Scoop, NPM), upgrade it with the
+package manager instead.
+
+The CLI is never downgraded. A version built from source is only replaced by the
+latest release with --force.`,
+		RunE: uc.runUpgradeCmd,
+	}
+	uc.cmd.Flags().BoolVar(&uc.check, "check", false, "Only check whether a new version is available")
+	uc.cmd.Flags().BoolVar(&uc.force, "force", false, "Replace a version built from source with the latest release")
+
+	return uc
+}
+
+func (uc *upgradeCmd) runUpgradeCmd(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	release, err := upgrade.LatestRelease(ctx)
+	if err != nil {
+		return fmt.Errorf("checking the latest release: %w", err)
+	}
+
+	if version.IsDev(version.Version) {
+		fmt.Printf("The latest version is %s (current: %s, built from source)\n", release.Version, version.Version)
+		if uc.check {
+			return nil
+		}
+		if !uc.force {
+			return fmt.Errorf("this CLI was built from source, use --force to replace it with version %s", release.Version)
+		}
+	} else {
+		cmp, err := version.Compare(version.Version, release.Version)
+		if err != nil {
+			return fmt.Errorf("checking the latest release: %w", err)
+		}
+		if cmp == 0 {
+			fmt.Printf("You are using the latest version (%s)\n", version.Version)
+			return nil
+		}
+		if cmp > 0 {
+			fmt.Printf("You are using a version newer than the latest release (current: %s, latest: %s)\n", version.Version, release.Version)
+			return nil
+		}
+
+		fmt.Printf("A new version is available: %s (current: %s)\n", release.Version, version.Version)
+		if uc.check {
+			return nil
+		}
+	}
+
+	path, err := upgrade.CheckExecutable()
+	if err != nil {
+		return err
+	}
+
+	s := ansi.StartNewSpinner(fmt.Sprintf("Downloading version %s...", release.Version), ansi.StatusWriter)
+	binary, err := release.Download(ctx, runtime.GOOS, runtime.GOARCH)
+	ansi.StopSpinner(s, "", ansi.StatusWriter)
+	if err != nil {
+		return err
+	}
+
+	if err := upgrade.ReplaceExecutable(path, binary); err != nil {
+		return fmt.Errorf("replacing %s: %w", path, err)
+	}
+
+	printInfo("Upgraded to version %s", release.Version)
+
+	return nil
+}
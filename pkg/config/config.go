@@ -3,6 +3,7 @@ package config
 import (
 	"bytes"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -17,7 +18,11 @@ import (
 	prefixed "github.com/x-cray/logrus-prefixed-formatter"
 
 	"github.com/hookdeck/hookdeck-cli/pkg/ansi"
+	"github.com/hookdeck/hookdeck-cli/pkg/cache"
 	"github.com/hookdeck/hookdeck-cli/pkg/hookdeck"
+	"github.com/hookdeck/hookdeck-cli/pkg/logfile"
+	"github.com/hookdeck/hookdeck-cli/pkg/mockapi"
+	"github.com/hookdeck/hookdeck-cli/pkg/output"
 )
 
 // ColorOn represnets the on-state for colors
@@ -29,19 +34,43 @@ const ColorOff = "off"
 // ColorAuto represents the auto-state for colors
 const ColorAuto = "auto"
 
+// localConfigPath is the path of the local config file, relative to the
+// directory it applies to
+const localConfigPath = ".hookdeck/config.toml"
+
 // Config handles all overall configuration for the CLI
 type Config struct {
 	Profile    Profile
 	Color      string
+	NoColor    bool
 	LogLevel   string
 	DeviceName string
 
+	// LogFile is the file the logs are written to instead of stderr,
+	// rotated once larger than LogMaxSize megabytes or older than
+	// LogMaxAge, keeping LogMaxBackups rotated files
+	LogFile       string
+	LogMaxSize    int
+	LogMaxAge     time.Duration
+	LogMaxBackups int
+
+	// Quiet suppresses the status and informational output of commands
+	Quiet bool
+	// Yes answers yes to the confirmations of commands
+	Yes bool
+
 	// Helpers
 	APIBaseURL       string
+	APIVersion       string
 	DashboardBaseURL string
 	ConsoleBaseURL   string
 	WSBaseURL        string
+	Proxy            string
 	Insecure         bool
+	MaxRetries       int
+	Verbosity        int
+	UpdateCheck      bool
+	Telemetry        bool
 
 	// Config
 	GlobalConfigFile string
@@ -78,6 +107,14 @@ func (c *Config) GetConfigFolder(xdgPath string) string {
 func (c *Config) InitConfig() {
 	c.Profile.Config = c
 
+	// Quiet only keeps warnings and errors, unless a log level is set
+	if c.Quiet {
+		ansi.StatusWriter = ioutil.Discard
+		if c.LogLevel == "info" {
+			c.LogLevel = "warn"
+		}
+	}
+
 	// Set log level
 	switch c.LogLevel {
 	case "debug":
@@ -126,7 +163,7 @@ func (c *Config) InitConfig() {
 	}
 	localConfigFile := ""
 	if c.LocalConfigFile == "" {
-		localConfigFile = filepath.Join(workspaceFolder, ".hookdeck/config.toml")
+		localConfigFile = findLocalConfigFile(workspaceFolder)
 	} else {
 		if filepath.IsAbs(c.LocalConfigFile) {
 			localConfigFile = c.LocalConfigFile
@@ -147,6 +184,21 @@ func (c *Config) InitConfig() {
 	// Construct the config struct
 	c.constructConfig()
 
+	if err := hookdeck.ValidateAPIVersion(c.APIVersion); err != nil {
+		log.Fatal(err)
+	}
+	if !hookdeck.IsKnownAPIVersion(c.APIVersion) {
+		log.Warnf("API version %s is newer than this version of the CLI, responses are decoded with the models of %s. Run `hookdeck upgrade` to get the latest version.", c.APIVersion, hookdeck.DefaultAPIVersion)
+	}
+
+	if err := hookdeck.SetProxy(c.Proxy); err != nil {
+		log.Fatal(err)
+	}
+
+	if c.NoColor {
+		c.Color = ColorOff
+	}
+
 	if c.DeviceName == "" {
 		deviceName, err := os.Hostname()
 		if err != nil {
@@ -168,6 +220,16 @@ func (c *Config) InitConfig() {
 		log.Fatalf("Unrecognized color value: %s. Expected one of on, off, auto.", c.Color)
 	}
 
+	if c.LogFile != "" {
+		w, err := logfile.Open(c.LogFile, int64(c.LogMaxSize)*1024*1024, c.LogMaxAge, c.LogMaxBackups)
+		if err != nil {
+			log.Fatalf("Could not open the log file: %s", err)
+		}
+		logFormatter.DisableColors = true
+		logFormatter.ForceFormatting = true
+		log.SetOutput(w)
+	}
+
 	log.SetFormatter(logFormatter)
 }
 
@@ -266,18 +328,81 @@ func (c *Config) WriteLocalConfig() error {
 	return c.LocalConfig.WriteConfig()
 }
 
+// findLocalConfigFile returns the path of the nearest .hookdeck/config.toml
+// file in dir or one of its parents, so that a project pinned at the root of
+// a repository applies to all of its subdirectories. It defaults to the file
+// in dir when none exists.
+func findLocalConfigFile(dir string) string {
+	for current := dir; ; {
+		file := filepath.Join(current, localConfigPath)
+		if _, err := os.Stat(file); err == nil {
+			return file
+		}
+
+		parent := filepath.Dir(current)
+		if parent == current {
+			return filepath.Join(dir, localConfigPath)
+		}
+		current = parent
+	}
+}
+
+// ListenDefaults are the default arguments and flags of the listen command
+// set in the [listen] table of the local config.
+type ListenDefaults struct {
+	Port       string
+	Source     string
+	Connection string
+	Path       string
+	Output     string
+}
+
+// GetListenDefaults returns the listen defaults of the local config.
+func (c *Config) GetListenDefaults() ListenDefaults {
+	return ListenDefaults{
+		Port:       c.LocalConfig.GetString("listen.port"),
+		Source:     c.LocalConfig.GetString("listen.source"),
+		Connection: c.LocalConfig.GetString("listen.connection"),
+		Path:       c.LocalConfig.GetString("listen.path"),
+		Output:     c.LocalConfig.GetString("listen.output"),
+	}
+}
+
+// GetCache returns the cache of API lookups, stored next to the global
+// config file.
+func (c *Config) GetCache() cache.Cache {
+	return cache.Cache{Dir: filepath.Join(filepath.Dir(c.GlobalConfigFile), "cache")}
+}
+
 // Construct the config struct from flags > local config > global config
 func (c *Config) constructConfig() {
 	c.Color = getStringConfig([]string{c.Color, c.LocalConfig.GetString("color"), c.GlobalConfig.GetString(("color")), "auto"})
+	output.DefaultFormat = getStringConfig([]string{c.LocalConfig.GetString("output"), c.GlobalConfig.GetString("output"), output.FormatTable})
+	c.UpdateCheck = getStringConfig([]string{c.LocalConfig.GetString("update_check"), c.GlobalConfig.GetString("update_check"), "true"}) != "false"
+	c.Telemetry = getStringConfig([]string{c.LocalConfig.GetString("telemetry"), c.GlobalConfig.GetString("telemetry"), "false"}) == "true"
 	c.LogLevel = getStringConfig([]string{c.LogLevel, c.LocalConfig.GetString("log"), c.GlobalConfig.GetString(("log")), "info"})
+	c.LogFile = getStringConfig([]string{c.LogFile, c.LocalConfig.GetString("log_file"), c.GlobalConfig.GetString("log_file"), ""})
 	c.APIBaseURL = getStringConfig([]string{c.APIBaseURL, c.LocalConfig.GetString("api_base"), c.GlobalConfig.GetString(("api_base")), hookdeck.DefaultAPIBaseURL})
+	c.APIVersion = getStringConfig([]string{c.APIVersion, os.Getenv("HOOKDECK_API_VERSION"), c.LocalConfig.GetString("api_version"), c.GlobalConfig.GetString("api_version"), hookdeck.DefaultAPIVersion})
 	c.DashboardBaseURL = getStringConfig([]string{c.DashboardBaseURL, c.LocalConfig.GetString("dashboard_base"), c.GlobalConfig.GetString(("dashboard_base")), hookdeck.DefaultDashboardBaseURL})
 	c.ConsoleBaseURL = getStringConfig([]string{c.ConsoleBaseURL, c.LocalConfig.GetString("console_base"), c.GlobalConfig.GetString(("console_base")), hookdeck.DefaultConsoleBaseURL})
+	c.Proxy = getStringConfig([]string{c.Proxy, os.Getenv("HOOKDECK_PROXY"), c.LocalConfig.GetString("proxy"), c.GlobalConfig.GetString("proxy"), ""})
 	c.WSBaseURL = getStringConfig([]string{c.WSBaseURL, c.LocalConfig.GetString("ws_base"), c.GlobalConfig.GetString(("ws_base")), hookdeck.DefaultWebsocektURL})
 	c.Profile.Name = getStringConfig([]string{c.Profile.Name, c.LocalConfig.GetString("profile"), c.GlobalConfig.GetString(("profile")), hookdeck.DefaultProfileName})
 	c.Profile.APIKey = getStringConfig([]string{c.Profile.APIKey, c.LocalConfig.GetString("api_key"), c.GlobalConfig.GetString((c.Profile.GetConfigField("api_key"))), ""})
 	c.Profile.TeamID = getStringConfig([]string{c.Profile.TeamID, c.LocalConfig.GetString("workspace_id"), c.LocalConfig.GetString("team_id"), c.GlobalConfig.GetString((c.Profile.GetConfigField("workspace_id"))), c.GlobalConfig.GetString((c.Profile.GetConfigField("team_id"))), ""})
 	c.Profile.TeamMode = getStringConfig([]string{c.Profile.TeamMode, c.LocalConfig.GetString("workspace_mode"), c.LocalConfig.GetString("team_mode"), c.GlobalConfig.GetString((c.Profile.GetConfigField("workspace_mode"))), c.GlobalConfig.GetString((c.Profile.GetConfigField("team_mode"))), ""})
+	c.Profile.GuestURL = c.GlobalConfig.GetString(c.Profile.GetConfigField("guest_url"))
+
+	// When using the mock server, send all API and websocket requests to it
+	// with the mock credentials so real API keys never leave the machine
+	if mockServer := os.Getenv(mockapi.EnvVar); mockServer != "" {
+		c.APIBaseURL = mockServer
+		c.WSBaseURL = mockServer
+		c.Profile.APIKey = mockapi.APIKey
+		c.Profile.TeamID = mockapi.Project.Id
+		c.Profile.TeamMode = mockapi.Project.Mode
+	}
 }
 
 func getStringConfig(values []string) string {
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/spf13/viper"
@@ -18,3 +20,16 @@ func TestRemoveKey(t *testing.T) {
 	require.EqualValues(t, []string{"stay"}, nv.AllKeys())
 	require.ElementsMatch(t, []string{"stay", "remove"}, v.AllKeys())
 }
+
+func TestFindLocalConfigFile(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "services", "api")
+	require.NoError(t, os.MkdirAll(nested, 0755))
+
+	require.Equal(t, filepath.Join(nested, localConfigPath), findLocalConfigFile(nested))
+
+	require.NoError(t, os.MkdirAll(filepath.Join(root, ".hookdeck"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, localConfigPath), []byte(""), 0644))
+
+	require.Equal(t, filepath.Join(root, localConfigPath), findLocalConfigFile(nested))
+}
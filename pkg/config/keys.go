@@ -0,0 +1,132 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/hookdeck"
+)
+
+// Key is a configuration value that can be managed with `hookdeck config`.
+type Key struct {
+	Name        string
+	Description string
+	// Field is the name of the field in the config file, when it differs
+	// from the key name
+	Field string
+	// PerProfile keys are stored in the profile section of the global
+	// config file
+	PerProfile bool
+	// Values lists the accepted values, if restricted
+	Values []string
+	// Validate checks the values, if set
+	Validate func(value string) error
+}
+
+// Keys lists the configuration values managed by `hookdeck config`.
+var Keys = []Key{
+	{Name: "api_key", Description: "API key used to authenticate", PerProfile: true},
+	{Name: "project", Description: "ID of the active project", Field: "workspace_id", PerProfile: true},
+	{Name: "profile", Description: "Name of the active profile"},
+	{Name: "color", Description: "Color output", Values: []string{ColorOn, ColorOff, ColorAuto}},
+	{Name: "log", Description: "Log level", Values: []string{"debug", "info", "warn", "error"}},
+	{Name: "log_file", Description: "File the logs are written to instead of stderr, rotated by size and age"},
+	{Name: "output", Description: "Default output format of list commands", Values: []string{"table", "json", "yaml"}},
+	{Name: "update_check", Description: "Daily notice when a new version is available", Values: []string{"true", "false"}},
+	{Name: "telemetry", Description: "Send anonymous usage metrics", Values: []string{"true", "false"}},
+	{Name: "api_base", Description: "Base URL of the Hookdeck API"},
+	{Name: "api_version", Description: "Version of the Hookdeck API, e.g. " + hookdeck.DefaultAPIVersion, Validate: hookdeck.ValidateAPIVersion},
+	{Name: "dashboard_base", Description: "Base URL of the Hookdeck dashboard"},
+	{Name: "console_base", Description: "Base URL of the Hookdeck console"},
+	{Name: "ws_base", Description: "Base URL of the Hookdeck websocket API"},
+	{Name: "proxy", Description: "Proxy URL used to connect to Hookdeck (http:// or socks5://)"},
+}
+
+// GetKey returns the key with the given name.
+func GetKey(name string) (Key, error) {
+	for _, key := range Keys {
+		if key.Name == name {
+			return key, nil
+		}
+	}
+
+	names := make([]string, len(Keys))
+	for i, key := range Keys {
+		names[i] = key.Name
+	}
+	sort.Strings(names)
+
+	return Key{}, fmt.Errorf("unknown config key %q. Available keys: %s", name, strings.Join(names, ", "))
+}
+
+func (k Key) field(c *Config, local bool) string {
+	field := k.Name
+	if k.Field != "" {
+		field = k.Field
+	}
+	if k.PerProfile && !local {
+		field = c.Profile.GetConfigField(field)
+	}
+	return field
+}
+
+// GetValue returns the value of the key in the local or global config file.
+func (c *Config) GetValue(key Key, local bool) string {
+	if local {
+		return c.LocalConfig.GetString(key.field(c, true))
+	}
+	return c.GlobalConfig.GetString(key.field(c, false))
+}
+
+// SetValue sets the value of the key in the local or global config file.
+func (c *Config) SetValue(key Key, value string, local bool) error {
+	if len(key.Values) > 0 && !contains(key.Values, value) {
+		return fmt.Errorf("invalid value %q for %s. Expected one of %s", value, key.Name, strings.Join(key.Values, ", "))
+	}
+	if key.Validate != nil {
+		if err := key.Validate(value); err != nil {
+			return err
+		}
+	}
+
+	if local {
+		c.LocalConfig.Set(key.field(c, true), value)
+		return c.WriteLocalConfig()
+	}
+
+	c.GlobalConfig.Set(key.field(c, false), value)
+	return c.WriteGlobalConfig()
+}
+
+// UnsetValue removes the key from the local or global config file.
+func (c *Config) UnsetValue(key Key, local bool) error {
+	v := c.GlobalConfig
+	if local {
+		v = c.LocalConfig
+	}
+
+	nv, err := removeKey(v, key.field(c, local))
+	if err != nil {
+		return err
+	}
+	nv.SetConfigType("toml")
+	nv.SetConfigFile(v.ConfigFileUsed())
+
+	if local {
+		c.LocalConfig = nv
+		return c.WriteLocalConfig()
+	}
+
+	c.GlobalConfig = nv
+	return c.WriteGlobalConfig()
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -7,6 +7,8 @@ type Profile struct {
 	APIKey   string
 	TeamID   string
 	TeamMode string
+	// Console URL of the guest account, until it is claimed
+	GuestURL string
 
 	Config *Config
 }
@@ -31,6 +33,7 @@ func (p *Profile) SaveProfile(local bool) error {
 		p.Config.GlobalConfig.Set(p.GetConfigField("api_key"), p.APIKey)
 		p.Config.GlobalConfig.Set(p.GetConfigField("workspace_id"), p.TeamID)
 		p.Config.GlobalConfig.Set(p.GetConfigField("workspace_mode"), p.TeamMode)
+		p.Config.GlobalConfig.Set(p.GetConfigField("guest_url"), p.GuestURL)
 		return p.Config.WriteGlobalConfig()
 	}
 }
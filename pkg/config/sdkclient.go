@@ -12,12 +12,21 @@ var once sync.Once
 
 func (c *Config) GetClient() *hookdeckclient.Client {
 	once.Do(func() {
-		client = hookdeck.CreateSDKClient(hookdeck.SDKClientInit{
-			APIBaseURL: c.APIBaseURL,
-			APIKey:     c.Profile.APIKey,
-			TeamID:     c.Profile.TeamID,
-		})
+		client = c.GetProjectClient(c.Profile.TeamID)
 	})
 
 	return client
 }
+
+// GetProjectClient returns a client for the given project rather than the
+// active one.
+func (c *Config) GetProjectClient(teamID string) *hookdeckclient.Client {
+	return hookdeck.CreateSDKClient(hookdeck.SDKClientInit{
+		APIBaseURL: c.APIBaseURL,
+		APIKey:     c.Profile.APIKey,
+		TeamID:     teamID,
+		MaxRetries: c.MaxRetries,
+		Verbosity:  c.Verbosity,
+		APIVersion: c.APIVersion,
+	})
+}
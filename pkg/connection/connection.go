@@ -0,0 +1,44 @@
+package connection
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	hookdecksdk "github.com/hookdeck/hookdeck-go-sdk"
+	hookdeckclient "github.com/hookdeck/hookdeck-go-sdk/client"
+)
+
+// Get finds a connection by ID or by name. Since connection names are only
+// unique per source, the full name ("source -> destination") can be used
+// to select a connection when several share the same name.
+func Get(client *hookdeckclient.Client, nameOrID string) (*hookdecksdk.Connection, error) {
+	if strings.HasPrefix(nameOrID, "web_") {
+		return client.Connection.Retrieve(context.Background(), nameOrID)
+	}
+
+	query := &hookdecksdk.ConnectionListRequest{Name: &nameOrID}
+	if strings.Contains(nameOrID, "->") {
+		query = &hookdecksdk.ConnectionListRequest{FullName: &nameOrID}
+	}
+
+	connections, err := client.Connection.List(context.Background(), query)
+	if err != nil {
+		return nil, err
+	}
+
+	switch len(connections.Models) {
+	case 0:
+		return nil, fmt.Errorf("connection %q not found", nameOrID)
+	case 1:
+		return connections.Models[0], nil
+	default:
+		names := make([]string, 0, len(connections.Models))
+		for _, connection := range connections.Models {
+			if connection.FullName != nil {
+				names = append(names, *connection.FullName)
+			}
+		}
+		return nil, fmt.Errorf("found %d connections named %q, use the ID or the full name instead: %s", len(connections.Models), nameOrID, strings.Join(names, ", "))
+	}
+}
@@ -0,0 +1,114 @@
+package connection
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+)
+
+// Operations of a promotion.
+const (
+	OperationCreate = "create"
+	OperationUpdate = "update"
+)
+
+// FieldChange is a field of the connection definition set by a promotion.
+// Field is the path of the field, e.g. "destination.url". Arrays, such as the
+// rules, are replaced as a whole so they're a single field.
+type FieldChange struct {
+	Field  string      `json:"field"`
+	Before interface{} `json:"before,omitempty"`
+	After  interface{} `json:"after"`
+}
+
+// Plan is the machine-readable preview of a promotion.
+type Plan struct {
+	Operation string        `json:"operation"`
+	Changes   []FieldChange `json:"changes"`
+	// Preserved lists the fields of the existing connection left as they
+	// are, because they're unchanged or not set by the promotion
+	Preserved []string `json:"preserved"`
+	Warnings  []string `json:"warnings"`
+}
+
+// Plan returns the preview of the promotion over the definition of the
+// existing connection, as returned by Definition, or an empty string when
+// the connection doesn't exist yet. Secrets are redacted.
+func (p *Promotion) Plan(current string) (*Plan, error) {
+	definition, err := p.Definition()
+	if err != nil {
+		return nil, err
+	}
+	after, err := flattenDefinition(definition)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &Plan{
+		Operation: OperationCreate,
+		Changes:   []FieldChange{},
+		Preserved: []string{},
+		Warnings:  p.Warnings,
+	}
+	if plan.Warnings == nil {
+		plan.Warnings = []string{}
+	}
+
+	before := map[string]interface{}{}
+	if current != "" {
+		plan.Operation = OperationUpdate
+		if before, err = flattenDefinition(current); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, field := range sortedKeys(after) {
+		beforeValue, exists := before[field]
+		if exists && reflect.DeepEqual(beforeValue, after[field]) {
+			continue
+		}
+		plan.Changes = append(plan.Changes, FieldChange{Field: field, Before: beforeValue, After: after[field]})
+	}
+	for _, field := range sortedKeys(before) {
+		if afterValue, set := after[field]; !set || reflect.DeepEqual(before[field], afterValue) {
+			plan.Preserved = append(plan.Preserved, field)
+		}
+	}
+
+	return plan, nil
+}
+
+// flattenDefinition decodes a definition into its fields, keyed by path.
+func flattenDefinition(definition string) (map[string]interface{}, error) {
+	var value map[string]interface{}
+	if err := json.Unmarshal([]byte(definition), &value); err != nil {
+		return nil, err
+	}
+
+	fields := map[string]interface{}{}
+	flatten(fields, "", value)
+	return fields, nil
+}
+
+func flatten(fields map[string]interface{}, prefix string, value map[string]interface{}) {
+	for key, v := range value {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		if object, ok := v.(map[string]interface{}); ok {
+			flatten(fields, path, object)
+			continue
+		}
+		fields[path] = v
+	}
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,165 @@
+package connection
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	hookdecksdk "github.com/hookdeck/hookdeck-go-sdk"
+	hookdeckclient "github.com/hookdeck/hookdeck-go-sdk/client"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/destination"
+	"github.com/hookdeck/hookdeck-cli/pkg/redact"
+)
+
+// Promotion copies the definition of a connection, with its source and
+// destination, to another project. Secrets aren't copied: the secret of the
+// destination auth method must be set with SetSecret, or the auth method of
+// the destination in the target project is kept with KeepSecret.
+type Promotion struct {
+	Request *hookdecksdk.ConnectionUpsertRequest
+	// SecretField is the name of the secret of the destination auth method,
+	// e.g. "token", empty when it has none
+	SecretField string
+	// Warnings lists the parts of the definition that aren't copied
+	Warnings []string
+}
+
+// NewPromotion returns the promotion of a connection.
+func NewPromotion(conn *hookdecksdk.Connection) (*Promotion, error) {
+	p := &Promotion{
+		Request: &hookdecksdk.ConnectionUpsertRequest{},
+	}
+
+	if conn.Name != nil {
+		p.Request.Name = hookdecksdk.Optional(*conn.Name)
+	}
+	if conn.Description != nil {
+		p.Request.Description = hookdecksdk.Optional(*conn.Description)
+	}
+	if conn.Rules != nil {
+		p.Request.Rules = hookdecksdk.Optional(conn.Rules)
+	}
+
+	if src := conn.Source; src != nil {
+		p.Request.Source = hookdecksdk.Optional(hookdecksdk.ConnectionUpsertRequestSource{
+			Name:               src.Name,
+			Description:        src.Description,
+			AllowedHttpMethods: src.AllowedHttpMethods,
+			CustomResponse:     src.CustomResponse,
+		})
+		if src.Verification != nil {
+			p.Warnings = append(p.Warnings, fmt.Sprintf("the verification of source %q isn't copied, set it up in the target project", src.Name))
+		}
+	}
+
+	if dest := conn.Destination; dest != nil {
+		destReq := hookdecksdk.ConnectionUpsertRequestDestination{
+			Name:                   dest.Name,
+			Description:            dest.Description,
+			Url:                    dest.Url,
+			CliPath:                dest.CliPath,
+			RateLimit:              dest.RateLimit,
+			HttpMethod:             dest.HttpMethod,
+			PathForwardingDisabled: dest.PathForwardingDisabled,
+		}
+		if dest.RateLimitPeriod != nil {
+			period := hookdecksdk.ConnectionUpsertRequestDestinationRateLimitPeriod(*dest.RateLimitPeriod)
+			destReq.RateLimitPeriod = &period
+		}
+
+		if dest.AuthMethod != nil {
+			auth, err := copyAuthMethod(dest.AuthMethod)
+			if err != nil {
+				return nil, err
+			}
+			switch field, err := destination.SecretField(auth); {
+			case err == nil:
+				if _, err := destination.RotateSecret(auth, ""); err != nil {
+					return nil, err
+				}
+				p.SecretField = field
+				destReq.AuthMethod = auth
+			case auth.HookdeckSignature != nil:
+				destReq.AuthMethod = auth
+			default:
+				p.Warnings = append(p.Warnings, fmt.Sprintf("the %s auth method of destination %q isn't copied, set it up in the target project", auth.Type, dest.Name))
+			}
+		}
+
+		p.Request.Destination = hookdecksdk.Optional(destReq)
+	}
+
+	return p, nil
+}
+
+// SetSecret sets the secret of the destination auth method.
+func (p *Promotion) SetSecret(secret string) error {
+	if p.SecretField == "" {
+		return fmt.Errorf("the destination auth method has no secret")
+	}
+	_, err := destination.RotateSecret(p.Request.Destination.Value.AuthMethod, secret)
+	return err
+}
+
+// KeepSecret leaves the auth method of the destination in the target project
+// unchanged.
+func (p *Promotion) KeepSecret() {
+	if p.Request.Destination != nil {
+		p.Request.Destination.Value.AuthMethod = nil
+	}
+	p.SecretField = ""
+}
+
+// DestinationURL returns the URL of the destination, empty for CLI
+// destinations.
+func (p *Promotion) DestinationURL() string {
+	if p.Request.Destination == nil || p.Request.Destination.Value.Url == nil {
+		return ""
+	}
+	return *p.Request.Destination.Value.Url
+}
+
+// Definition returns the definition of the connection as indented JSON, with
+// the secrets redacted.
+func (p *Promotion) Definition() (string, error) {
+	data, err := json.MarshalIndent(p.Request, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(redact.JSON(data)), nil
+}
+
+// Apply creates or updates the connection in the project of the client.
+func (p *Promotion) Apply(client *hookdeckclient.Client) (*hookdecksdk.Connection, error) {
+	return client.Connection.Upsert(context.Background(), p.Request)
+}
+
+// Find returns the connection with the given full name, or nil if there's
+// none.
+func Find(client *hookdeckclient.Client, fullName string) (*hookdecksdk.Connection, error) {
+	connections, err := client.Connection.List(context.Background(), &hookdecksdk.ConnectionListRequest{
+		FullName: &fullName,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(connections.Models) == 0 {
+		return nil, nil
+	}
+	return connections.Models[0], nil
+}
+
+// copyAuthMethod returns a deep copy of an auth method, so that its secret
+// can be changed.
+func copyAuthMethod(auth *hookdecksdk.DestinationAuthMethodConfig) (*hookdecksdk.DestinationAuthMethodConfig, error) {
+	data, err := json.Marshal(auth)
+	if err != nil {
+		return nil, err
+	}
+	copied := &hookdecksdk.DestinationAuthMethodConfig{}
+	if err := json.Unmarshal(data, copied); err != nil {
+		return nil, err
+	}
+	return copied, nil
+}
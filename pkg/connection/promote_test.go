@@ -0,0 +1,86 @@
+package connection
+
+import (
+	"testing"
+
+	hookdecksdk "github.com/hookdeck/hookdeck-go-sdk"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPromotion(t *testing.T) {
+	period := hookdecksdk.DestinationRateLimitPeriodSecond
+	conn := &hookdecksdk.Connection{
+		Name:   hookdecksdk.String("stripe-api"),
+		Source: &hookdecksdk.Source{Name: "stripe"},
+		Destination: &hookdecksdk.Destination{
+			Name:            "api",
+			Url:             hookdecksdk.String("https://staging.example.com/webhooks"),
+			RateLimit:       hookdecksdk.Int(10),
+			RateLimitPeriod: &period,
+			AuthMethod: hookdecksdk.NewDestinationAuthMethodConfigFromBearerToken(&hookdecksdk.AuthBearerToken{
+				Config: &hookdecksdk.DestinationAuthMethodBearerTokenConfig{Token: "staging-token"},
+			}),
+		},
+		Rules: []*hookdecksdk.Rule{{Type: "delay", Delay: &hookdecksdk.DelayRule{Delay: 1000}}},
+	}
+
+	p, err := NewPromotion(conn)
+	require.NoError(t, err)
+	require.Equal(t, "token", p.SecretField)
+	require.Empty(t, p.Warnings)
+
+	dest := p.Request.Destination.Value
+	require.Equal(t, "api", dest.Name)
+	require.Equal(t, "", dest.AuthMethod.BearerToken.Config.Token)
+	require.Equal(t, "staging-token", conn.Destination.AuthMethod.BearerToken.Config.Token)
+
+	require.NoError(t, p.SetSecret("prod-token"))
+	require.Equal(t, "prod-token", dest.AuthMethod.BearerToken.Config.Token)
+
+	definition, err := p.Definition()
+	require.NoError(t, err)
+	require.Contains(t, definition, `"rate_limit_period": "second"`)
+	require.NotContains(t, definition, "prod-token")
+
+	p.KeepSecret()
+	require.Nil(t, p.Request.Destination.Value.AuthMethod)
+	require.Error(t, p.SetSecret("prod-token"))
+}
+
+func TestDiff(t *testing.T) {
+	require.Equal(t, []string{"  a", "- b", "+ c", "  d"}, Diff("a\nb\nd\n", "a\nc\nd\n"))
+	require.Equal(t, []string{"+ a"}, Diff("", "a"))
+}
+
+func TestPlan(t *testing.T) {
+	existing, err := NewPromotion(&hookdecksdk.Connection{
+		Name:        hookdecksdk.String("stripe-api"),
+		Description: hookdecksdk.String("Stripe to the API"),
+		Destination: &hookdecksdk.Destination{Name: "api", Url: hookdecksdk.String("https://example.com/webhooks")},
+	})
+	require.NoError(t, err)
+	current, err := existing.Definition()
+	require.NoError(t, err)
+
+	p, err := NewPromotion(&hookdecksdk.Connection{
+		Name:        hookdecksdk.String("stripe-api"),
+		Destination: &hookdecksdk.Destination{Name: "api", Url: hookdecksdk.String("https://staging.example.com/webhooks")},
+		Rules:       []*hookdecksdk.Rule{{Type: "delay", Delay: &hookdecksdk.DelayRule{Delay: 1000}}},
+	})
+	require.NoError(t, err)
+
+	plan, err := p.Plan(current)
+	require.NoError(t, err)
+	require.Equal(t, OperationUpdate, plan.Operation)
+	require.Equal(t, []FieldChange{
+		{Field: "destination.url", Before: "https://example.com/webhooks", After: "https://staging.example.com/webhooks"},
+		{Field: "rules", After: []interface{}{map[string]interface{}{"type": "delay", "delay": float64(1000)}}},
+	}, plan.Changes)
+	require.Equal(t, []string{"description", "destination.name", "name"}, plan.Preserved)
+
+	plan, err = p.Plan("")
+	require.NoError(t, err)
+	require.Equal(t, OperationCreate, plan.Operation)
+	require.Len(t, plan.Changes, 4)
+	require.Empty(t, plan.Preserved)
+}
@@ -0,0 +1,77 @@
+package connection
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	hookdecksdk "github.com/hookdeck/hookdeck-go-sdk"
+	hookdeckclient "github.com/hookdeck/hookdeck-go-sdk/client"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/validators"
+)
+
+// Rename is the renaming of a connection.
+type Rename struct {
+	Connection *hookdecksdk.Connection
+	Name       string
+	// FullName is the full name of the connection once renamed
+	FullName string
+}
+
+// PlanRename checks that the connection can be renamed, since connection
+// names are unique per source, and returns the renaming.
+func PlanRename(client *hookdeckclient.Client, connection *hookdecksdk.Connection, name string) (*Rename, error) {
+	if err := validators.ResourceName(name); err != nil {
+		return nil, err
+	}
+	if connection.Name != nil && *connection.Name == name {
+		return nil, fmt.Errorf("the connection is already named %q", name)
+	}
+
+	rename := &Rename{Connection: connection, Name: name, FullName: renamedFullName(connection, name)}
+	query := &hookdecksdk.ConnectionListRequest{Name: &name}
+	if connection.Source != nil {
+		query.SourceId = []*string{&connection.Source.Id}
+	}
+
+	existing, err := client.Connection.List(context.Background(), query)
+	if err != nil {
+		return nil, err
+	}
+	for _, other := range existing.Models {
+		if other.Id != connection.Id {
+			return nil, fmt.Errorf("a connection named %q already exists: %s", name, other.Id)
+		}
+	}
+
+	return rename, nil
+}
+
+// Apply renames the connection, leaving its other settings unchanged.
+func (r *Rename) Apply(client *hookdeckclient.Client) (*hookdecksdk.Connection, error) {
+	return client.Connection.Update(context.Background(), r.Connection.Id, &hookdecksdk.ConnectionUpdateRequest{
+		Name: hookdecksdk.Optional(r.Name),
+	})
+}
+
+// renamedFullName returns the full name of a connection once renamed.
+// Hookdeck concatenates it from the names of the source, the connection and
+// the destination, so the segments after the source matching the old name
+// are replaced, and it's unchanged when it doesn't include the name.
+func renamedFullName(connection *hookdecksdk.Connection, name string) string {
+	if connection.FullName == nil {
+		return ""
+	}
+	if connection.Name == nil {
+		return *connection.FullName
+	}
+
+	segments := strings.Split(*connection.FullName, " -> ")
+	for i := 1; i < len(segments); i++ {
+		if segments[i] == *connection.Name {
+			segments[i] = name
+		}
+	}
+	return strings.Join(segments, " -> ")
+}
@@ -0,0 +1,21 @@
+package connection
+
+import (
+	"testing"
+
+	hookdecksdk "github.com/hookdeck/hookdeck-go-sdk"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenamedFullName(t *testing.T) {
+	connection := &hookdecksdk.Connection{
+		Name:     hookdecksdk.String("orders"),
+		FullName: hookdecksdk.String("orders -> orders"),
+	}
+	// The source segment is kept, even when it has the same name
+	require.Equal(t, "orders -> orders_v2", renamedFullName(connection, "orders_v2"))
+
+	// Full names without the connection name are unchanged
+	connection.FullName = hookdecksdk.String("shopify -> api")
+	require.Equal(t, "shopify -> api", renamedFullName(connection, "orders_v2"))
+}
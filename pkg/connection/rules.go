@@ -0,0 +1,116 @@
+package connection
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	hookdecksdk "github.com/hookdeck/hookdeck-go-sdk"
+	hookdeckclient "github.com/hookdeck/hookdeck-go-sdk/client"
+)
+
+// RuleTypes lists the types of rules that can be set on a connection.
+var RuleTypes = []string{"retry", "filter", "transform", "delay"}
+
+// ParseRule parses a rule from its JSON representation, e.g.
+// {"type":"retry","strategy":"linear","count":5,"interval":60000}.
+func ParseRule(data string) (*hookdecksdk.Rule, error) {
+	rule := &hookdecksdk.Rule{}
+	if err := json.Unmarshal([]byte(data), rule); err != nil {
+		return nil, fmt.Errorf("invalid rule: %s", err)
+	}
+
+	if rule.Retry == nil && rule.Filter == nil && rule.Transform == nil && rule.Delay == nil {
+		return nil, fmt.Errorf("invalid rule type %q, expected one of %v", rule.Type, RuleTypes)
+	}
+
+	return rule, nil
+}
+
+// InsertRule inserts a rule at the given index. A negative index appends
+// the rule at the end.
+func InsertRule(rules []*hookdecksdk.Rule, rule *hookdecksdk.Rule, index int) ([]*hookdecksdk.Rule, error) {
+	if index < 0 {
+		return append(rules, rule), nil
+	}
+	if index > len(rules) {
+		return nil, fmt.Errorf("invalid index %d, the connection has %d rules", index, len(rules))
+	}
+
+	result := make([]*hookdecksdk.Rule, 0, len(rules)+1)
+	result = append(result, rules[:index]...)
+	result = append(result, rule)
+	result = append(result, rules[index:]...)
+
+	return result, nil
+}
+
+// ReplaceRule replaces the rule at the given index.
+func ReplaceRule(rules []*hookdecksdk.Rule, rule *hookdecksdk.Rule, index int) ([]*hookdecksdk.Rule, error) {
+	if err := checkIndex(rules, index); err != nil {
+		return nil, err
+	}
+
+	result := append([]*hookdecksdk.Rule{}, rules...)
+	result[index] = rule
+
+	return result, nil
+}
+
+// RemoveRule removes the rule at the given index.
+func RemoveRule(rules []*hookdecksdk.Rule, index int) ([]*hookdecksdk.Rule, error) {
+	if err := checkIndex(rules, index); err != nil {
+		return nil, err
+	}
+
+	result := make([]*hookdecksdk.Rule, 0, len(rules)-1)
+	result = append(result, rules[:index]...)
+	result = append(result, rules[index+1:]...)
+
+	return result, nil
+}
+
+// RemoveRulesByType removes all the rules of the given type.
+func RemoveRulesByType(rules []*hookdecksdk.Rule, ruleType string) ([]*hookdecksdk.Rule, error) {
+	result := make([]*hookdecksdk.Rule, 0, len(rules))
+	for _, rule := range rules {
+		if rule.Type != ruleType {
+			result = append(result, rule)
+		}
+	}
+
+	if len(result) == len(rules) {
+		return nil, fmt.Errorf("the connection has no %s rule", ruleType)
+	}
+
+	return result, nil
+}
+
+// MoveRule moves the rule at index from to index to.
+func MoveRule(rules []*hookdecksdk.Rule, from int, to int) ([]*hookdecksdk.Rule, error) {
+	if err := checkIndex(rules, from); err != nil {
+		return nil, err
+	}
+	if err := checkIndex(rules, to); err != nil {
+		return nil, err
+	}
+
+	rule := rules[from]
+	result, _ := RemoveRule(rules, from)
+
+	return InsertRule(result, rule, to)
+}
+
+// UpdateRules replaces the rules of the connection.
+func UpdateRules(client *hookdeckclient.Client, connection *hookdecksdk.Connection, rules []*hookdecksdk.Rule) (*hookdecksdk.Connection, error) {
+	return client.Connection.Update(context.Background(), connection.Id, &hookdecksdk.ConnectionUpdateRequest{
+		Rules: hookdecksdk.Optional(rules),
+	})
+}
+
+func checkIndex(rules []*hookdecksdk.Rule, index int) error {
+	if index < 0 || index >= len(rules) {
+		return fmt.Errorf("invalid index %d, the connection has %d rules", index, len(rules))
+	}
+	return nil
+}
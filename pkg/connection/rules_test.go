@@ -0,0 +1,63 @@
+package connection
+
+import (
+	"testing"
+
+	hookdecksdk "github.com/hookdeck/hookdeck-go-sdk"
+	"github.com/stretchr/testify/require"
+)
+
+func ruleTypes(rules []*hookdecksdk.Rule) []string {
+	types := []string{}
+	for _, rule := range rules {
+		types = append(types, rule.Type)
+	}
+	return types
+}
+
+func TestParseRule(t *testing.T) {
+	rule, err := ParseRule(`{"type":"delay","delay":1000}`)
+	require.NoError(t, err)
+	require.Equal(t, 1000, rule.Delay.Delay)
+
+	_, err = ParseRule(`{"type":"unknown"}`)
+	require.Error(t, err)
+
+	_, err = ParseRule(`not json`)
+	require.Error(t, err)
+}
+
+func TestRuleEditing(t *testing.T) {
+	retry, _ := ParseRule(`{"type":"retry","strategy":"linear"}`)
+	filter, _ := ParseRule(`{"type":"filter","body":{}}`)
+	delay, _ := ParseRule(`{"type":"delay","delay":1000}`)
+
+	rules, err := InsertRule(nil, retry, -1)
+	require.NoError(t, err)
+	rules, err = InsertRule(rules, filter, 0)
+	require.NoError(t, err)
+	require.Equal(t, []string{"filter", "retry"}, ruleTypes(rules))
+
+	_, err = InsertRule(rules, delay, 3)
+	require.Error(t, err)
+
+	rules, err = MoveRule(rules, 1, 0)
+	require.NoError(t, err)
+	require.Equal(t, []string{"retry", "filter"}, ruleTypes(rules))
+
+	replaced, err := ReplaceRule(rules, delay, 1)
+	require.NoError(t, err)
+	require.Equal(t, []string{"retry", "delay"}, ruleTypes(replaced))
+	require.Equal(t, []string{"retry", "filter"}, ruleTypes(rules))
+
+	removed, err := RemoveRule(rules, 0)
+	require.NoError(t, err)
+	require.Equal(t, []string{"filter"}, ruleTypes(removed))
+
+	removed, err = RemoveRulesByType(rules, "filter")
+	require.NoError(t, err)
+	require.Equal(t, []string{"retry"}, ruleTypes(removed))
+
+	_, err = RemoveRulesByType(rules, "transform")
+	require.Error(t, err)
+}
@@ -0,0 +1,29 @@
+package destination
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	hookdecksdk "github.com/hookdeck/hookdeck-go-sdk"
+	hookdeckclient "github.com/hookdeck/hookdeck-go-sdk/client"
+)
+
+// Get finds a destination by ID or by name.
+func Get(client *hookdeckclient.Client, nameOrID string) (*hookdecksdk.Destination, error) {
+	if strings.HasPrefix(nameOrID, "des_") {
+		return client.Destination.Retrieve(context.Background(), nameOrID)
+	}
+
+	destinations, err := client.Destination.List(context.Background(), &hookdecksdk.DestinationListRequest{
+		Name: &nameOrID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(destinations.Models) == 0 {
+		return nil, fmt.Errorf("destination %q not found", nameOrID)
+	}
+
+	return destinations.Models[0], nil
+}
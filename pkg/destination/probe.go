@@ -0,0 +1,50 @@
+package destination
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// CheckURL probes a destination URL with a HEAD request, falling back to an
+// OPTIONS request when the server doesn't allow HEAD. It returns an error
+// when the URL can't be reached within the timeout or responds with a 5xx.
+// Other responses, such as a 404 or 401, mean an endpoint is listening.
+func CheckURL(url string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	client := &http.Client{
+		// Redirects are followed by Hookdeck, but the first response is
+		// enough to know the endpoint is up
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	status, err := probe(ctx, client, http.MethodHead, url)
+	if err == nil && (status == http.StatusMethodNotAllowed || status == http.StatusNotImplemented) {
+		status, err = probe(ctx, client, http.MethodOptions, url)
+	}
+	if err != nil {
+		return fmt.Errorf("%s is unreachable: %w", url, err)
+	}
+	if status >= 500 {
+		return fmt.Errorf("%s responded with %d %s", url, status, http.StatusText(status))
+	}
+	return nil
+}
+
+func probe(ctx context.Context, client *http.Client, method string, url string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	res.Body.Close()
+	return res.StatusCode, nil
+}
@@ -0,0 +1,37 @@
+package destination
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckURL(t *testing.T) {
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		switch {
+		case r.URL.Path == "/down":
+			w.WriteHeader(http.StatusBadGateway)
+		case r.Method == http.MethodHead:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	require.NoError(t, CheckURL(server.URL+"/webhooks", time.Second))
+	require.Equal(t, []string{http.MethodHead, http.MethodOptions}, methods)
+
+	require.EqualError(t, CheckURL(server.URL+"/down", time.Second), server.URL+"/down responded with 502 Bad Gateway")
+
+	unreachable := server.URL
+	server.Close()
+	err := CheckURL(unreachable, time.Second)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "is unreachable")
+}
@@ -0,0 +1,83 @@
+package destination
+
+import (
+	"fmt"
+	"strings"
+
+	hookdecksdk "github.com/hookdeck/hookdeck-go-sdk"
+)
+
+// SecretField returns the name of the secret of an auth method, or an error
+// for auth methods without a secret the CLI can rotate.
+func SecretField(auth *hookdecksdk.DestinationAuthMethodConfig) (string, error) {
+	if auth == nil {
+		return "", fmt.Errorf("the destination has no auth method")
+	}
+
+	switch {
+	case auth.BearerToken != nil:
+		return "token", nil
+	case auth.BasicAuth != nil:
+		return "password", nil
+	case auth.ApiKey != nil:
+		return "api_key", nil
+	case auth.CustomSignature != nil:
+		return "signing_secret", nil
+	default:
+		return "", fmt.Errorf("rotating the secret of %s auth methods isn't supported, expected one of BEARER_TOKEN, BASIC_AUTH, API_KEY, CUSTOM_SIGNATURE", auth.Type)
+	}
+}
+
+// RotateSecret replaces the secret of an auth method, and returns the
+// previous one.
+func RotateSecret(auth *hookdecksdk.DestinationAuthMethodConfig, secret string) (string, error) {
+	if _, err := SecretField(auth); err != nil {
+		return "", err
+	}
+
+	var previous string
+	switch {
+	case auth.BearerToken != nil:
+		config := auth.BearerToken.Config
+		if config == nil {
+			config = &hookdecksdk.DestinationAuthMethodBearerTokenConfig{}
+			auth.BearerToken.Config = config
+		}
+		previous, config.Token = config.Token, secret
+	case auth.BasicAuth != nil:
+		config := auth.BasicAuth.Config
+		if config == nil {
+			return "", fmt.Errorf("the destination has no basic auth username")
+		}
+		previous, config.Password = config.Password, secret
+	case auth.ApiKey != nil:
+		config := auth.ApiKey.Config
+		if config == nil {
+			return "", fmt.Errorf("the destination has no API key name")
+		}
+		previous, config.ApiKey = config.ApiKey, secret
+	case auth.CustomSignature != nil:
+		config := auth.CustomSignature.Config
+		if config == nil {
+			return "", fmt.Errorf("the destination has no custom signature key")
+		}
+		if config.SigningSecret != nil {
+			previous = *config.SigningSecret
+		}
+		config.SigningSecret = &secret
+	}
+
+	return previous, nil
+}
+
+// Redact hides a secret, only keeping its last characters so that it can
+// be told apart from another one.
+func Redact(secret string) string {
+	if secret == "" {
+		return "(none)"
+	}
+	if len(secret) <= 8 {
+		return strings.Repeat("*", len(secret))
+	}
+	return strings.Repeat("*", 8) + secret[len(secret)-4:]
+}
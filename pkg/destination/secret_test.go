@@ -0,0 +1,33 @@
+package destination
+
+import (
+	"testing"
+
+	hookdecksdk "github.com/hookdeck/hookdeck-go-sdk"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotateSecret(t *testing.T) {
+	auth := hookdecksdk.NewDestinationAuthMethodConfigFromBasicAuth(&hookdecksdk.AuthBasicAuth{
+		Config: &hookdecksdk.DestinationAuthMethodBasicAuthConfig{Username: "user", Password: "old-password"},
+	})
+
+	field, err := SecretField(auth)
+	require.NoError(t, err)
+	require.Equal(t, "password", field)
+
+	previous, err := RotateSecret(auth, "new-password")
+	require.NoError(t, err)
+	require.Equal(t, "old-password", previous)
+	require.Equal(t, "user", auth.BasicAuth.Config.Username)
+	require.Equal(t, "new-password", auth.BasicAuth.Config.Password)
+
+	_, err = RotateSecret(hookdecksdk.NewDestinationAuthMethodConfigFromHookdeckSignature(&hookdecksdk.AuthHookdeckSignature{}), "secret")
+	require.Error(t, err)
+}
+
+func TestRedact(t *testing.T) {
+	require.Equal(t, "(none)", Redact(""))
+	require.Equal(t, "*****", Redact("short"))
+	require.Equal(t, "********7890", Redact("sk_live_1234567890"))
+}
@@ -0,0 +1,174 @@
+package docs
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// Commands returns the command and all its available subcommands, depth
+// first. Hidden commands and help topics are left out.
+func Commands(cmd *cobra.Command) []*cobra.Command {
+	commands := []*cobra.Command{cmd}
+	for _, sub := range cmd.Commands() {
+		if !sub.IsAvailableCommand() || sub.IsAdditionalHelpTopicCommand() {
+			continue
+		}
+		commands = append(commands, Commands(sub)...)
+	}
+	return commands
+}
+
+// WriteReference writes the reference of the command and its subcommands as
+// plain text: their description, usage, flags and examples.
+func WriteReference(w io.Writer, cmd *cobra.Command) error {
+	for i, c := range Commands(cmd) {
+		if i > 0 {
+			fmt.Fprintf(w, "\n%s\n\n", strings.Repeat("-", 80))
+		}
+		fmt.Fprintf(w, "%s\n\n", strings.ToUpper(c.CommandPath()))
+		fmt.Fprintf(w, "%s\n\n", description(c))
+		fmt.Fprintf(w, "Usage:\n  %s\n", c.UseLine())
+		if flags := c.NonInheritedFlags(); flags.HasAvailableFlags() {
+			fmt.Fprintf(w, "\nFlags:\n%s", flags.FlagUsages())
+		}
+		if c.Example != "" {
+			fmt.Fprintf(w, "\nExamples:\n%s\n", c.Example)
+		}
+	}
+	return nil
+}
+
+// GenManTree writes a man page in section 1 for the command and each of its
+// subcommands to dir, e.g. hookdeck-project-list.1. date is the date of the
+// pages, pass a fixed one for reproducible builds.
+func GenManTree(cmd *cobra.Command, dir string, date time.Time) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	for _, c := range Commands(cmd) {
+		f, err := os.Create(filepath.Join(dir, manName(c)+".1"))
+		if err != nil {
+			return err
+		}
+		err = WriteMan(f, c, date)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteMan writes the man page of a command in roff.
+func WriteMan(w io.Writer, cmd *cobra.Command, date time.Time) error {
+	name := manName(cmd)
+
+	fmt.Fprintf(w, ".TH %q \"1\" %q \"Hookdeck CLI\" \"Hookdeck Manual\"\n", strings.ToUpper(name), date.Format("Jan 2006"))
+	fmt.Fprintf(w, ".SH NAME\n%s \\- %s\n", roffEscape(name), roffText(cmd.Short))
+	fmt.Fprintf(w, ".SH SYNOPSIS\n.B %s\n", roffText(cmd.UseLine()))
+	fmt.Fprintf(w, ".SH DESCRIPTION\n%s\n", roffText(description(cmd)))
+
+	writeManFlags(w, "OPTIONS", cmd.NonInheritedFlags())
+	writeManFlags(w, "OPTIONS INHERITED FROM PARENT COMMANDS", cmd.InheritedFlags())
+
+	if cmd.Example != "" {
+		fmt.Fprintf(w, ".SH EXAMPLES\n.nf\n%s\n.fi\n", roffLines(cmd.Example))
+	}
+
+	var related []string
+	if cmd.HasParent() {
+		related = append(related, manName(cmd.Parent()))
+	}
+	for _, sub := range cmd.Commands() {
+		if sub.IsAvailableCommand() && !sub.IsAdditionalHelpTopicCommand() {
+			related = append(related, manName(sub))
+		}
+	}
+	if len(related) > 0 {
+		fmt.Fprintf(w, ".SH SEE ALSO\n")
+		for i, r := range related {
+			separator := ","
+			if i == len(related)-1 {
+				separator = ""
+			}
+			fmt.Fprintf(w, ".BR %s (1)%s\n", roffEscape(r), separator)
+		}
+	}
+
+	return nil
+}
+
+func writeManFlags(w io.Writer, title string, flags *pflag.FlagSet) {
+	if !flags.HasAvailableFlags() {
+		return
+	}
+
+	fmt.Fprintf(w, ".SH %s\n", title)
+	flags.VisitAll(func(flag *pflag.Flag) {
+		if flag.Hidden {
+			return
+		}
+		name := "--" + flag.Name
+		if flag.Shorthand != "" {
+			name = "-" + flag.Shorthand + ", " + name
+		}
+		if varname, _ := pflag.UnquoteUsage(flag); varname != "" {
+			name += " " + varname
+		}
+		usage := flag.Usage
+		if flag.DefValue != "" && flag.DefValue != "false" && flag.DefValue != "[]" {
+			usage += fmt.Sprintf(" (default %s)", flag.DefValue)
+		}
+		fmt.Fprintf(w, ".TP\n.B %s\n%s\n", roffText(name), roffText(usage))
+	})
+}
+
+// manName is the name of the man page of a command, e.g. hookdeck-project-list.
+func manName(cmd *cobra.Command) string {
+	return strings.ReplaceAll(cmd.CommandPath(), " ", "-")
+}
+
+func description(cmd *cobra.Command) string {
+	if cmd.Long != "" {
+		return cmd.Long
+	}
+	return cmd.Short
+}
+
+// roffText escapes a text for roff, blank lines becoming paragraph breaks.
+func roffText(text string) string {
+	lines := strings.Split(roffLines(text), "\n")
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			lines[i] = ".PP"
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// roffLines escapes a text for roff line by line. Lines starting with a
+// control character are protected so they aren't read as requests.
+func roffLines(text string) string {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = roffEscape(line)
+		if strings.HasPrefix(line, ".") || strings.HasPrefix(line, "'") {
+			lines[i] = "\\&" + lines[i]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func roffEscape(text string) string {
+	return strings.NewReplacer(`\`, `\e`, "-", `\-`).Replace(text)
+}
@@ -0,0 +1,53 @@
+package docs
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func testCommands() *cobra.Command {
+	root := &cobra.Command{Use: "hookdeck"}
+	root.PersistentFlags().String("profile", "", "Profile to use")
+	list := &cobra.Command{
+		Use:     "list",
+		Short:   "List projects",
+		Long:    "List your projects.\n\n.Hidden lines are escaped.",
+		Example: "  hookdeck project list --output json",
+		Run:     func(cmd *cobra.Command, args []string) {},
+	}
+	list.Flags().StringP("output", "o", "table", "Output format")
+	project := &cobra.Command{Use: "project", Short: "Manage your projects"}
+	project.AddCommand(list)
+	project.AddCommand(&cobra.Command{Use: "secret", Hidden: true, Run: func(cmd *cobra.Command, args []string) {}})
+	root.AddCommand(project)
+	return project
+}
+
+func TestCommands(t *testing.T) {
+	project := testCommands()
+
+	var paths []string
+	for _, c := range Commands(project) {
+		paths = append(paths, c.CommandPath())
+	}
+	require.Equal(t, []string{"hookdeck project", "hookdeck project list"}, paths)
+}
+
+func TestWriteMan(t *testing.T) {
+	list := testCommands().Commands()[0]
+
+	out := &bytes.Buffer{}
+	require.NoError(t, WriteMan(out, list, time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)))
+
+	page := out.String()
+	require.Contains(t, page, `.TH "HOOKDECK-PROJECT-LIST" "1" "Jan 2024"`)
+	require.Contains(t, page, "hookdeck\\-project\\-list \\- List projects")
+	require.Contains(t, page, "List your projects.\n.PP\n\\&.Hidden lines are escaped.")
+	require.Contains(t, page, ".B \\-o, \\-\\-output string\nOutput format (default table)")
+	require.Contains(t, page, ".SH OPTIONS INHERITED FROM PARENT COMMANDS\n.TP\n.B \\-\\-profile string")
+	require.Contains(t, page, ".SH SEE ALSO\n.BR hookdeck\\-project (1)\n")
+}
@@ -0,0 +1,319 @@
+// Package doctor diagnoses the environment of the CLI: the connectivity to
+// Hookdeck, the API key, the clock, the local server and the proxy settings.
+package doctor
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+
+	ws "github.com/gorilla/websocket"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/ansi"
+	"github.com/hookdeck/hookdeck-cli/pkg/hookdeck"
+	"github.com/hookdeck/hookdeck-cli/pkg/login"
+	"github.com/hookdeck/hookdeck-cli/pkg/version"
+)
+
+// Statuses of a check.
+const (
+	StatusOK      = "ok"
+	StatusWarning = "warning"
+	StatusError   = "error"
+	StatusSkipped = "skipped"
+)
+
+// maxClockSkew is the clock skew above which the clock check warns, as
+// Hookdeck signatures and timestamps are compared with the local clock.
+const maxClockSkew = 30 * time.Second
+
+// proxyEnvVars are the environment variables read for the proxy settings.
+var proxyEnvVars = []string{"HTTPS_PROXY", "HTTP_PROXY", "NO_PROXY"}
+
+// Options are the settings diagnosed.
+type Options struct {
+	APIBaseURL string
+	WSBaseURL  string
+	APIKey     string
+	TeamID     string
+	// Proxy is the proxy set with --proxy, if any
+	Proxy    string
+	Insecure bool
+	// LocalURL is the URL of the local server, nil to skip its check
+	LocalURL *url.URL
+	Timeout  time.Duration
+}
+
+// Check is the result of a diagnostic.
+type Check struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Detail string `json:"detail"`
+}
+
+// Report is the result of the diagnostics, meant to be shared with support:
+// it includes no secret.
+type Report struct {
+	Version  string  `json:"version"`
+	Platform string  `json:"platform"`
+	Checks   []Check `json:"checks"`
+}
+
+// Run runs the diagnostics.
+func Run(opts Options) Report {
+	report := Report{
+		Version:  version.Version,
+		Platform: runtime.GOOS + "/" + runtime.GOARCH,
+	}
+
+	report.Checks = append(report.Checks, checkProxy(opts))
+	api, skew := checkAPI(opts)
+	report.Checks = append(report.Checks, api, skew)
+	report.Checks = append(report.Checks, checkAPIKey(opts))
+	report.Checks = append(report.Checks, checkWebsocket(opts))
+	report.Checks = append(report.Checks, checkLocalServer(opts))
+
+	return report
+}
+
+// Failed returns whether any check failed.
+func (r Report) Failed() bool {
+	for _, check := range r.Checks {
+		if check.Status == StatusError {
+			return true
+		}
+	}
+	return false
+}
+
+// Print writes the report for humans.
+func (r Report) Print(w io.Writer) {
+	color := ansi.Color(w)
+
+	fmt.Fprintf(w, "Hookdeck CLI %s (%s)\n\n", r.Version, r.Platform)
+	for _, check := range r.Checks {
+		var symbol string
+		switch check.Status {
+		case StatusOK:
+			symbol = color.Green("✔").String()
+		case StatusWarning:
+			symbol = color.Yellow("!").String()
+		case StatusError:
+			symbol = color.Red("✗").String()
+		default:
+			symbol = color.Faint("-").String()
+		}
+		fmt.Fprintf(w, "%s %-14s %s\n", symbol, check.Name, check.Detail)
+	}
+}
+
+func checkProxy(opts Options) Check {
+	check := Check{Name: "Proxy", Status: StatusOK}
+
+	var settings []string
+	if opts.Proxy != "" {
+		settings = append(settings, "--proxy="+redactURL(opts.Proxy))
+	}
+	for _, name := range proxyEnvVars {
+		value := os.Getenv(name)
+		if value == "" {
+			value = os.Getenv(strings.ToLower(name))
+		}
+		if value != "" {
+			settings = append(settings, name+"="+redactURL(value))
+		}
+	}
+	if len(settings) == 0 {
+		check.Detail = "none"
+		return check
+	}
+	check.Detail = strings.Join(settings, ", ")
+
+	req, err := http.NewRequest(http.MethodGet, opts.APIBaseURL, nil)
+	if err != nil {
+		return check
+	}
+	proxyURL, err := hookdeck.Proxy(req)
+	if err != nil {
+		check.Status = StatusError
+		check.Detail += fmt.Sprintf(" (invalid: %s)", err)
+		return check
+	}
+	if proxyURL == nil {
+		check.Detail += " (not used for the API)"
+	}
+	return check
+}
+
+// checkAPI checks that the API is reachable, and the skew of the local
+// clock from the Date of its response.
+func checkAPI(opts Options) (Check, Check) {
+	api := Check{Name: "API"}
+	skew := Check{Name: "Clock"}
+
+	client := &http.Client{
+		Timeout: opts.Timeout,
+		Transport: &http.Transport{
+			Proxy:           hookdeck.Proxy,
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: opts.Insecure},
+		},
+	}
+	start := time.Now()
+	res, err := client.Get(opts.APIBaseURL)
+	if err != nil {
+		api.Status = StatusError
+		api.Detail = fmt.Sprintf("%s is unreachable: %s", opts.APIBaseURL, err)
+		skew.Status = StatusSkipped
+		skew.Detail = "the API is unreachable"
+		return api, skew
+	}
+	latency := time.Since(start)
+	res.Body.Close()
+
+	api.Status = StatusOK
+	api.Detail = fmt.Sprintf("%s reachable in %dms", opts.APIBaseURL, latency.Milliseconds())
+	if res.StatusCode >= 500 {
+		api.Status = StatusError
+		api.Detail = fmt.Sprintf("%s responded with %s", opts.APIBaseURL, res.Status)
+	}
+
+	date, err := http.ParseTime(res.Header.Get("Date"))
+	if err != nil {
+		skew.Status = StatusSkipped
+		skew.Detail = "the API response has no date"
+		return api, skew
+	}
+	// The date is truncated to the second, compare it with the middle of
+	// the request
+	offset := start.Add(latency / 2).Sub(date).Round(time.Second)
+	skew.Status, skew.Detail = clockSkew(offset)
+	return api, skew
+}
+
+// clockSkew returns the status and detail of the clock check for the offset
+// of the local clock from the API clock.
+func clockSkew(offset time.Duration) (string, string) {
+	abs := offset
+	if abs < 0 {
+		abs = -abs
+	}
+	if abs <= time.Second {
+		return StatusOK, "in sync with the API"
+	}
+	direction := "ahead of"
+	if offset < 0 {
+		direction = "behind"
+	}
+	detail := fmt.Sprintf("%s %s the API", abs, direction)
+	if abs > maxClockSkew {
+		return StatusWarning, detail + ", synchronize the system clock"
+	}
+	return StatusOK, detail
+}
+
+func checkAPIKey(opts Options) Check {
+	check := Check{Name: "API key"}
+	if opts.APIKey == "" {
+		check.Status = StatusWarning
+		check.Detail = "not logged in, run `hookdeck login`"
+		return check
+	}
+
+	response, err := login.ValidateKey(opts.APIBaseURL, opts.APIKey, opts.TeamID)
+	if err != nil {
+		check.Status = StatusError
+		check.Detail = fmt.Sprintf("%s is invalid: %s", redactKey(opts.APIKey), err)
+		return check
+	}
+	check.Status = StatusOK
+	check.Detail = fmt.Sprintf("%s valid for project %s in organization %s", redactKey(opts.APIKey), response.TeamName, response.OrganizationName)
+	return check
+}
+
+// checkWebsocket checks that the websocket endpoint accepts connections.
+// The handshake isn't authenticated, so any response proves it's
+// reachable.
+func checkWebsocket(opts Options) Check {
+	check := Check{Name: "Websocket"}
+
+	dialer := &ws.Dialer{
+		Proxy:            hookdeck.Proxy,
+		HandshakeTimeout: opts.Timeout,
+		TLSClientConfig:  &tls.Config{InsecureSkipVerify: opts.Insecure},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), opts.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	conn, res, err := dialer.DialContext(ctx, opts.WSBaseURL, nil)
+	if err != nil && !(errors.Is(err, ws.ErrBadHandshake) && res != nil) {
+		check.Status = StatusError
+		check.Detail = fmt.Sprintf("%s is unreachable: %s", opts.WSBaseURL, err)
+		return check
+	}
+	if conn != nil {
+		conn.Close()
+	}
+	if res != nil && res.Body != nil {
+		res.Body.Close()
+	}
+
+	check.Status = StatusOK
+	check.Detail = fmt.Sprintf("%s reachable in %dms", opts.WSBaseURL, time.Since(start).Milliseconds())
+	return check
+}
+
+func checkLocalServer(opts Options) Check {
+	check := Check{Name: "Local server"}
+	if opts.LocalURL == nil {
+		check.Status = StatusSkipped
+		check.Detail = "no port given, use --port to check it"
+		return check
+	}
+
+	host := opts.LocalURL.Host
+	if opts.LocalURL.Port() == "" {
+		port := "80"
+		if opts.LocalURL.Scheme == "https" {
+			port = "443"
+		}
+		host = net.JoinHostPort(opts.LocalURL.Hostname(), port)
+	}
+	conn, err := net.DialTimeout("tcp", host, opts.Timeout)
+	if err != nil {
+		check.Status = StatusError
+		check.Detail = fmt.Sprintf("nothing is listening on %s: %s", host, err)
+		return check
+	}
+	conn.Close()
+
+	check.Status = StatusOK
+	check.Detail = fmt.Sprintf("%s is listening", host)
+	return check
+}
+
+// redactURL removes the password of a URL, e.g. of a proxy.
+func redactURL(value string) string {
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return value
+	}
+	return parsed.Redacted()
+}
+
+// redactKey keeps the last characters of an API key to identify it.
+func redactKey(key string) string {
+	if len(key) <= 8 {
+		return "***"
+	}
+	return "***" + key[len(key)-4:]
+}
@@ -0,0 +1,139 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	hookdeckclient "github.com/hookdeck/hookdeck-go-sdk/client"
+)
+
+// Kinds of changes between two events.
+const (
+	ChangeAdded   = "added"
+	ChangeRemoved = "removed"
+	ChangeChanged = "changed"
+)
+
+// Change is a difference between the requests of two events. Path locates
+// the changed value, e.g. "headers.content-type" or "body.data.items[0].id".
+type Change struct {
+	Kind   string      `json:"kind"`
+	Path   string      `json:"path"`
+	Before interface{} `json:"before,omitempty"`
+	After  interface{} `json:"after,omitempty"`
+}
+
+// Get retrieves an event with its payload.
+func Get(ctx context.Context, client *hookdeckclient.Client, id string) (*Exported, error) {
+	event, err := client.Event.Retrieve(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	body, err := client.Event.RetrieveBody(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return newExported(event, body.Body), nil
+}
+
+// Diff returns the differences between the requests of two events: their
+// path, query, headers and body. JSON bodies are compared structurally,
+// other bodies are compared as a whole.
+func Diff(before, after *Exported) []Change {
+	var changes []Change
+	changes = diffValues(changes, "path", before.Path, after.Path)
+	changes = diffValues(changes, "query", before.Query, after.Query)
+	changes = diffValues(changes, "headers", stringMap(before.Headers), stringMap(after.Headers))
+	changes = diffValues(changes, "body", bodyValue(before.Body), bodyValue(after.Body))
+	return changes
+}
+
+// diffValues appends the changes between two decoded JSON values.
+func diffValues(changes []Change, path string, before, after interface{}) []Change {
+	beforeMap, beforeIsMap := before.(map[string]interface{})
+	afterMap, afterIsMap := after.(map[string]interface{})
+	if beforeIsMap && afterIsMap {
+		keys := make([]string, 0, len(beforeMap)+len(afterMap))
+		for key := range beforeMap {
+			keys = append(keys, key)
+		}
+		for key := range afterMap {
+			if _, ok := beforeMap[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			keyPath := path + "." + key
+			beforeValue, inBefore := beforeMap[key]
+			afterValue, inAfter := afterMap[key]
+			switch {
+			case !inBefore:
+				changes = append(changes, Change{Kind: ChangeAdded, Path: keyPath, After: afterValue})
+			case !inAfter:
+				changes = append(changes, Change{Kind: ChangeRemoved, Path: keyPath, Before: beforeValue})
+			default:
+				changes = diffValues(changes, keyPath, beforeValue, afterValue)
+			}
+		}
+		return changes
+	}
+
+	beforeArray, beforeIsArray := before.([]interface{})
+	afterArray, afterIsArray := after.([]interface{})
+	if beforeIsArray && afterIsArray {
+		for i := 0; i < len(beforeArray) || i < len(afterArray); i++ {
+			itemPath := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= len(beforeArray):
+				changes = append(changes, Change{Kind: ChangeAdded, Path: itemPath, After: afterArray[i]})
+			case i >= len(afterArray):
+				changes = append(changes, Change{Kind: ChangeRemoved, Path: itemPath, Before: beforeArray[i]})
+			default:
+				changes = diffValues(changes, itemPath, beforeArray[i], afterArray[i])
+			}
+		}
+		return changes
+	}
+
+	if !reflect.DeepEqual(before, after) {
+		changes = append(changes, Change{Kind: ChangeChanged, Path: path, Before: before, After: after})
+	}
+	return changes
+}
+
+// FormatValue formats a value of a change for display, as compact JSON.
+func FormatValue(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(encoded)
+}
+
+// bodyValue decodes a JSON body, or returns it as is when it isn't JSON.
+func bodyValue(body string) interface{} {
+	var value interface{}
+	if err := json.Unmarshal([]byte(body), &value); err != nil {
+		return body
+	}
+	return value
+}
+
+// stringMap converts headers to a map comparable with diffValues. Header
+// names are case-insensitive, so they're lowercased.
+func stringMap(values map[string]string) map[string]interface{} {
+	m := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		m[strings.ToLower(key)] = value
+	}
+	return m
+}
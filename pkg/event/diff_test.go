@@ -0,0 +1,39 @@
+package event
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	before := &Exported{
+		Path:    "/webhooks",
+		Headers: map[string]string{"Content-Type": "application/json", "X-Retry": "1"},
+		Body:    `{"type":"invoice.paid","data":{"amount":100,"items":[{"id":"a"},{"id":"b"}],"coupon":"X"}}`,
+	}
+	after := &Exported{
+		Path:    "/webhooks",
+		Query:   "test=1",
+		Headers: map[string]string{"content-type": "application/json", "X-Signature": "sig"},
+		Body:    `{"type":"invoice.paid","data":{"amount":"100","items":[{"id":"a"}],"currency":"usd"}}`,
+	}
+
+	require.Equal(t, []Change{
+		{Kind: ChangeChanged, Path: "query", Before: "", After: "test=1"},
+		{Kind: ChangeRemoved, Path: "headers.x-retry", Before: "1"},
+		{Kind: ChangeAdded, Path: "headers.x-signature", After: "sig"},
+		{Kind: ChangeChanged, Path: "body.data.amount", Before: float64(100), After: "100"},
+		{Kind: ChangeRemoved, Path: "body.data.coupon", Before: "X"},
+		{Kind: ChangeAdded, Path: "body.data.currency", After: "usd"},
+		{Kind: ChangeRemoved, Path: "body.data.items[1]", Before: map[string]interface{}{"id": "b"}},
+	}, Diff(before, after))
+
+	require.Empty(t, Diff(before, before))
+}
+
+func TestDiff_NonJSONBody(t *testing.T) {
+	changes := Diff(&Exported{Body: "a=1"}, &Exported{Body: "a=2"})
+	require.Equal(t, []Change{{Kind: ChangeChanged, Path: "body", Before: "a=1", After: "a=2"}}, changes)
+	require.Equal(t, `"a=1"`, FormatValue(changes[0].Before))
+}
@@ -0,0 +1,178 @@
+package event
+
+import (
+	"context"
+	"time"
+
+	hookdecksdk "github.com/hookdeck/hookdeck-go-sdk"
+	hookdeckclient "github.com/hookdeck/hookdeck-go-sdk/client"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/hookdeck"
+)
+
+// DefaultExportPageSize is the number of events listed per API request.
+const DefaultExportPageSize = 100
+
+// Exported is an event as exported, with its payload and the metadata of its
+// delivery attempts. The body is kept as received so that the event can be
+// imported and signed again.
+type Exported struct {
+	ID             string            `json:"id"`
+	SourceID       string            `json:"source_id"`
+	ConnectionID   string            `json:"connection_id"`
+	DestinationID  string            `json:"destination_id"`
+	Status         string            `json:"status"`
+	Attempts       int               `json:"attempts"`
+	ResponseStatus *int              `json:"response_status,omitempty"`
+	ErrorCode      string            `json:"error_code,omitempty"`
+	CreatedAt      time.Time         `json:"created_at"`
+	LastAttemptAt  *time.Time        `json:"last_attempt_at,omitempty"`
+	SuccessfulAt   *time.Time        `json:"successful_at,omitempty"`
+	Path           string            `json:"path"`
+	Query          string            `json:"query,omitempty"`
+	Headers        map[string]string `json:"headers,omitempty"`
+	Body           string            `json:"body"`
+}
+
+// ExportOptions select the exported events.
+type ExportOptions struct {
+	SourceID string
+	Status   string
+	// Since excludes the events created before it, when set
+	Since time.Time
+	// Cursor resumes a previous export from the page it points to
+	Cursor string
+	// Concurrency is the number of event payloads retrieved concurrently
+	Concurrency int
+	PageSize    int
+}
+
+// Export lists the events matching the options, most recent first, and
+// calls write for each page of events with the cursor of the next page. The
+// cursor is empty for the last page.
+func Export(ctx context.Context, client *hookdeckclient.Client, opts ExportOptions, write func(events []*Exported, next string) error) error {
+	request := &hookdecksdk.EventListRequest{
+		Include: hookdecksdk.String("data"),
+	}
+	if opts.SourceID != "" {
+		request.SourceId = []*string{&opts.SourceID}
+	}
+	if opts.Status != "" {
+		status := hookdecksdk.EventStatus(opts.Status)
+		request.Status = &status
+	}
+	if opts.Cursor != "" {
+		request.Next = &opts.Cursor
+	}
+
+	return listEvents(ctx, client, request, opts.Since, opts.PageSize, func(events []*hookdecksdk.Event, next string) error {
+		bodies, err := hookdeck.Batch(ctx, events, hookdeck.BatchOptions{Concurrency: opts.Concurrency}, func(ctx context.Context, event *hookdecksdk.Event) (*hookdecksdk.RawBody, error) {
+			return client.Event.RetrieveBody(ctx, event.Id)
+		})
+		if err != nil {
+			return err
+		}
+
+		exported := make([]*Exported, len(events))
+		for i, event := range events {
+			exported[i] = newExported(event, bodies[i].Body)
+		}
+
+		return write(exported, next)
+	})
+}
+
+// listEvents lists the events of the request, most recent first, and calls
+// fn for each page of events with the cursor of the next page. The cursor is
+// empty for the last page. Events created before since, when set, are
+// excluded.
+func listEvents(ctx context.Context, client *hookdeckclient.Client, request *hookdecksdk.EventListRequest, since time.Time, pageSize int, fn func(events []*hookdecksdk.Event, next string) error) error {
+	if pageSize == 0 {
+		pageSize = DefaultExportPageSize
+	}
+	request.OrderBy = hookdecksdk.EventListRequestOrderByCreatedAt.Ptr()
+	request.Dir = hookdecksdk.EventListRequestDirDesc.Ptr()
+	request.Limit = &pageSize
+
+	for {
+		page, err := client.Event.List(ctx, request)
+		if err != nil {
+			return err
+		}
+
+		// Events are listed from the most recent, so the listing is done
+		// at the first event created before since
+		events := page.Models
+		done := page.Pagination == nil || page.Pagination.Next == nil || *page.Pagination.Next == ""
+		for i, event := range events {
+			if !since.IsZero() && event.CreatedAt.Before(since) {
+				events = events[:i]
+				done = true
+				break
+			}
+		}
+
+		next := ""
+		if !done {
+			next = *page.Pagination.Next
+		}
+		if err := fn(events, next); err != nil {
+			return err
+		}
+
+		if done {
+			return nil
+		}
+		request.Next = &next
+	}
+}
+
+func newExported(event *hookdecksdk.Event, body string) *Exported {
+	exported := &Exported{
+		ID:             event.Id,
+		SourceID:       event.SourceId,
+		ConnectionID:   event.WebhookId,
+		DestinationID:  event.DestinationId,
+		Status:         string(event.Status),
+		Attempts:       event.Attempts,
+		ResponseStatus: event.ResponseStatus,
+		CreatedAt:      event.CreatedAt,
+		LastAttemptAt:  event.LastAttemptAt,
+		SuccessfulAt:   event.SuccessfulAt,
+		Body:           body,
+	}
+	if event.ErrorCode != nil {
+		exported.ErrorCode = string(*event.ErrorCode)
+	}
+
+	if data := event.Data; data != nil {
+		exported.Path = data.Path
+		if data.Query != nil {
+			exported.Query = *data.Query
+		}
+		if data.Headers != nil && data.Headers.StringStringOptionalMap != nil {
+			exported.Headers = map[string]string{}
+			for name, value := range data.Headers.StringStringOptionalMap {
+				if value != nil {
+					exported.Headers[name] = *value
+				}
+			}
+		}
+	}
+
+	return exported
+}
+
+// Recent returns the n most recent events, without their data.
+func Recent(ctx context.Context, client *hookdeckclient.Client, n int) ([]*hookdecksdk.Event, error) {
+	request := &hookdecksdk.EventListRequest{
+		OrderBy: hookdecksdk.EventListRequestOrderByCreatedAt.Ptr(),
+		Dir:     hookdecksdk.EventListRequestDirDesc.Ptr(),
+		Limit:   &n,
+	}
+	page, err := client.Event.List(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return page.Models, nil
+}
@@ -0,0 +1,107 @@
+package event
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/hookdeck"
+)
+
+func TestExport(t *testing.T) {
+	now := time.Date(2024, 1, 31, 12, 0, 0, 0, time.UTC)
+	page := func(ids []int, next string) map[string]interface{} {
+		models := []map[string]interface{}{}
+		for _, id := range ids {
+			models = append(models, map[string]interface{}{
+				"id":         fmt.Sprintf("evt_%d", id),
+				"source_id":  "src_1",
+				"webhook_id": "web_1",
+				"status":     "SUCCESSFUL",
+				"attempts":   1,
+				"created_at": now.Add(-time.Duration(id) * time.Hour),
+				"updated_at": now,
+				"data": map[string]interface{}{
+					"path":    "/webhooks",
+					"headers": map[string]string{"content-type": "application/json"},
+				},
+			})
+		}
+		pagination := map[string]interface{}{}
+		if next != "" {
+			pagination["next"] = next
+		}
+		return map[string]interface{}{"models": models, "count": len(models), "pagination": pagination}
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/raw_body") {
+			id := strings.Split(r.URL.Path, "/")[3]
+			json.NewEncoder(w).Encode(map[string]string{"body": `{"id":"` + id + `"}`})
+			return
+		}
+
+		require.Equal(t, "/2024-03-01/events", r.URL.Path)
+		require.Equal(t, "created_at", r.URL.Query().Get("order_by"))
+		require.Equal(t, "desc", r.URL.Query().Get("dir"))
+		if r.URL.Query().Get("next") == "" {
+			json.NewEncoder(w).Encode(page([]int{1, 2}, "cursor_2"))
+		} else {
+			require.Equal(t, "cursor_2", r.URL.Query().Get("next"))
+			json.NewEncoder(w).Encode(page([]int{3, 4}, "cursor_3"))
+		}
+	}))
+	defer ts.Close()
+
+	client := hookdeck.CreateSDKClient(hookdeck.SDKClientInit{APIBaseURL: ts.URL})
+
+	var exported []*Exported
+	var cursors []string
+	err := Export(context.Background(), client, ExportOptions{Since: now.Add(-210 * time.Minute), PageSize: 2}, func(events []*Exported, next string) error {
+		exported = append(exported, events...)
+		cursors = append(cursors, next)
+		return nil
+	})
+	require.NoError(t, err)
+
+	// The export stops at evt_4, created before Since
+	require.Len(t, exported, 3)
+	require.Equal(t, []string{"cursor_2", ""}, cursors)
+	require.Equal(t, "evt_1", exported[0].ID)
+	require.Equal(t, "web_1", exported[0].ConnectionID)
+	require.Equal(t, `{"id":"evt_1"}`, exported[0].Body)
+	require.Equal(t, "/webhooks", exported[0].Path)
+	require.Equal(t, map[string]string{"content-type": "application/json"}, exported[0].Headers)
+
+	var ndjson bytes.Buffer
+	writer, err := NewWriter(FormatNDJSON, &ndjson, true)
+	require.NoError(t, err)
+	require.NoError(t, writer.Write(exported))
+	lines := strings.Split(strings.TrimSpace(ndjson.String()), "\n")
+	require.Len(t, lines, 3)
+	var decoded Exported
+	require.NoError(t, json.Unmarshal([]byte(lines[2]), &decoded))
+	require.Equal(t, *exported[2], decoded)
+
+	var csv bytes.Buffer
+	writer, err = NewWriter(FormatCSV, &csv, true)
+	require.NoError(t, err)
+	require.NoError(t, writer.Write(exported[:1]))
+	require.NoError(t, writer.Write(exported[1:]))
+	lines = strings.Split(strings.TrimSpace(csv.String()), "\n")
+	require.Len(t, lines, 4)
+	require.True(t, strings.HasPrefix(lines[0], "id,source_id,"))
+	require.Contains(t, lines[1], `"{""id"":""evt_1""}"`)
+
+	_, err = NewWriter("xml", &csv, true)
+	require.Error(t, err)
+}
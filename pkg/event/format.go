@@ -0,0 +1,117 @@
+package event
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// Export formats.
+const (
+	FormatNDJSON = "ndjson"
+	FormatCSV    = "csv"
+)
+
+// csvColumns are the columns of the CSV format. Headers are encoded as a
+// JSON object.
+var csvColumns = []string{
+	"id", "source_id", "connection_id", "destination_id", "status", "attempts",
+	"response_status", "error_code", "created_at", "last_attempt_at",
+	"successful_at", "path", "query", "headers", "body",
+}
+
+// Writer writes exported events in a format.
+type Writer interface {
+	Write(events []*Exported) error
+}
+
+// NewWriter returns a writer of the given format. The CSV header row is
+// only written when header is set, so that resumed exports can be appended
+// to a previous file.
+func NewWriter(format string, w io.Writer, header bool) (Writer, error) {
+	switch format {
+	case FormatNDJSON:
+		return &ndjsonWriter{encoder: json.NewEncoder(w)}, nil
+	case FormatCSV:
+		return &csvWriter{writer: csv.NewWriter(w), header: header}, nil
+	default:
+		return nil, fmt.Errorf("invalid format %q, expected one of %s, %s", format, FormatNDJSON, FormatCSV)
+	}
+}
+
+type ndjsonWriter struct {
+	encoder *json.Encoder
+}
+
+func (w *ndjsonWriter) Write(events []*Exported) error {
+	for _, event := range events {
+		if err := w.encoder.Encode(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type csvWriter struct {
+	writer *csv.Writer
+	header bool
+}
+
+func (w *csvWriter) Write(events []*Exported) error {
+	if w.header {
+		if err := w.writer.Write(csvColumns); err != nil {
+			return err
+		}
+		w.header = false
+	}
+
+	for _, event := range events {
+		headers := ""
+		if event.Headers != nil {
+			data, err := json.Marshal(event.Headers)
+			if err != nil {
+				return err
+			}
+			headers = string(data)
+		}
+
+		responseStatus := ""
+		if event.ResponseStatus != nil {
+			responseStatus = strconv.Itoa(*event.ResponseStatus)
+		}
+
+		err := w.writer.Write([]string{
+			event.ID,
+			event.SourceID,
+			event.ConnectionID,
+			event.DestinationID,
+			event.Status,
+			strconv.Itoa(event.Attempts),
+			responseStatus,
+			event.ErrorCode,
+			event.CreatedAt.Format(time.RFC3339),
+			formatTime(event.LastAttemptAt),
+			formatTime(event.SuccessfulAt),
+			event.Path,
+			event.Query,
+			headers,
+			event.Body,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
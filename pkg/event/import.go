@@ -0,0 +1,109 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	hookdecksdk "github.com/hookdeck/hookdeck-go-sdk"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/hookdeck"
+	"github.com/hookdeck/hookdeck-cli/pkg/source"
+)
+
+// skippedImportHeaders are the headers of exported events that aren't sent
+// again on import, since they're set by the HTTP client or by Hookdeck.
+var skippedImportHeaders = map[string]bool{
+	"connection":        true,
+	"content-length":    true,
+	"host":              true,
+	"transfer-encoding": true,
+}
+
+// ImportOptions configures Import.
+type ImportOptions struct {
+	// Rate is the maximum number of events sent per second, unlimited when 0
+	Rate float64
+	// Concurrency is the number of events sent at once
+	Concurrency int
+	// Progress, if set, is called after each event is sent
+	Progress func(done, total int)
+}
+
+// ReadNDJSON reads the events of an NDJSON export.
+func ReadNDJSON(r io.Reader) ([]*Exported, error) {
+	decoder := json.NewDecoder(r)
+	events := []*Exported{}
+	for decoder.More() {
+		var event Exported
+		if err := decoder.Decode(&event); err != nil {
+			return nil, fmt.Errorf("invalid event %d: %w", len(events)+1, err)
+		}
+		events = append(events, &event)
+	}
+	return events, nil
+}
+
+// Import sends exported events to the URL of a source, signed or
+// authenticated according to its verification config. Events that fail are
+// reported with a *hookdeck.BatchError.
+func Import(ctx context.Context, src *hookdecksdk.Source, events []*Exported, opts ImportOptions) error {
+	client := &http.Client{Timeout: 30 * time.Second}
+
+	var ticker *time.Ticker
+	if opts.Rate > 0 {
+		ticker = time.NewTicker(time.Duration(float64(time.Second) / opts.Rate))
+		defer ticker.Stop()
+	}
+
+	_, err := hookdeck.Batch(ctx, events, hookdeck.BatchOptions{Concurrency: opts.Concurrency, Progress: opts.Progress}, func(ctx context.Context, event *Exported) (struct{}, error) {
+		if ticker != nil {
+			select {
+			case <-ctx.Done():
+				return struct{}{}, ctx.Err()
+			case <-ticker.C:
+			}
+		}
+		return struct{}{}, send(ctx, client, src, event)
+	})
+	return err
+}
+
+func send(ctx context.Context, client *http.Client, src *hookdecksdk.Source, event *Exported) error {
+	url := strings.TrimSuffix(src.Url, "/") + event.Path
+	if event.Query != "" {
+		url += "?" + strings.TrimPrefix(event.Query, "?")
+	}
+
+	// The method of the original request isn't part of the event
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, strings.NewReader(event.Body))
+	if err != nil {
+		return err
+	}
+	for name, value := range event.Headers {
+		lower := strings.ToLower(name)
+		if skippedImportHeaders[lower] || strings.HasPrefix(lower, "x-hookdeck-") {
+			continue
+		}
+		req.Header.Set(name, value)
+	}
+
+	if err := source.Authenticate(src, req, event.Body); err != nil {
+		return err
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("event %s: source responded with %s", event.ID, res.Status)
+	}
+	return nil
+}
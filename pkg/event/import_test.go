@@ -0,0 +1,59 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	hookdecksdk "github.com/hookdeck/hookdeck-go-sdk"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/hookdeck"
+)
+
+func TestImport(t *testing.T) {
+	events, err := ReadNDJSON(strings.NewReader(`{"id":"evt_1","path":"/orders","query":"a=1","headers":{"Content-Type":"application/json","X-Hookdeck-Signature":"sig","Content-Length":"12"},"body":"{\"id\":1}"}
+{"id":"evt_2","path":"/fail","body":"{\"id\":2}"}
+`))
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	var mu sync.Mutex
+	received := map[string]*http.Request{}
+	bodies := map[string]string{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		mu.Lock()
+		received[r.URL.Path] = r
+		bodies[r.URL.Path] = string(body)
+		mu.Unlock()
+		if r.URL.Path == "/e/src_1/fail" {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer ts.Close()
+
+	src := &hookdecksdk.Source{Name: "staging", Url: ts.URL + "/e/src_1"}
+	err = Import(context.Background(), src, events, ImportOptions{Rate: 100, Concurrency: 2})
+
+	var batchErr *hookdeck.BatchError
+	require.True(t, errors.As(err, &batchErr))
+	require.Len(t, batchErr.Errors, 1)
+	require.Contains(t, batchErr.Errors[1].Error(), "evt_2")
+
+	req := received["/e/src_1/orders"]
+	require.NotNil(t, req)
+	require.Equal(t, http.MethodPost, req.Method)
+	require.Equal(t, "a=1", req.URL.RawQuery)
+	require.Equal(t, "application/json", req.Header.Get("Content-Type"))
+	require.Empty(t, req.Header.Get("X-Hookdeck-Signature"))
+	require.Equal(t, `{"id":1}`, bodies["/e/src_1/orders"])
+
+	_, err = ReadNDJSON(strings.NewReader(`{"id":"evt_1"}` + "\n" + `not json`))
+	require.Error(t, err)
+}
@@ -0,0 +1,117 @@
+package event
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	hookdecksdk "github.com/hookdeck/hookdeck-go-sdk"
+	hookdeckclient "github.com/hookdeck/hookdeck-go-sdk/client"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/hookdeck"
+)
+
+// Checkpoint is the progress of a bulk retry, saved so that an interrupted
+// bulk retry can be resumed.
+type Checkpoint struct {
+	// Cursor points to the page of events being retried, it's empty for the
+	// first page
+	Cursor string `json:"cursor,omitempty"`
+	// Retried are the IDs of the events of the page already retried
+	Retried []string `json:"retried,omitempty"`
+}
+
+// BulkRetryOptions select the retried events and pace the retries.
+type BulkRetryOptions struct {
+	SourceID string
+	Status   string
+	// Since excludes the events created before it, when set
+	Since time.Time
+	// Checkpoint resumes a previous bulk retry
+	Checkpoint Checkpoint
+	// Save, if set, is called with the progress after each retried event.
+	// Calls to Save are serialized.
+	Save func(checkpoint Checkpoint) error
+	// Progress, if set, is called with the number of retried events after
+	// each retried event
+	Progress    func(retried int)
+	Concurrency int
+	Pacer       *hookdeck.Pacer
+	PageSize    int
+}
+
+// Retry retries the events with the given IDs.
+func Retry(ctx context.Context, client *hookdeckclient.Client, ids []string, opts hookdeck.BatchOptions) error {
+	_, err := hookdeck.Batch(ctx, ids, opts, func(ctx context.Context, id string) (*hookdecksdk.RetriedEvent, error) {
+		return client.Event.Retry(ctx, id)
+	})
+	return err
+}
+
+// BulkRetry retries the events matching the options, most recent first, and
+// returns the number of retried events. It stops at the first page with
+// events that couldn't be retried, so that the bulk retry can be resumed from
+// the last saved checkpoint.
+func BulkRetry(ctx context.Context, client *hookdeckclient.Client, opts BulkRetryOptions) (int, error) {
+	request := &hookdecksdk.EventListRequest{}
+	if opts.SourceID != "" {
+		request.SourceId = []*string{&opts.SourceID}
+	}
+	if opts.Status != "" {
+		status := hookdecksdk.EventStatus(opts.Status)
+		request.Status = &status
+	}
+
+	checkpoint := opts.Checkpoint
+	if checkpoint.Cursor != "" {
+		request.Next = &checkpoint.Cursor
+	}
+	retried := map[string]bool{}
+	for _, id := range checkpoint.Retried {
+		retried[id] = true
+	}
+
+	count := 0
+	var mu sync.Mutex
+	err := listEvents(ctx, client, request, opts.Since, opts.PageSize, func(events []*hookdecksdk.Event, next string) error {
+		ids := make([]string, 0, len(events))
+		for _, event := range events {
+			if !retried[event.Id] {
+				ids = append(ids, event.Id)
+			}
+		}
+
+		_, err := hookdeck.Batch(ctx, ids, hookdeck.BatchOptions{Concurrency: opts.Concurrency, Pacer: opts.Pacer}, func(ctx context.Context, id string) (*hookdecksdk.RetriedEvent, error) {
+			retriedEvent, err := client.Event.Retry(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			count++
+			checkpoint.Retried = append(checkpoint.Retried, id)
+			if opts.Progress != nil {
+				opts.Progress(count)
+			}
+			if opts.Save != nil {
+				if err := opts.Save(checkpoint); err != nil {
+					return nil, err
+				}
+			}
+			return retriedEvent, nil
+		})
+		if err != nil {
+			return err
+		}
+
+		checkpoint = Checkpoint{Cursor: next}
+		retried = map[string]bool{}
+		if opts.Save != nil {
+			return opts.Save(checkpoint)
+		}
+		return nil
+	})
+
+	return count, err
+}
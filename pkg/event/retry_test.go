@@ -0,0 +1,85 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/hookdeck"
+)
+
+func TestBulkRetry(t *testing.T) {
+	now := time.Now()
+	page := func(ids []int, next string) map[string]interface{} {
+		models := []map[string]interface{}{}
+		for _, id := range ids {
+			models = append(models, map[string]interface{}{
+				"id":         fmt.Sprintf("evt_%d", id),
+				"status":     "FAILED",
+				"created_at": now,
+				"updated_at": now,
+			})
+		}
+		pagination := map[string]interface{}{}
+		if next != "" {
+			pagination["next"] = next
+		}
+		return map[string]interface{}{"models": models, "count": len(models), "pagination": pagination}
+	}
+
+	var mu sync.Mutex
+	retried := []string{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/retry") {
+			id := strings.Split(r.URL.Path, "/")[3]
+			if id == "evt_5" {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				json.NewEncoder(w).Encode(map[string]interface{}{"message": "Can't retry"})
+				return
+			}
+			mu.Lock()
+			retried = append(retried, id)
+			mu.Unlock()
+			json.NewEncoder(w).Encode(map[string]interface{}{})
+			return
+		}
+
+		require.Equal(t, "FAILED", r.URL.Query().Get("status"))
+		switch r.URL.Query().Get("next") {
+		case "":
+			json.NewEncoder(w).Encode(page([]int{1, 2}, "cursor_2"))
+		case "cursor_2":
+			json.NewEncoder(w).Encode(page([]int{3, 4}, "cursor_3"))
+		default:
+			json.NewEncoder(w).Encode(page([]int{5, 6}, ""))
+		}
+	}))
+	defer ts.Close()
+
+	client := hookdeck.CreateSDKClient(hookdeck.SDKClientInit{APIBaseURL: ts.URL})
+
+	// Resumed from the second page, where evt_3 was already retried
+	var saved Checkpoint
+	count, err := BulkRetry(context.Background(), client, BulkRetryOptions{
+		Status:     "FAILED",
+		Checkpoint: Checkpoint{Cursor: "cursor_2", Retried: []string{"evt_3"}},
+		Save:       func(checkpoint Checkpoint) error { saved = checkpoint; return nil },
+		Pacer:      hookdeck.NewPacer(1000),
+		PageSize:   2,
+	})
+
+	// The bulk retry stops at the page of evt_5, which couldn't be retried
+	require.Error(t, err)
+	require.Equal(t, 2, count)
+	require.ElementsMatch(t, []string{"evt_4", "evt_6"}, retried)
+	require.Equal(t, Checkpoint{Cursor: "cursor_3", Retried: []string{"evt_6"}}, saved)
+}
@@ -0,0 +1,135 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	hookdecksdk "github.com/hookdeck/hookdeck-go-sdk"
+	hookdeckclient "github.com/hookdeck/hookdeck-go-sdk/client"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/hookdeck"
+)
+
+// MaxStatsBuckets is the maximum number of intervals of the event volume.
+const MaxStatsBuckets = 200
+
+// ConnectionStats are the metrics of the events of a connection over a
+// window of time.
+type ConnectionStats struct {
+	ConnectionID string `json:"connection_id"`
+	Name         string `json:"name"`
+	Events       int    `json:"events"`
+	Successful   int    `json:"successful"`
+	Failed       int    `json:"failed"`
+	// SuccessRate is the share of successful events among the events that
+	// are done, events still being delivered aren't counted
+	SuccessRate float64 `json:"success_rate"`
+	// AvgDeliveryMs is the average time between the creation and the
+	// successful delivery of the successful events
+	AvgDeliveryMs int64 `json:"avg_delivery_ms"`
+	// Volume is the number of events created in each interval of the
+	// window, oldest first
+	Volume []int `json:"volume"`
+
+	deliveryMs int64
+	deliveries int
+}
+
+// StatsOptions select the events the stats are computed over.
+type StatsOptions struct {
+	SourceID    string
+	Since       time.Time
+	Until       time.Time
+	Granularity time.Duration
+}
+
+// Buckets returns the number of intervals of the window.
+func (o StatsOptions) Buckets() int {
+	window := o.Until.Sub(o.Since)
+	return int((window + o.Granularity - 1) / o.Granularity)
+}
+
+// Stats computes the metrics of each connection from the events created in
+// the window, sorted by number of events.
+func Stats(ctx context.Context, client *hookdeckclient.Client, opts StatsOptions) ([]*ConnectionStats, error) {
+	if opts.Granularity <= 0 || !opts.Since.Before(opts.Until) {
+		return nil, fmt.Errorf("invalid stats window")
+	}
+	buckets := opts.Buckets()
+	if buckets > MaxStatsBuckets {
+		return nil, fmt.Errorf("the window has %d intervals, use a larger granularity to have at most %d", buckets, MaxStatsBuckets)
+	}
+
+	request := &hookdecksdk.EventListRequest{}
+	if opts.SourceID != "" {
+		request.SourceId = []*string{&opts.SourceID}
+	}
+
+	stats := map[string]*ConnectionStats{}
+	err := listEvents(ctx, client, request, opts.Since, 0, func(events []*hookdecksdk.Event, next string) error {
+		for _, event := range events {
+			if !event.CreatedAt.Before(opts.Until) {
+				continue
+			}
+
+			s, ok := stats[event.WebhookId]
+			if !ok {
+				s = &ConnectionStats{ConnectionID: event.WebhookId, Volume: make([]int, buckets)}
+				stats[event.WebhookId] = s
+			}
+			s.add(event, int(event.CreatedAt.Sub(opts.Since)/opts.Granularity))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*ConnectionStats, 0, len(stats))
+	for _, s := range stats {
+		if s.deliveries > 0 {
+			s.AvgDeliveryMs = s.deliveryMs / int64(s.deliveries)
+		}
+		if s.Successful+s.Failed > 0 {
+			s.SuccessRate = float64(s.Successful) / float64(s.Successful+s.Failed)
+		}
+		result = append(result, s)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Events != result[j].Events {
+			return result[i].Events > result[j].Events
+		}
+		return result[i].ConnectionID < result[j].ConnectionID
+	})
+
+	// Connections may have been deleted since, in which case they're only
+	// shown with their ID
+	connections, _ := hookdeck.Batch(ctx, result, hookdeck.BatchOptions{}, func(ctx context.Context, s *ConnectionStats) (*hookdecksdk.Connection, error) {
+		return client.Connection.Retrieve(ctx, s.ConnectionID)
+	})
+	for i, connection := range connections {
+		if connection != nil && connection.FullName != nil {
+			result[i].Name = *connection.FullName
+		}
+	}
+
+	return result, nil
+}
+
+func (s *ConnectionStats) add(event *hookdecksdk.Event, bucket int) {
+	s.Events++
+	s.Volume[bucket]++
+
+	switch event.Status {
+	case hookdecksdk.EventStatusSuccessful:
+		s.Successful++
+		if event.SuccessfulAt != nil {
+			s.deliveryMs += event.SuccessfulAt.Sub(event.CreatedAt).Milliseconds()
+			s.deliveries++
+		}
+	case hookdecksdk.EventStatusFailed:
+		s.Failed++
+	}
+}
@@ -0,0 +1,78 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/hookdeck"
+)
+
+func TestStats(t *testing.T) {
+	until := time.Date(2024, 1, 31, 12, 0, 0, 0, time.UTC)
+	since := until.Add(-3 * time.Hour)
+
+	newEvent := func(id, connection, status string, age time.Duration, delivery time.Duration) map[string]interface{} {
+		event := map[string]interface{}{
+			"id":         id,
+			"webhook_id": connection,
+			"status":     status,
+			"created_at": until.Add(-age),
+			"updated_at": until,
+		}
+		if delivery > 0 {
+			event["successful_at"] = until.Add(-age).Add(delivery)
+		}
+		return event
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/2024-03-01/events":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"models": []map[string]interface{}{
+					newEvent("evt_1", "web_1", "SUCCESSFUL", 10*time.Minute, 200*time.Millisecond),
+					newEvent("evt_2", "web_1", "FAILED", 70*time.Minute, 0),
+					newEvent("evt_3", "web_2", "SUCCESSFUL", 80*time.Minute, 100*time.Millisecond),
+					newEvent("evt_4", "web_1", "SUCCESSFUL", 170*time.Minute, 400*time.Millisecond),
+					newEvent("evt_5", "web_1", "QUEUED", 175*time.Minute, 0),
+					newEvent("evt_6", "web_1", "SUCCESSFUL", 5*time.Hour, time.Second),
+				},
+			})
+		case "/2024-03-01/connections/web_1":
+			json.NewEncoder(w).Encode(map[string]interface{}{"id": "web_1", "full_name": "stripe -> api"})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"not found"}`))
+		}
+	}))
+	defer ts.Close()
+
+	client := hookdeck.CreateSDKClient(hookdeck.SDKClientInit{APIBaseURL: ts.URL})
+	stats, err := Stats(context.Background(), client, StatsOptions{Since: since, Until: until, Granularity: time.Hour})
+	require.NoError(t, err)
+	require.Len(t, stats, 2)
+
+	require.Equal(t, "web_1", stats[0].ConnectionID)
+	require.Equal(t, "stripe -> api", stats[0].Name)
+	require.Equal(t, 4, stats[0].Events)
+	require.Equal(t, 2, stats[0].Successful)
+	require.Equal(t, 1, stats[0].Failed)
+	require.InDelta(t, 2.0/3.0, stats[0].SuccessRate, 0.001)
+	require.Equal(t, int64(300), stats[0].AvgDeliveryMs)
+	require.Equal(t, []int{2, 1, 1}, stats[0].Volume)
+
+	// The connection couldn't be retrieved
+	require.Equal(t, "web_2", stats[1].ConnectionID)
+	require.Empty(t, stats[1].Name)
+	require.Equal(t, []int{0, 1, 0}, stats[1].Volume)
+
+	_, err = Stats(context.Background(), client, StatsOptions{Since: since, Until: until, Granularity: time.Second})
+	require.Error(t, err)
+}
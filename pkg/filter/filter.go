@@ -0,0 +1,365 @@
+// Package filter evaluates Hookdeck filter rules locally, so filters can be
+// tested against sample requests without sending events through Hookdeck.
+//
+// Filters follow the Hookdeck filter syntax: primitive values must be equal,
+// objects match each of their fields, arrays must all be contained in the
+// input array, and operators ($eq, $neq, $gt, $gte, $lt, $lte, $in, $nin,
+// $startsWith, $endsWith, $exist, $and, $or, $not) compare values.
+package filter
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// Rule is a filter rule, with a filter for each part of the request.
+type Rule struct {
+	Body    interface{} `json:"body,omitempty"`
+	Headers interface{} `json:"headers,omitempty"`
+	Query   interface{} `json:"query,omitempty"`
+	Path    interface{} `json:"path,omitempty"`
+}
+
+// Request is the part of an event that filters are evaluated against.
+type Request struct {
+	Body    interface{}
+	Headers map[string]interface{}
+	Query   map[string]interface{}
+	Path    string
+}
+
+// Result is the outcome of evaluating a filter rule.
+type Result struct {
+	Match bool
+	// Reasons explain why the filter didn't match
+	Reasons []string
+}
+
+// ParseRule parses a filter rule from JSON. The JSON can either be a full
+// filter rule (as set on a connection) or a body filter. It is considered a
+// full rule when it has a body, headers, query or path field.
+func ParseRule(data string) (*Rule, error) {
+	raw := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(data), &raw); err != nil {
+		return nil, fmt.Errorf("invalid filter: %s", err)
+	}
+
+	isRule := raw["type"] == "filter"
+	for _, key := range []string{"body", "headers", "query", "path"} {
+		if _, ok := raw[key]; ok {
+			isRule = true
+		}
+	}
+	if !isRule {
+		return &Rule{Body: raw}, nil
+	}
+
+	rule := &Rule{}
+	if err := json.Unmarshal([]byte(data), rule); err != nil {
+		return nil, fmt.Errorf("invalid filter: %s", err)
+	}
+
+	return rule, nil
+}
+
+// NewRequest builds a request from a raw body, headers and query string.
+// The body is parsed as JSON when possible.
+func NewRequest(body string, headers map[string]string, rawQuery string, path string) (*Request, error) {
+	req := &Request{
+		Headers: map[string]interface{}{},
+		Query:   map[string]interface{}{},
+		Path:    path,
+	}
+
+	if body != "" {
+		var parsed interface{}
+		if err := json.Unmarshal([]byte(body), &parsed); err == nil {
+			req.Body = parsed
+		} else {
+			req.Body = body
+		}
+	}
+
+	for name, value := range headers {
+		req.Headers[strings.ToLower(name)] = value
+	}
+
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return nil, fmt.Errorf("invalid query string: %s", err)
+	}
+	for name, values := range query {
+		if len(values) == 1 {
+			req.Query[name] = values[0]
+		} else {
+			list := make([]interface{}, len(values))
+			for i, value := range values {
+				list[i] = value
+			}
+			req.Query[name] = list
+		}
+	}
+
+	return req, nil
+}
+
+// Evaluate checks whether the request matches the filter rule.
+func (r *Rule) Evaluate(req *Request) Result {
+	var reasons []string
+
+	if r.Body != nil {
+		reasons = append(reasons, match(r.Body, req.Body, "body")...)
+	}
+	if r.Headers != nil {
+		reasons = append(reasons, match(r.Headers, toInterface(req.Headers), "headers")...)
+	}
+	if r.Query != nil {
+		reasons = append(reasons, match(r.Query, toInterface(req.Query), "query")...)
+	}
+	if r.Path != nil {
+		reasons = append(reasons, match(r.Path, req.Path, "path")...)
+	}
+
+	return Result{
+		Match:   len(reasons) == 0,
+		Reasons: reasons,
+	}
+}
+
+// match returns the reasons why the input doesn't match the filter, or
+// nothing if it matches.
+func match(filter interface{}, input interface{}, path string) []string {
+	switch f := filter.(type) {
+	case map[string]interface{}:
+		var reasons []string
+		for _, key := range sortedKeys(f) {
+			if strings.HasPrefix(key, "$") {
+				reasons = append(reasons, matchOperator(key, f[key], input, path)...)
+				continue
+			}
+
+			object, ok := input.(map[string]interface{})
+			if !ok {
+				reasons = append(reasons, fmt.Sprintf("%s: expected an object, got %s", path, format(input)))
+				continue
+			}
+			reasons = append(reasons, match(f[key], object[key], path+"."+key)...)
+		}
+		return reasons
+
+	case []interface{}:
+		array, ok := input.([]interface{})
+		if !ok {
+			return []string{fmt.Sprintf("%s: expected an array, got %s", path, format(input))}
+		}
+		var reasons []string
+		for _, value := range f {
+			if !containsMatch(array, value, path) {
+				reasons = append(reasons, fmt.Sprintf("%s: expected array to contain %s", path, format(value)))
+			}
+		}
+		return reasons
+
+	default:
+		// An array input matches a primitive if one of its elements does
+		if array, ok := input.([]interface{}); ok {
+			if containsMatch(array, filter, path) {
+				return nil
+			}
+			return []string{fmt.Sprintf("%s: expected array to contain %s", path, format(filter))}
+		}
+		if !equal(filter, input) {
+			return []string{fmt.Sprintf("%s: expected %s, got %s", path, format(filter), format(input))}
+		}
+		return nil
+	}
+}
+
+func matchOperator(operator string, value interface{}, input interface{}, path string) []string {
+	fail := func(format string, args ...interface{}) []string {
+		return []string{fmt.Sprintf("%s: %s", path, fmt.Sprintf(format, args...))}
+	}
+
+	switch operator {
+	case "$eq":
+		return match(value, input, path)
+
+	case "$neq":
+		if len(match(value, input, path)) == 0 {
+			return fail("expected value other than %s", format(value))
+		}
+
+	case "$gt", "$gte", "$lt", "$lte":
+		cmp, ok := compare(input, value)
+		if !ok {
+			return fail("cannot compare %s with %s", format(input), format(value))
+		}
+		matched := map[string]bool{
+			"$gt":  cmp > 0,
+			"$gte": cmp >= 0,
+			"$lt":  cmp < 0,
+			"$lte": cmp <= 0,
+		}[operator]
+		if !matched {
+			return fail("expected %s %s, got %s", operatorNames[operator], format(value), format(input))
+		}
+
+	case "$in":
+		if !in(value, input, path) {
+			return fail("expected %s to be in %s", format(input), format(value))
+		}
+
+	case "$nin":
+		if in(value, input, path) {
+			return fail("expected %s not to be in %s", format(input), format(value))
+		}
+
+	case "$startsWith", "$endsWith":
+		prefix, ok1 := value.(string)
+		str, ok2 := input.(string)
+		hasAffix := strings.HasPrefix
+		if operator == "$endsWith" {
+			hasAffix = strings.HasSuffix
+		}
+		if !ok1 || !ok2 || !hasAffix(str, prefix) {
+			return fail("expected %s to %s %s", format(input), operatorNames[operator], format(value))
+		}
+
+	case "$exist":
+		expected, _ := value.(bool)
+		if (input != nil) != expected {
+			if expected {
+				return fail("expected value to exist")
+			}
+			return fail("expected value not to exist, got %s", format(input))
+		}
+
+	case "$and":
+		filters, ok := value.([]interface{})
+		if !ok {
+			return fail("$and expects an array of filters")
+		}
+		var reasons []string
+		for _, f := range filters {
+			reasons = append(reasons, match(f, input, path)...)
+		}
+		return reasons
+
+	case "$or":
+		filters, ok := value.([]interface{})
+		if !ok {
+			return fail("$or expects an array of filters")
+		}
+		var reasons []string
+		for _, f := range filters {
+			r := match(f, input, path)
+			if len(r) == 0 {
+				return nil
+			}
+			reasons = append(reasons, r...)
+		}
+		return append(fail("none of the $or filters matched"), reasons...)
+
+	case "$not":
+		if len(match(value, input, path)) == 0 {
+			return fail("expected value not to match %s", format(value))
+		}
+
+	default:
+		return fail("unsupported operator %s", operator)
+	}
+
+	return nil
+}
+
+var operatorNames = map[string]string{
+	"$gt":         ">",
+	"$gte":        ">=",
+	"$lt":         "<",
+	"$lte":        "<=",
+	"$startsWith": "start with",
+	"$endsWith":   "end with",
+}
+
+// in checks whether the input is one of the values, or, for strings,
+// whether the value contains the input.
+func in(value interface{}, input interface{}, path string) bool {
+	switch v := value.(type) {
+	case []interface{}:
+		return containsMatch(v, input, path)
+	case string:
+		str, ok := input.(string)
+		return ok && strings.Contains(v, str)
+	default:
+		return false
+	}
+}
+
+func containsMatch(array []interface{}, filter interface{}, path string) bool {
+	for _, element := range array {
+		if len(match(filter, element, path)) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func equal(a interface{}, b interface{}) bool {
+	return format(a) == format(b)
+}
+
+func compare(a interface{}, b interface{}) (int, bool) {
+	switch x := a.(type) {
+	case float64:
+		y, ok := b.(float64)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case x < y:
+			return -1, true
+		case x > y:
+			return 1, true
+		default:
+			return 0, true
+		}
+	case string:
+		y, ok := b.(string)
+		if !ok {
+			return 0, false
+		}
+		return strings.Compare(x, y), true
+	default:
+		return 0, false
+	}
+}
+
+func format(value interface{}) string {
+	if value == nil {
+		return "nothing"
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(data)
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func toInterface(m map[string]interface{}) interface{} {
+	if m == nil {
+		return map[string]interface{}{}
+	}
+	return m
+}
@@ -0,0 +1,53 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func evaluate(t *testing.T, filter string, body string) Result {
+	rule, err := ParseRule(filter)
+	require.NoError(t, err)
+	req, err := NewRequest(body, map[string]string{"X-Event": "order.created"}, "shop=acme&tag=a&tag=b", "/webhooks")
+	require.NoError(t, err)
+	return rule.Evaluate(req)
+}
+
+func TestEvaluate(t *testing.T) {
+	body := `{"type":"order.created","amount":120,"items":[{"sku":"A1"},{"sku":"B2"}],"customer":{"email":"jane@example.com"}}`
+
+	tests := []struct {
+		filter string
+		match  bool
+	}{
+		{`{"type":"order.created"}`, true},
+		{`{"type":"order.updated"}`, false},
+		{`{"amount":{"$gte":100,"$lt":200}}`, true},
+		{`{"amount":{"$gt":120}}`, false},
+		{`{"type":{"$startsWith":"order."}}`, true},
+		{`{"customer":{"email":{"$endsWith":"@example.org"}}}`, false},
+		{`{"items":[{"sku":"B2"}]}`, true},
+		{`{"items":{"sku":"C3"}}`, false},
+		{`{"type":{"$in":["order.created","order.paid"]}}`, true},
+		{`{"type":{"$nin":["order.created"]}}`, false},
+		{`{"refund":{"$exist":false}}`, true},
+		{`{"$or":[{"type":"order.paid"},{"amount":120}]}`, true},
+		{`{"$not":{"type":"order.created"}}`, false},
+		{`{"headers":{"x-event":"order.created"},"query":{"tag":"b"},"path":{"$startsWith":"/webhooks"}}`, true},
+		{`{"type":"filter","query":{"shop":"other"}}`, false},
+	}
+
+	for _, test := range tests {
+		result := evaluate(t, test.filter, body)
+		require.Equal(t, test.match, result.Match, "%s: %v", test.filter, result.Reasons)
+		if !test.match {
+			require.NotEmpty(t, result.Reasons)
+		}
+	}
+}
+
+func TestEvaluate_Reasons(t *testing.T) {
+	result := evaluate(t, `{"customer":{"email":"john@example.com"}}`, `{"customer":{"email":"jane@example.com"}}`)
+	require.Equal(t, []string{`body.customer.email: expected "john@example.com", got "jane@example.com"`}, result.Reasons)
+}
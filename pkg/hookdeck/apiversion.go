@@ -0,0 +1,80 @@
+package hookdeck
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// DefaultAPIVersion is the version of the API the CLI is built against.
+const DefaultAPIVersion = "2024-03-01"
+
+// APIVersions lists the versions of the API known when the CLI was built,
+// that can be selected with the `--api-version` flag. Versions other than the
+// default one can be used to test an upgrade, but responses are still decoded
+// with the models of the default version.
+var APIVersions = []string{"2024-03-01", "2024-09-01", "2025-01-01", "2025-07-01"}
+
+// ValidateAPIVersion returns an error if the API version isn't a date like
+// 2024-03-01. Versions released after the CLI was built are valid, see
+// IsKnownAPIVersion.
+func ValidateAPIVersion(version string) error {
+	if _, err := time.Parse("2006-01-02", version); err != nil {
+		return fmt.Errorf("invalid API version %q, expected a date like %s", version, DefaultAPIVersion)
+	}
+	return nil
+}
+
+// IsKnownAPIVersion returns whether the API version is one of APIVersions.
+func IsKnownAPIVersion(version string) bool {
+	for _, known := range APIVersions {
+		if version == known {
+			return true
+		}
+	}
+	return false
+}
+
+// deprecationTransport warns when the API reports that the requested API
+// version or endpoint is deprecated, with the Deprecation, Sunset or Warning
+// response headers. Each warning is only printed once.
+type deprecationTransport struct {
+	Transport http.RoundTripper
+}
+
+var deprecationWarnings sync.Map
+
+func (t *deprecationTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.Transport.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	if warning := deprecationWarning(resp.Header); warning != "" {
+		if _, warned := deprecationWarnings.LoadOrStore(warning, true); !warned {
+			log.WithFields(log.Fields{
+				"prefix": "hookdeck.deprecationTransport.RoundTrip",
+			}).Warn(warning)
+		}
+	}
+
+	return resp, nil
+}
+
+func deprecationWarning(header http.Header) string {
+	if warning := header.Get("Warning"); warning != "" {
+		return "API warning: " + warning
+	}
+	if header.Get("Deprecation") == "" {
+		return ""
+	}
+
+	warning := "This API version is deprecated"
+	if sunset := header.Get("Sunset"); sunset != "" {
+		warning += " and will be removed on " + sunset
+	}
+	return warning + ". Use --api-version to test a newer version."
+}
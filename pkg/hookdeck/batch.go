@@ -0,0 +1,139 @@
+package hookdeck
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultBatchConcurrency is the number of calls Batch runs at once when no
+// concurrency is set. It is kept low since rate limited requests are retried
+// by the client anyway.
+const DefaultBatchConcurrency = 5
+
+// BatchOptions configures Batch.
+type BatchOptions struct {
+	// Maximum number of calls running at once
+	Concurrency int
+	// Progress, if set, is called after each call completes with the number
+	// of completed calls and the total number of calls. Calls to Progress
+	// are serialized.
+	Progress func(done, total int)
+	// Pacer, if set, paces the calls. It's slowed down when the API rate
+	// limits the calls.
+	Pacer *Pacer
+}
+
+// BatchError is returned by Batch when some of the calls failed.
+type BatchError struct {
+	Total int
+	// Errors maps the index of the failed items to their error
+	Errors map[int]error
+}
+
+func (e *BatchError) Error() string {
+	indexes := make([]int, 0, len(e.Errors))
+	for i := range e.Errors {
+		indexes = append(indexes, i)
+	}
+	sort.Ints(indexes)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d of %d operations failed:", len(e.Errors), e.Total)
+	for _, i := range indexes {
+		fmt.Fprintf(&b, "\n  - item %d: %s", i+1, e.Errors[i])
+	}
+	return b.String()
+}
+
+// Unwrap returns the error of the first failed call, so that errors.As can
+// find an API error in a batch, e.g. for its exit code.
+func (e *BatchError) Unwrap() error {
+	first := -1
+	for i := range e.Errors {
+		if first == -1 || i < first {
+			first = i
+		}
+	}
+	if first == -1 {
+		return nil
+	}
+	return e.Errors[first]
+}
+
+// Batch calls fn for each item, running up to opts.Concurrency calls at once.
+// Results are returned in the order of the items. All the calls are made even
+// if some fail, in which case a *BatchError is returned along with the results
+// of the successful calls. No new call is started once ctx is done.
+func Batch[T, R any](ctx context.Context, items []T, opts BatchOptions, fn func(ctx context.Context, item T) (R, error)) ([]R, error) {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultBatchConcurrency
+	}
+
+	results := make([]R, len(items))
+	batchErr := &BatchError{Total: len(items), Errors: map[int]error{}}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	done := 0
+	sem := make(chan struct{}, concurrency)
+
+	if opts.Pacer != nil {
+		ctx = WithPacer(ctx, opts.Pacer)
+	}
+
+	for i, item := range items {
+		var err error
+		select {
+		case sem <- struct{}{}:
+			if opts.Pacer != nil {
+				if err = opts.Pacer.Wait(ctx); err != nil {
+					<-sem
+				}
+			}
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+		if err != nil {
+			mu.Lock()
+			for j := i; j < len(items); j++ {
+				batchErr.Errors[j] = err
+			}
+			mu.Unlock()
+			wg.Wait()
+			return results, batchErr
+		}
+
+		wg.Add(1)
+		go func(i int, item T) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			result, err := fn(ctx, item)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				batchErr.Errors[i] = err
+			} else {
+				results[i] = result
+				if opts.Pacer != nil {
+					opts.Pacer.SpeedUp()
+				}
+			}
+			done++
+			if opts.Progress != nil {
+				opts.Progress(done, len(items))
+			}
+		}(i, item)
+	}
+	wg.Wait()
+
+	if len(batchErr.Errors) > 0 {
+		return results, batchErr
+	}
+	return results, nil
+}
@@ -0,0 +1,86 @@
+package hookdeck
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatch(t *testing.T) {
+	var running, maxRunning int32
+	progress := []int{}
+
+	results, err := Batch(context.Background(), []int{1, 2, 3, 4, 5, 6}, BatchOptions{
+		Concurrency: 2,
+		Progress:    func(done, total int) { progress = append(progress, done) },
+	}, func(ctx context.Context, item int) (int, error) {
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			max := atomic.LoadInt32(&maxRunning)
+			if n <= max || atomic.CompareAndSwapInt32(&maxRunning, max, n) {
+				break
+			}
+		}
+		return item * 10, nil
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, []int{10, 20, 30, 40, 50, 60}, results)
+	require.LessOrEqual(t, maxRunning, int32(2))
+	require.Equal(t, []int{1, 2, 3, 4, 5, 6}, progress)
+}
+
+func TestBatch_Errors(t *testing.T) {
+	notFound := &APIError{StatusCode: http.StatusNotFound, Message: "Not found"}
+
+	results, err := Batch(context.Background(), []string{"a", "b", "c"}, BatchOptions{}, func(ctx context.Context, item string) (string, error) {
+		if item == "b" {
+			return "", notFound
+		}
+		return item, nil
+	})
+
+	require.Equal(t, []string{"a", "", "c"}, results)
+	require.EqualError(t, err, "1 of 3 operations failed:\n  - item 2: Not found")
+
+	var batchErr *BatchError
+	require.True(t, errors.As(err, &batchErr))
+	apiErr, ok := AsAPIError(err)
+	require.True(t, ok)
+	require.True(t, apiErr.IsNotFound())
+}
+
+func TestBatchError_UnwrapFirstError(t *testing.T) {
+	unauthorized := &APIError{StatusCode: http.StatusUnauthorized, Message: "Unauthorized"}
+	err := &BatchError{Total: 4, Errors: map[int]error{
+		3: errors.New("timeout"),
+		1: unauthorized,
+		2: &APIError{StatusCode: http.StatusNotFound, Message: "Not found"},
+	}}
+
+	require.Equal(t, unauthorized, errors.Unwrap(err))
+	apiErr, ok := AsAPIError(err)
+	require.True(t, ok)
+	require.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+	require.Nil(t, (&BatchError{}).Unwrap())
+}
+
+func TestBatch_Pacer(t *testing.T) {
+	pacer := NewPacer(100)
+	start := time.Now()
+
+	_, err := Batch(context.Background(), []int{1, 2, 3, 4, 5}, BatchOptions{Pacer: pacer}, func(ctx context.Context, item int) (int, error) {
+		require.Equal(t, pacer, pacerFromContext(ctx))
+		return item, nil
+	})
+
+	require.NoError(t, err)
+	// The first call isn't delayed
+	require.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+}
@@ -24,12 +24,12 @@ type CreateCIClientInput struct {
 	DeviceName string `json:"device_name"`
 }
 
-func (c *Client) CreateCIClient(input CreateCIClientInput) (CIClient, error) {
+func (c *Client) CreateCIClient(ctx context.Context, input CreateCIClientInput) (CIClient, error) {
 	input_bytes, err := json.Marshal(input)
 	if err != nil {
 		return CIClient{}, err
 	}
-	res, err := c.Post(context.Background(), "/cli-auth/ci", input_bytes, nil)
+	res, err := c.Post(ctx, "/cli-auth/ci", input_bytes, nil)
 	if err != nil {
 		return CIClient{}, err
 	}
@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"fmt"
 	"io/ioutil"
 	"net"
 	"net/http"
@@ -42,34 +41,47 @@ type Client struct {
 
 	TeamID string
 
-	// When this is enabled, request and response headers will be printed to
-	// stdout.
+	// Level of request tracing printed to stderr. See VerbosityRequests,
+	// VerbosityHeaders and VerbosityBodies.
+	Verbosity int
+
+	// Verbose traces the requests at VerbosityRequests when Verbosity is
+	// lower.
+	//
+	// Deprecated: use Verbosity instead.
 	Verbose bool
 
+	// Maximum number of times a request is retried when it is rate limited
+	// or fails with a transient server error. Zero disables retries.
+	MaxRetries int
+
+	// Product identifying the program embedding the client, prepended to the
+	// User-Agent header of the requests, e.g. "my-tool/1.0".
+	UserAgent string
+
+	// HTTP client sending the requests. If left nil, a client retrying the
+	// requests up to MaxRetries times and tracing them at Verbosity is used.
+	HTTPClient HTTPDoer
+
 	// Cached HTTP client, lazily created the first time the Client is used to
 	// send a request.
 	httpClient *http.Client
 }
 
-type ErrorResponse struct {
-	Handled bool   `json:"Handled"`
-	Message string `json:"message"`
-}
-
 // PerformRequest sends a request to Hookdeck and returns the response.
 func (c *Client) PerformRequest(ctx context.Context, req *http.Request) (*http.Response, error) {
 	if req.Header == nil {
 		req.Header = http.Header{}
 	}
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("User-Agent", useragent.GetEncodedUserAgent())
+	req.Header.Set("User-Agent", userAgent(c.UserAgent, useragent.GetEncodedUserAgent()))
 	req.Header.Set("X-Hookdeck-Client-User-Agent", useragent.GetEncodedHookdeckUserAgent())
 
 	if c.TeamID != "" {
 		req.Header.Set("X-Team-ID", c.TeamID)
 	}
 
-	if !telemetryOptedOut(os.Getenv("HOOKDECK_CLI_TELEMETRY_OPTOUT")) {
+	if telemetryEnabled() {
 		telemetryHdr, err := getTelemetryHeader()
 		if err == nil {
 			req.Header.Set("Hookdeck-CLI-Telemetry", telemetryHdr)
@@ -80,15 +92,19 @@ func (c *Client) PerformRequest(ctx context.Context, req *http.Request) (*http.R
 		req.SetBasicAuth(c.APIKey, "")
 	}
 
-	if c.httpClient == nil {
-		c.httpClient = newHTTPClient(c.Verbose, os.Getenv("HOOKDECK_CLI_UNIX_SOCKET"))
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		if c.httpClient == nil {
+			c.httpClient = newHTTPClient(c.verbosity(), os.Getenv("HOOKDECK_CLI_UNIX_SOCKET"), c.MaxRetries)
+		}
+		httpClient = c.httpClient
 	}
 
 	if ctx != nil {
 		req = req.WithContext(ctx)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := httpClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -147,6 +163,29 @@ func (c *Client) Put(ctx context.Context, path string, data []byte, configure fu
 	return c.PerformRequest(ctx, req)
 }
 
+func (c *Client) Delete(ctx context.Context, path string, configure func(*http.Request)) (*http.Response, error) {
+	url, err := url.Parse(path)
+	if err != nil {
+		return nil, err
+	}
+	url = c.BaseURL.ResolveReference(url)
+	req, err := http.NewRequest(http.MethodDelete, url.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.PerformRequest(ctx, req)
+}
+
+// verbosity returns the level of request tracing, including the deprecated
+// Verbose field.
+func (c *Client) verbosity() int {
+	if c.Verbose && c.Verbosity < VerbosityRequests {
+		return VerbosityRequests
+	}
+	return c.Verbosity
+}
+
 func checkAndPrintError(res *http.Response) error {
 	if res.StatusCode != http.StatusOK {
 		defer res.Body.Close()
@@ -154,16 +193,7 @@ func checkAndPrintError(res *http.Response) error {
 		if err != nil {
 			return err
 		}
-		response := &ErrorResponse{}
-		err = json.Unmarshal(body, &response)
-		if err != nil {
-			// Not a valid JSON response, just use body
-			return fmt.Errorf("unexpected http status code: %d %s", res.StatusCode, body)
-		}
-		if response.Message != "" {
-			return fmt.Errorf("error: %s", response.Message)
-		}
-		return fmt.Errorf("unexpected http status code: %d %s", res.StatusCode, body)
+		return newAPIError(res.StatusCode, body)
 	}
 	return nil
 }
@@ -178,7 +208,7 @@ func postprocessJsonResponse(res *http.Response, target interface{}) (interface{
 	return target, err
 }
 
-func newHTTPClient(verbose bool, unixSocket string) *http.Client {
+func newHTTPClient(verbosity int, unixSocket string, maxRetries int) *http.Client {
 	var httpTransport *http.Transport
 
 	if unixSocket != "" {
@@ -197,7 +227,7 @@ func newHTTPClient(verbose bool, unixSocket string) *http.Client {
 		}
 	} else {
 		httpTransport = &http.Transport{
-			Proxy: http.ProxyFromEnvironment,
+			Proxy: Proxy,
 			DialContext: (&net.Dialer{
 				Timeout:   30 * time.Second,
 				KeepAlive: 30 * time.Second,
@@ -206,10 +236,11 @@ func newHTTPClient(verbose bool, unixSocket string) *http.Client {
 		}
 	}
 
-	tr := &verboseTransport{
-		Transport: httpTransport,
-		Verbose:   verbose,
-		Out:       os.Stderr,
+	tr := &retryTransport{
+		Transport: &deprecationTransport{
+			Transport: newVerboseTransport(httpTransport, verbosity, os.Stderr),
+		},
+		MaxRetries: maxRetries,
 	}
 
 	return &http.Client{
@@ -201,3 +201,97 @@ func TestPerformRequest_ConfigureFunc(t *testing.T) {
 
 	defer resp.Body.Close()
 }
+
+func TestPerformRequest_APIError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{"code":"UNPROCESSABLE_ENTITY","message":"Invalid request","data":["\"name\" is required"]}`))
+	}))
+	defer ts.Close()
+
+	baseURL, _ := url.Parse(ts.URL)
+	client := Client{
+		BaseURL: baseURL,
+	}
+
+	_, err := client.Post(context.TODO(), "/post", []byte("{}"), nil)
+	require.Error(t, err)
+
+	apiErr, ok := AsAPIError(err)
+	require.True(t, ok)
+	require.Equal(t, http.StatusUnprocessableEntity, apiErr.StatusCode)
+	require.Equal(t, "UNPROCESSABLE_ENTITY", apiErr.Code)
+	require.True(t, apiErr.IsValidationError())
+	require.Equal(t, []string{`"name" is required`}, apiErr.FieldErrors())
+	require.Equal(t, "Invalid request\n  - \"name\" is required", apiErr.Error())
+}
+
+func TestDeprecationWarning(t *testing.T) {
+	require.Equal(t, "", deprecationWarning(http.Header{}))
+
+	header := http.Header{}
+	header.Set("Deprecation", "true")
+	header.Set("Sunset", "Wed, 01 Jul 2026 00:00:00 GMT")
+	require.Equal(t, "This API version is deprecated and will be removed on Wed, 01 Jul 2026 00:00:00 GMT. Use --api-version to test a newer version.", deprecationWarning(header))
+
+	require.NoError(t, ValidateAPIVersion(DefaultAPIVersion))
+}
+
+func TestValidateAPIVersion(t *testing.T) {
+	// Versions released after the CLI was built are valid, but unknown
+	require.NoError(t, ValidateAPIVersion("2030-01-01"))
+	require.False(t, IsKnownAPIVersion("2030-01-01"))
+	require.True(t, IsKnownAPIVersion(DefaultAPIVersion))
+
+	for _, version := range []string{"", "latest", "2024-3-1", "2024-13-01", "v2024-03-01"} {
+		require.Error(t, ValidateAPIVersion(version), version)
+	}
+}
+
+type recordingDoer struct {
+	requests []*http.Request
+}
+
+func (d *recordingDoer) Do(req *http.Request) (*http.Response, error) {
+	d.requests = append(d.requests, req)
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       ioutil.NopCloser(strings.NewReader(`[{"id":"tm_1","name":"production"}]`)),
+	}, nil
+}
+
+func TestNewClient(t *testing.T) {
+	doer := &recordingDoer{}
+	client, err := NewClient(
+		WithBaseURL("https://api.example.com"),
+		WithAPIKey("key"),
+		WithProjectID("tm_1"),
+		WithUserAgent("my-tool/1.0"),
+		WithHTTPClient(doer),
+	)
+	require.NoError(t, err)
+	require.Equal(t, DefaultMaxRetries, client.MaxRetries)
+
+	projects, err := client.ListProjects(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []Project{{Id: "tm_1", Name: "production"}}, projects)
+
+	require.Len(t, doer.requests, 1)
+	req := doer.requests[0]
+	require.Equal(t, "https://api.example.com/teams", req.URL.String())
+	require.Equal(t, "tm_1", req.Header.Get("X-Team-ID"))
+	require.True(t, strings.HasPrefix(req.Header.Get("User-Agent"), "my-tool/1.0 "))
+	apiKey, _, _ := req.BasicAuth()
+	require.Equal(t, "key", apiKey)
+
+	_, err = NewClient(WithBaseURL("api.example.com"))
+	require.EqualError(t, err, `invalid API base URL "api.example.com"`)
+	_, err = NewClient(WithMaxRetries(-1))
+	require.Error(t, err)
+}
+
+func TestClientVerbosity(t *testing.T) {
+	require.Equal(t, 0, (&Client{}).verbosity())
+	require.Equal(t, VerbosityRequests, (&Client{Verbose: true}).verbosity())
+	require.Equal(t, VerbosityBodies, (&Client{Verbose: true, Verbosity: VerbosityBodies}).verbosity())
+}
@@ -0,0 +1,8 @@
+// Package hookdeck is the client of the Hookdeck API used by the CLI, which
+// Go programs can import to reuse its authentication, retries and request
+// tracing. Create a client with NewClient and its options. The client covers
+// the account endpoints (projects, organizations, CLI sessions), and
+// SDKClient returns a client of the Hookdeck Go SDK with the same settings
+// for the resources of a project. All requests take a context, and
+// WithHTTPClient replaces the transport, e.g. to mock the API in tests.
+package hookdeck
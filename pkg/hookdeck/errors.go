@@ -0,0 +1,147 @@
+package hookdeck
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hookdeck/hookdeck-go-sdk/core"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/redact"
+)
+
+// ErrorResponse is the body of an error response of the API.
+//
+// Deprecated: requests failing with an error status return an *APIError,
+// use AsAPIError to inspect it.
+type ErrorResponse struct {
+	Handled bool   `json:"Handled"`
+	Message string `json:"message"`
+}
+
+// APIError is returned when the Hookdeck API responds with an error status.
+type APIError struct {
+	// HTTP status code of the response
+	StatusCode int `json:"-"`
+	// Error code returned by the API, e.g. UNPROCESSABLE_ENTITY
+	Code string `json:"code"`
+	// Human readable description of the error
+	Message string `json:"message"`
+	// Additional details, such as validation errors for specific fields
+	Data json.RawMessage `json:"data,omitempty"`
+
+	body string
+}
+
+func (e *APIError) Error() string {
+	message := e.Message
+	if message == "" {
+		message = fmt.Sprintf("unexpected http status code: %d %s", e.StatusCode, e.body)
+	}
+
+	fieldErrors := e.FieldErrors()
+	if len(fieldErrors) == 0 {
+		return message
+	}
+
+	return message + "\n  - " + strings.Join(fieldErrors, "\n  - ")
+}
+
+// FieldErrors returns the validation errors included in the error data.
+func (e *APIError) FieldErrors() []string {
+	if len(e.Data) == 0 {
+		return nil
+	}
+
+	var messages []string
+	if err := json.Unmarshal(e.Data, &messages); err == nil {
+		return messages
+	}
+
+	var details []struct {
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(e.Data, &details); err == nil {
+		for _, detail := range details {
+			if detail.Message != "" {
+				messages = append(messages, detail.Message)
+			}
+		}
+	}
+
+	return messages
+}
+
+// IsAuthError returns whether the request was rejected because of the API
+// key or the project permissions.
+func (e *APIError) IsAuthError() bool {
+	return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+}
+
+// IsNotFound returns whether the requested resource doesn't exist.
+func (e *APIError) IsNotFound() bool {
+	return e.StatusCode == http.StatusNotFound || e.StatusCode == http.StatusGone
+}
+
+// IsValidationError returns whether the request was invalid.
+func (e *APIError) IsValidationError() bool {
+	return e.StatusCode == http.StatusBadRequest || e.StatusCode == http.StatusUnprocessableEntity
+}
+
+// IsRateLimited returns whether the request was rate limited.
+func (e *APIError) IsRateLimited() bool {
+	return e.StatusCode == http.StatusTooManyRequests
+}
+
+// Hint returns a suggestion to help the user resolve the error.
+func (e *APIError) Hint() string {
+	switch {
+	case e.IsAuthError():
+		return "Check that your API key is valid and has access to the project, or run `hookdeck login` again."
+	case e.IsNotFound():
+		return "Check that the resource exists in the current project. Run `hookdeck project list` to see your projects."
+	case e.IsValidationError():
+		return "Check the flags and arguments passed to the command."
+	case e.IsRateLimited():
+		return "You are being rate limited. Wait a moment and try again, or increase `--max-retries`."
+	case e.StatusCode >= http.StatusInternalServerError:
+		return "Hookdeck is having trouble processing the request. Try again later."
+	default:
+		return ""
+	}
+}
+
+// AsAPIError converts errors returned by the Hookdeck client or the Hookdeck
+// Go SDK into an APIError.
+func AsAPIError(err error) (*APIError, bool) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr, true
+	}
+
+	var sdkErr *core.APIError
+	if errors.As(err, &sdkErr) && sdkErr.StatusCode != 0 {
+		body := ""
+		if inner := sdkErr.Unwrap(); inner != nil {
+			body = inner.Error()
+		}
+		return newAPIError(sdkErr.StatusCode, []byte(body)), true
+	}
+
+	return nil, false
+}
+
+func newAPIError(statusCode int, body []byte) *APIError {
+	apiErr := &APIError{}
+	if err := json.Unmarshal(body, apiErr); err != nil {
+		// Not a valid JSON response, just use body
+		apiErr = &APIError{}
+	}
+	apiErr.StatusCode = statusCode
+	// The body is printed when the error has no message
+	apiErr.body = string(redact.JSON(body))
+
+	return apiErr
+}
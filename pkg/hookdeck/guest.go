@@ -19,12 +19,12 @@ type CreateGuestUserInput struct {
 	DeviceName string `json:"device_name"`
 }
 
-func (c *Client) CreateGuestUser(input CreateGuestUserInput) (GuestUser, error) {
+func (c *Client) CreateGuestUser(ctx context.Context, input CreateGuestUserInput) (GuestUser, error) {
 	input_bytes, err := json.Marshal(input)
 	if err != nil {
 		return GuestUser{}, err
 	}
-	res, err := c.Post(context.Background(), "/cli/guest", input_bytes, nil)
+	res, err := c.Post(ctx, "/cli/guest", input_bytes, nil)
 	if err != nil {
 		return GuestUser{}, err
 	}
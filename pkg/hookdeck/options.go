@@ -0,0 +1,133 @@
+package hookdeck
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	hookdeckclient "github.com/hookdeck/hookdeck-go-sdk/client"
+)
+
+// HTTPDoer sends the HTTP requests of a Client. *http.Client implements it,
+// programs embedding the client can provide their own to mock or instrument
+// the API.
+type HTTPDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Option configures a Client created with NewClient.
+type Option func(c *Client) error
+
+// NewClient returns a client of the Hookdeck API, sending its requests to
+// DefaultAPIBaseURL and retrying them up to DefaultMaxRetries times unless
+// configured otherwise:
+//
+//	client, err := hookdeck.NewClient(
+//		hookdeck.WithAPIKey(os.Getenv("HOOKDECK_API_KEY")),
+//		hookdeck.WithUserAgent("my-tool/1.0"),
+//	)
+//	projects, err := client.ListProjects(ctx)
+//
+// Use SDKClient for the resources of a project, such as its connections.
+func NewClient(opts ...Option) (*Client, error) {
+	baseURL, err := url.Parse(DefaultAPIBaseURL)
+	if err != nil {
+		return nil, err
+	}
+	c := &Client{
+		BaseURL:    baseURL,
+		MaxRetries: DefaultMaxRetries,
+	}
+
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+// WithBaseURL sets the base URL (protocol + hostname) of the API.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) error {
+		parsed, err := url.Parse(baseURL)
+		if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+			return fmt.Errorf("invalid API base URL %q", baseURL)
+		}
+		c.BaseURL = parsed
+		return nil
+	}
+}
+
+// WithAPIKey sets the API key authenticating the requests.
+func WithAPIKey(apiKey string) Option {
+	return func(c *Client) error {
+		c.APIKey = apiKey
+		return nil
+	}
+}
+
+// WithProjectID sets the project of the requests, for the API keys giving
+// access to several projects.
+func WithProjectID(projectID string) Option {
+	return func(c *Client) error {
+		c.TeamID = projectID
+		return nil
+	}
+}
+
+// WithMaxRetries sets the number of times a request is retried when it is
+// rate limited or fails with a transient server error. Zero disables
+// retries. It has no effect with WithHTTPClient.
+func WithMaxRetries(maxRetries int) Option {
+	return func(c *Client) error {
+		if maxRetries < 0 {
+			return fmt.Errorf("invalid max retries %d", maxRetries)
+		}
+		c.MaxRetries = maxRetries
+		return nil
+	}
+}
+
+// WithUserAgent sets the product identifying the program in the User-Agent
+// header of the requests, e.g. "my-tool/1.0".
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) error {
+		c.UserAgent = userAgent
+		return nil
+	}
+}
+
+// WithHTTPClient sets the HTTP client sending the requests, replacing the
+// default one along with its retries and request tracing.
+func WithHTTPClient(httpClient HTTPDoer) Option {
+	return func(c *Client) error {
+		c.HTTPClient = httpClient
+		return nil
+	}
+}
+
+// SDKClient returns a client of the Hookdeck Go SDK with the settings of the
+// client, for the resources of a project: its sources, destinations,
+// connections, events, etc.
+func (c *Client) SDKClient() *hookdeckclient.Client {
+	return CreateSDKClient(SDKClientInit{
+		APIBaseURL: strings.TrimSuffix(c.BaseURL.String(), "/"),
+		APIKey:     c.APIKey,
+		TeamID:     c.TeamID,
+		MaxRetries: c.MaxRetries,
+		Verbosity:  c.verbosity(),
+		UserAgent:  c.UserAgent,
+		HTTPClient: c.HTTPClient,
+	})
+}
+
+// userAgent returns the User-Agent header of the requests.
+func userAgent(product string, defaultUserAgent string) string {
+	if product == "" {
+		return defaultUserAgent
+	}
+	return product + " " + defaultUserAgent
+}
@@ -0,0 +1,23 @@
+package hookdeck
+
+import (
+	"context"
+)
+
+// Organization groups projects and their members.
+type Organization struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// ListOrganizations lists the organizations of the user.
+func (c *Client) ListOrganizations(ctx context.Context) ([]Organization, error) {
+	res, err := c.Get(ctx, "/organizations", "", nil)
+	if err != nil {
+		return nil, err
+	}
+	organizations := []Organization{}
+	_, err = postprocessJsonResponse(res, &organizations)
+
+	return organizations, err
+}
@@ -0,0 +1,119 @@
+package hookdeck
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// maxPacerInterval is the longest a Pacer slows down to.
+const maxPacerInterval = 10 * time.Second
+
+// A Pacer spaces out API calls to stay under a rate. It slows down when the
+// API rate limits the calls made with its context, see WithPacer, and gets
+// back to the rate as calls succeed.
+type Pacer struct {
+	mu sync.Mutex
+	// interval is the current time between two calls, and minInterval the
+	// one of the requested rate
+	interval    time.Duration
+	minInterval time.Duration
+	next        time.Time
+}
+
+// NewPacer returns a Pacer making up to perSecond calls per second.
+func NewPacer(perSecond float64) *Pacer {
+	interval := time.Duration(float64(time.Second) / perSecond)
+	return &Pacer{interval: interval, minInterval: interval}
+}
+
+// ParseRate parses a rate such as "10/s", "300/m" or "10", in calls per
+// second.
+func ParseRate(value string) (float64, error) {
+	count, unit, _ := strings.Cut(value, "/")
+	n, err := strconv.ParseFloat(count, 64)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid rate %q, expected a positive number of calls per second or minute such as 10/s or 300/m", value)
+	}
+
+	switch unit {
+	case "", "s":
+		return n, nil
+	case "m":
+		return n / 60, nil
+	default:
+		return 0, fmt.Errorf("invalid rate %q, expected a positive number of calls per second or minute such as 10/s or 300/m", value)
+	}
+}
+
+// Wait blocks until the next call can be made.
+func (p *Pacer) Wait(ctx context.Context) error {
+	p.mu.Lock()
+	now := time.Now()
+	at := p.next
+	if at.Before(now) {
+		at = now
+	}
+	p.next = at.Add(p.interval)
+	p.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(time.Until(at)):
+		return nil
+	}
+}
+
+// SlowDown halves the rate, after a call was rate limited.
+func (p *Pacer) SlowDown() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.interval *= 2
+	if p.interval > maxPacerInterval {
+		p.interval = maxPacerInterval
+	}
+
+	log.WithFields(log.Fields{
+		"prefix": "hookdeck.Pacer.SlowDown",
+	}).Debugf("Rate limited, slowing down to %.2f calls/s", float64(time.Second)/float64(p.interval))
+}
+
+// SpeedUp gets the rate closer to the requested one, after a call succeeded.
+func (p *Pacer) SpeedUp() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.interval -= p.interval / 10
+	if p.interval < p.minInterval {
+		p.interval = p.minInterval
+	}
+}
+
+// Rate returns the current rate in calls per second.
+func (p *Pacer) Rate() float64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return float64(time.Second) / float64(p.interval)
+}
+
+type pacerContextKey struct{}
+
+// WithPacer returns a context slowing down the pacer when the API rate limits
+// the requests made with it.
+func WithPacer(ctx context.Context, pacer *Pacer) context.Context {
+	return context.WithValue(ctx, pacerContextKey{}, pacer)
+}
+
+// pacerFromContext returns the pacer of a context, or nil.
+func pacerFromContext(ctx context.Context) *Pacer {
+	pacer, _ := ctx.Value(pacerContextKey{}).(*Pacer)
+	return pacer
+}
@@ -0,0 +1,67 @@
+package hookdeck
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRate(t *testing.T) {
+	for value, expected := range map[string]float64{"10/s": 10, "10": 10, "0.5": 0.5, "300/m": 5} {
+		rate, err := ParseRate(value)
+		require.NoError(t, err, value)
+		require.Equal(t, expected, rate, value)
+	}
+
+	for _, value := range []string{"", "0/s", "-1", "ten/s", "10/h"} {
+		_, err := ParseRate(value)
+		require.Error(t, err, value)
+	}
+}
+
+func TestPacer(t *testing.T) {
+	pacer := NewPacer(10)
+	require.Equal(t, 10.0, pacer.Rate())
+
+	pacer.SlowDown()
+	pacer.SlowDown()
+	require.Equal(t, 2.5, pacer.Rate())
+
+	for i := 0; i < 50; i++ {
+		pacer.SpeedUp()
+	}
+	require.Equal(t, 10.0, pacer.Rate())
+
+	for i := 0; i < 20; i++ {
+		pacer.SlowDown()
+	}
+	require.Equal(t, 0.1, pacer.Rate())
+}
+
+func TestRetryTransport_SlowsDownPacer(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	pacer := NewPacer(10)
+	client := &http.Client{Transport: &retryTransport{Transport: http.DefaultTransport, MaxRetries: 1, MinBackoff: time.Millisecond}}
+	req, err := http.NewRequestWithContext(WithPacer(context.Background(), pacer), http.MethodPost, server.URL, nil)
+	require.NoError(t, err)
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, 5.0, pacer.Rate())
+}
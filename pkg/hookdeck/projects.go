@@ -2,18 +2,21 @@ package hookdeck
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 )
 
 type Project struct {
-	Id   string
-	Name string
-	Mode string
+	Id             string `json:"id"`
+	Name           string `json:"name"`
+	Mode           string `json:"mode"`
+	OrganizationId string `json:"organization_id,omitempty"`
 }
 
-func (c *Client) ListProjects() ([]Project, error) {
-	res, err := c.Get(context.Background(), "/teams", "", nil)
+func (c *Client) ListProjects(ctx context.Context) ([]Project, error) {
+	res, err := c.Get(ctx, "/teams", "", nil)
 	if err != nil {
 		return []Project{}, err
 	}
@@ -25,3 +28,53 @@ func (c *Client) ListProjects() ([]Project, error) {
 
 	return projects, nil
 }
+
+// CreateProject creates a project in an organization.
+func (c *Client) CreateProject(ctx context.Context, name string, organizationID string) (Project, error) {
+	data, err := json.Marshal(struct {
+		Name           string `json:"name"`
+		OrganizationId string `json:"organization_id,omitempty"`
+	}{name, organizationID})
+	if err != nil {
+		return Project{}, err
+	}
+
+	res, err := c.Post(ctx, "/teams", data, nil)
+	if err != nil {
+		return Project{}, err
+	}
+	project := Project{}
+	_, err = postprocessJsonResponse(res, &project)
+
+	return project, err
+}
+
+// RenameProject changes the name of a project.
+func (c *Client) RenameProject(ctx context.Context, id string, name string) (Project, error) {
+	data, err := json.Marshal(struct {
+		Name string `json:"name"`
+	}{name})
+	if err != nil {
+		return Project{}, err
+	}
+
+	res, err := c.Put(ctx, "/teams/"+url.PathEscape(id), data, nil)
+	if err != nil {
+		return Project{}, err
+	}
+	project := Project{}
+	_, err = postprocessJsonResponse(res, &project)
+
+	return project, err
+}
+
+// DeleteProject deletes a project with all its resources.
+func (c *Client) DeleteProject(ctx context.Context, id string) error {
+	res, err := c.Delete(ctx, "/teams/"+url.PathEscape(id), nil)
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package hookdeck
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// proxyURL is the proxy set with SetProxy. The proxy environment variables
+// are used when it's nil.
+var proxyURL *url.URL
+
+// SetProxy sets the proxy used to connect to Hookdeck, for both the API
+// requests and the websocket connection. The value is an http:// or
+// socks5:// URL, which can include the proxy credentials. An empty value
+// restores the default of using the HTTP_PROXY, HTTPS_PROXY and NO_PROXY
+// environment variables.
+func SetProxy(value string) error {
+	if value == "" {
+		proxyURL = nil
+		return nil
+	}
+
+	parsed, err := url.Parse(value)
+	if err != nil || parsed.Host == "" {
+		return fmt.Errorf("invalid proxy URL %q", value)
+	}
+	switch parsed.Scheme {
+	case "http", "socks5":
+	default:
+		return fmt.Errorf("unsupported proxy scheme %q, expected http or socks5", parsed.Scheme)
+	}
+
+	proxyURL = parsed
+	return nil
+}
+
+// Proxy returns the proxy to use for a request to Hookdeck. It can be used
+// as the Proxy of an http.Transport or a websocket dialer.
+func Proxy(req *http.Request) (*url.URL, error) {
+	if proxyURL != nil {
+		return proxyURL, nil
+	}
+	return http.ProxyFromEnvironment(req)
+}
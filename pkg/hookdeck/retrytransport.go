@@ -0,0 +1,186 @@
+package hookdeck
+
+import (
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// DefaultMaxRetries is the default number of times a request is retried
+// when it is rate limited or fails with a transient server error.
+const DefaultMaxRetries = 3
+
+const (
+	defaultMinRetryBackoff = 500 * time.Millisecond
+	defaultMaxRetryBackoff = 30 * time.Second
+)
+
+// RateLimit holds the rate limit information returned by the API in the
+// `X-RateLimit-*` response headers.
+type RateLimit struct {
+	// Limit is the number of requests allowed in the current window
+	Limit int
+	// Remaining is the number of requests left in the current window
+	Remaining int
+	// Reset is the time at which the current window resets
+	Reset time.Time
+}
+
+// GetRateLimit parses the rate limit headers of a response. It returns nil
+// if the response doesn't include them.
+func GetRateLimit(resp *http.Response) *RateLimit {
+	limit, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Limit"))
+	if err != nil {
+		return nil
+	}
+
+	rateLimit := &RateLimit{Limit: limit}
+	rateLimit.Remaining, _ = strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining"))
+	if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		rateLimit.Reset = time.Unix(reset, 0)
+	}
+
+	return rateLimit
+}
+
+// retryTransport retries requests that were rate limited or failed with a
+// transient error, using exponential backoff with jitter. The Retry-After
+// header is honored when present.
+type retryTransport struct {
+	Transport  http.RoundTripper
+	MaxRetries int
+
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := t.Transport.RoundTrip(req)
+		if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+			if pacer := pacerFromContext(req.Context()); pacer != nil {
+				pacer.SlowDown()
+			}
+		}
+
+		if attempt >= t.MaxRetries || !shouldRetry(req, resp, err) || !rewindBody(req) {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt, resp)
+
+		log.WithFields(log.Fields{
+			"prefix":  "hookdeck.retryTransport.RoundTrip",
+			"attempt": attempt + 1,
+			"wait":    wait,
+		}).Debug("Retrying request")
+
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// backoff returns how long to wait before the next attempt.
+func (t *retryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	minBackoff := t.MinBackoff
+	if minBackoff == 0 {
+		minBackoff = defaultMinRetryBackoff
+	}
+	maxBackoff := t.MaxBackoff
+	if maxBackoff == 0 {
+		maxBackoff = defaultMaxRetryBackoff
+	}
+
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			if wait > maxBackoff {
+				return maxBackoff
+			}
+			return wait
+		}
+	}
+
+	backoff := minBackoff << uint(attempt)
+	if backoff <= 0 || backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+
+	// Full jitter between half and the whole backoff
+	return backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+}
+
+// shouldRetry returns whether a request can safely be retried. Rate limited
+// and unavailable responses are always retried as the API did not process
+// them, other errors are only retried for idempotent methods.
+func shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+
+	idempotent := req.Method == http.MethodGet || req.Method == http.MethodHead ||
+		req.Method == http.MethodPut || req.Method == http.MethodDelete || req.Method == http.MethodOptions
+
+	if err != nil {
+		return idempotent
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusGatewayTimeout:
+		return idempotent
+	default:
+		return false
+	}
+}
+
+// rewindBody resets the request body so it can be sent again. It returns
+// false if the body cannot be rewound.
+func rewindBody(req *http.Request) bool {
+	if req.Body == nil || req.Body == http.NoBody {
+		return true
+	}
+	if req.GetBody == nil {
+		return false
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return false
+	}
+	req.Body = body
+
+	return true
+}
+
+// parseRetryAfter parses a Retry-After header value, which is either a
+// number of seconds or an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
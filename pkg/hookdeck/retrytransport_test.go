@@ -0,0 +1,73 @@
+package hookdeck
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryTransport_RateLimited(t *testing.T) {
+	attempts := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("X-RateLimit-Limit", "240")
+		w.Header().Set("X-RateLimit-Remaining", "239")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	client := &http.Client{Transport: &retryTransport{
+		Transport:  http.DefaultTransport,
+		MaxRetries: 3,
+	}}
+
+	req, err := http.NewRequest(http.MethodPost, ts.URL, strings.NewReader("{}"))
+	require.NoError(t, err)
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, 3, attempts)
+	require.Equal(t, &RateLimit{Limit: 240, Remaining: 239}, GetRateLimit(resp))
+}
+
+func TestRetryTransport_NonIdempotentServerError(t *testing.T) {
+	attempts := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+
+	client := &http.Client{Transport: &retryTransport{
+		Transport:  http.DefaultTransport,
+		MaxRetries: 3,
+		MinBackoff: time.Millisecond,
+	}}
+
+	resp, err := client.Post(ts.URL, "application/json", strings.NewReader("{}"))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, 1, attempts)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	wait, ok := parseRetryAfter("5")
+	require.True(t, ok)
+	require.Equal(t, 5*time.Second, wait)
+
+	_, ok = parseRetryAfter("")
+	require.False(t, ok)
+}
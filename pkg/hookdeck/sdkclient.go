@@ -11,22 +11,32 @@ import (
 	hookdeckclient "github.com/hookdeck/hookdeck-go-sdk/client"
 )
 
-const apiVersion = "/2024-03-01"
-
 type SDKClientInit struct {
 	APIBaseURL string
 	APIKey     string
 	TeamID     string
+	MaxRetries int
+	Verbosity  int
+	// APIVersion defaults to DefaultAPIVersion
+	APIVersion string
+	// UserAgent is the product prepended to the User-Agent header
+	UserAgent string
+	// HTTPClient replaces the default HTTP client when set
+	HTTPClient HTTPDoer
 }
 
 func CreateSDKClient(init SDKClientInit) *hookdeckclient.Client {
-	parsedBaseURL, err := url.Parse(init.APIBaseURL + apiVersion)
+	apiVersion := init.APIVersion
+	if apiVersion == "" {
+		apiVersion = DefaultAPIVersion
+	}
+	parsedBaseURL, err := url.Parse(init.APIBaseURL + "/" + apiVersion)
 	if err != nil {
 		log.Fatal("Invalid API base URL")
 	}
 
 	header := http.Header{}
-	header.Set("User-Agent", useragent.GetEncodedUserAgent())
+	header.Set("User-Agent", userAgent(init.UserAgent, useragent.GetEncodedUserAgent()))
 	header.Set("X-Hookdeck-Client-User-Agent", useragent.GetEncodedHookdeckUserAgent())
 	if init.TeamID != "" {
 		header.Set("X-Team-ID", init.TeamID)
@@ -35,16 +45,22 @@ func CreateSDKClient(init SDKClientInit) *hookdeckclient.Client {
 		header.Set("Authorization", "Basic "+basicAuth(init.APIKey, ""))
 	}
 
-	if !telemetryOptedOut(os.Getenv("HOOKDECK_CLI_TELEMETRY_OPTOUT")) {
+	if telemetryEnabled() {
 		telemetryHeader, err := getTelemetryHeader()
 		if err == nil {
 			header.Set("Hookdeck-CLI-Telemetry", telemetryHeader)
 		}
 	}
 
+	var httpClient HTTPDoer = init.HTTPClient
+	if httpClient == nil {
+		httpClient = newHTTPClient(init.Verbosity, os.Getenv("HOOKDECK_CLI_UNIX_SOCKET"), init.MaxRetries)
+	}
+
 	return hookdeckclient.NewClient(
 		hookdeckclient.WithBaseURL(parsedBaseURL.String()),
 		hookdeckclient.WithHTTPHeader(header),
+		hookdeckclient.WithHTTPClient(httpClient),
 	)
 }
 
@@ -16,12 +16,12 @@ type CreateSessionInput struct {
 	ConnectionIds []string `json:"webhook_ids"`
 }
 
-func (c *Client) CreateSession(input CreateSessionInput) (Session, error) {
+func (c *Client) CreateSession(ctx context.Context, input CreateSessionInput) (Session, error) {
 	input_bytes, err := json.Marshal(input)
 	if err != nil {
 		return Session{}, err
 	}
-	res, err := c.Post(context.Background(), "/cli-sessions", input_bytes, nil)
+	res, err := c.Post(ctx, "/cli-sessions", input_bytes, nil)
 	if err != nil {
 		return Session{}, err
 	}
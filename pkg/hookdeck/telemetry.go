@@ -2,6 +2,8 @@ package hookdeck
 
 import (
 	"encoding/json"
+	"io/ioutil"
+	"os"
 	"strings"
 	"sync"
 
@@ -16,8 +18,24 @@ import (
 // API requests.
 type CLITelemetry struct {
 	CommandPath       string `json:"command_path"`
-	DeviceName        string `json:"device_name"`
+	DeviceName        string `json:"device_name,omitempty"`
 	GeneratedResource bool   `json:"generated_resource"`
+	// PreviousCommand is the outcome of the previous command, as the API
+	// requests of a command are made before it completes
+	PreviousCommand *CommandOutcome `json:"previous_command,omitempty"`
+
+	// Enabled is set when the user opted in to telemetry. No telemetry is
+	// sent otherwise.
+	Enabled bool `json:"-"`
+}
+
+// CommandOutcome is the duration and error class of a completed command.
+type CommandOutcome struct {
+	CommandPath string `json:"command_path"`
+	DurationMs  int64  `json:"duration_ms"`
+	// ErrorClass is the kind of error the command failed with, e.g. auth or
+	// not_found, and empty when it succeeded
+	ErrorClass string `json:"error_class,omitempty"`
 }
 
 // SetCommandContext sets the telemetry values for the command being executed.
@@ -43,6 +61,15 @@ func (t *CLITelemetry) SetDeviceName(deviceName string) {
 // Public functions
 //
 
+// TelemetryOptOutEnvVar is the environment variable disabling telemetry,
+// regardless of the config.
+const TelemetryOptOutEnvVar = "HOOKDECK_CLI_TELEMETRY_OPTOUT"
+
+// TelemetryOptedOut returns true if telemetry is disabled by the environment.
+func TelemetryOptedOut() bool {
+	return telemetryOptedOut(os.Getenv(TelemetryOptOutEnvVar))
+}
+
 // GetTelemetryInstance returns the CLITelemetry instance (initializing it
 // first if necessary).
 func GetTelemetryInstance() *CLITelemetry {
@@ -53,6 +80,32 @@ func GetTelemetryInstance() *CLITelemetry {
 	return instance
 }
 
+// SaveCommandOutcome stores the outcome of the command in stateFile, for it
+// to be sent with the requests of the next command.
+func SaveCommandOutcome(stateFile string, outcome CommandOutcome) error {
+	data, err := json.Marshal(outcome)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(stateFile, data, 0600)
+}
+
+// LoadCommandOutcome returns the outcome of the previous command stored in
+// stateFile, if any, and removes it so that it's only sent once.
+func LoadCommandOutcome(stateFile string) *CommandOutcome {
+	data, err := ioutil.ReadFile(stateFile)
+	if err != nil {
+		return nil
+	}
+	os.Remove(stateFile)
+
+	var outcome CommandOutcome
+	if err := json.Unmarshal(data, &outcome); err != nil || outcome.CommandPath == "" {
+		return nil
+	}
+	return &outcome
+}
+
 //
 // Private variables
 //
@@ -75,6 +128,10 @@ func getTelemetryHeader() (string, error) {
 	return string(b), nil
 }
 
+func telemetryEnabled() bool {
+	return GetTelemetryInstance().Enabled && !TelemetryOptedOut()
+}
+
 // telemetryOptedOut returns true if the user has opted out of telemetry,
 // false otherwise.
 func telemetryOptedOut(optoutVar string) bool {
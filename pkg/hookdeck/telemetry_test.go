@@ -1,6 +1,8 @@
 package hookdeck
 
 import (
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/spf13/cobra"
@@ -33,3 +35,21 @@ func TestTelemetryOptedOut(t *testing.T) {
 	require.True(t, telemetryOptedOut("True"))
 	require.True(t, telemetryOptedOut("TRUE"))
 }
+
+func TestCommandOutcome(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "telemetry")
+	require.Nil(t, LoadCommandOutcome(stateFile))
+
+	outcome := CommandOutcome{CommandPath: "hookdeck connection list", DurationMs: 420, ErrorClass: "auth"}
+	require.NoError(t, SaveCommandOutcome(stateFile, outcome))
+	require.Equal(t, &outcome, LoadCommandOutcome(stateFile))
+	// The outcome is only sent once
+	require.Nil(t, LoadCommandOutcome(stateFile))
+
+	tel := GetTelemetryInstance()
+	tel.PreviousCommand = &outcome
+	defer func() { tel.PreviousCommand = nil }()
+	header, err := getTelemetryHeader()
+	require.NoError(t, err)
+	require.True(t, strings.Contains(header, `"previous_command":{"command_path":"hookdeck connection list","duration_ms":420,"error_class":"auth"}`), header)
+}
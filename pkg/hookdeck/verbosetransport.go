@@ -1,13 +1,29 @@
 package hookdeck
 
 import (
+	"bytes"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"regexp"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/hookdeck/hookdeck-cli/pkg/ansi"
+	"github.com/hookdeck/hookdeck-cli/pkg/redact"
+)
+
+// Verbosity levels for API request tracing, set with the `--verbose` flag.
+const (
+	// VerbosityRequests prints the method, URL, status, duration and
+	// whitelisted headers of each request.
+	VerbosityRequests = 1
+	// VerbosityHeaders prints all the request and response headers.
+	VerbosityHeaders = 2
+	// VerbosityBodies also prints the request and response bodies.
+	VerbosityBodies = 3
 )
 
 // inspectHeaders is the whitelist of headers that will be printed.
@@ -22,10 +38,26 @@ var inspectHeaders = []string{
 	"Hookdeck-Version",
 }
 
+var authorizationRegexp = regexp.MustCompile("(?i)^(basic|bearer) (.+)")
+
 type verboseTransport struct {
-	Transport *http.Transport
+	Transport http.RoundTripper
 	Verbose   bool
-	Out       io.Writer
+	// Print all headers instead of the whitelisted ones
+	AllHeaders bool
+	// Print the request and response bodies
+	Bodies bool
+	Out    io.Writer
+}
+
+func newVerboseTransport(transport http.RoundTripper, verbosity int, out io.Writer) *verboseTransport {
+	return &verboseTransport{
+		Transport:  transport,
+		Verbose:    verbosity >= VerbosityRequests,
+		AllHeaders: verbosity >= VerbosityHeaders,
+		Bodies:     verbosity >= VerbosityBodies,
+		Out:        out,
+	}
 }
 
 func (t *verboseTransport) RoundTrip(req *http.Request) (resp *http.Response, err error) {
@@ -33,10 +65,15 @@ func (t *verboseTransport) RoundTrip(req *http.Request) (resp *http.Response, er
 		t.dumpRequest(req)
 	}
 
+	start := time.Now()
 	resp, err = t.Transport.RoundTrip(req)
 
-	if err == nil && t.Verbose {
-		t.dumpResponse(resp)
+	if t.Verbose {
+		if err != nil {
+			t.verbosePrintln(fmt.Sprintf("< %s (%s)", err, formatDuration(time.Since(start))))
+		} else {
+			t.dumpResponse(resp, time.Since(start))
+		}
 	}
 
 	return
@@ -46,16 +83,42 @@ func (t *verboseTransport) dumpRequest(req *http.Request) {
 	info := fmt.Sprintf("> %s %s://%s%s", req.Method, req.URL.Scheme, req.URL.Host, req.URL.RequestURI())
 	t.verbosePrintln(info)
 	t.dumpHeaders(req.Header, ">")
+
+	if t.Bodies && req.Body != nil && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err == nil {
+			t.dumpBody(body, ">")
+			body.Close()
+		}
+	}
 }
 
-func (t *verboseTransport) dumpResponse(resp *http.Response) {
-	info := fmt.Sprintf("< HTTP %d", resp.StatusCode)
+func (t *verboseTransport) dumpResponse(resp *http.Response, duration time.Duration) {
+	info := fmt.Sprintf("< HTTP %d (%s)", resp.StatusCode, formatDuration(duration))
 	t.verbosePrintln(info)
 	t.dumpHeaders(resp.Header, "<")
+
+	if t.Bodies && resp.Body != nil {
+		body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+		if err == nil {
+			t.dumpBody(ioutil.NopCloser(bytes.NewReader(body)), "<")
+		}
+	}
 }
 
 func (t *verboseTransport) dumpHeaders(header http.Header, indent string) {
-	for _, listed := range inspectHeaders {
+	names := inspectHeaders
+	if t.AllHeaders {
+		names = make([]string, 0, len(header))
+		for name := range header {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	}
+
+	for _, listed := range names {
 		for name, vv := range header {
 			if !strings.EqualFold(name, listed) {
 				continue
@@ -63,9 +126,8 @@ func (t *verboseTransport) dumpHeaders(header http.Header, indent string) {
 
 			for _, v := range vv {
 				if v != "" {
-					r := regexp.MustCompile("(?i)^(basic|bearer) (.+)")
-					if r.MatchString(v) {
-						v = r.ReplaceAllString(v, "$1 [REDACTED]")
+					if authorizationRegexp.MatchString(v) {
+						v = authorizationRegexp.ReplaceAllString(v, "$1 [REDACTED]")
 					}
 
 					info := fmt.Sprintf("%s %s: %s", indent, name, v)
@@ -76,7 +138,24 @@ func (t *verboseTransport) dumpHeaders(header http.Header, indent string) {
 	}
 }
 
+func (t *verboseTransport) dumpBody(body io.ReadCloser, indent string) {
+	data, err := ioutil.ReadAll(body)
+	if err != nil || len(data) == 0 {
+		return
+	}
+	data = redact.JSON(data)
+
+	t.verbosePrintln(indent)
+	for _, line := range strings.Split(strings.TrimRight(string(data), "\n"), "\n") {
+		t.verbosePrintln(indent + " " + line)
+	}
+}
+
 func (t *verboseTransport) verbosePrintln(msg string) {
 	color := ansi.Color(t.Out)
 	fmt.Fprintln(t.Out, color.Cyan(msg))
 }
+
+func formatDuration(d time.Duration) string {
+	return d.Round(time.Millisecond).String()
+}
@@ -2,6 +2,7 @@ package hookdeck
 
 import (
 	"bytes"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"regexp"
@@ -41,7 +42,37 @@ func TestVerboseTransport_Verbose(t *testing.T) {
 	require.Regexp(t, regexp.MustCompile("> POST http://(.+)/test\n"), out)
 	require.Contains(t, out, "> Authorization: Bearer [REDACTED]\n")
 	require.Contains(t, out, "> Content-Type: application/x-www-form-urlencoded\n")
-	require.Contains(t, out, "< HTTP 200\n")
+	require.Regexp(t, regexp.MustCompile("< HTTP 200 \\(.+\\)\n"), out)
 	require.Contains(t, out, "< Request-Id: req_123\n")
 	require.NotContains(t, out, "Non-Whitelisted-Header")
 }
+
+func TestVerboseTransport_Bodies(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Non-Whitelisted-Header", "foo")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"src_123","verification":{"configs":{"webhook_secret_key":"whsec_123"}}}`))
+	}))
+	defer ts.Close()
+
+	var b bytes.Buffer
+
+	tr := newVerboseTransport(&http.Transport{}, VerbosityBodies, &b)
+	client := &http.Client{Transport: tr}
+	req, err := http.NewRequest("POST", ts.URL+"/test", bytes.NewBufferString(`{"name":"test"}`))
+	require.NoError(t, err)
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, `{"id":"src_123","verification":{"configs":{"webhook_secret_key":"whsec_123"}}}`, string(body))
+
+	out := b.String()
+	require.Contains(t, out, `> {"name":"test"}`)
+	require.Contains(t, out, `< {"id":"src_123","verification":{"configs":{"webhook_secret_key":"***"}}}`)
+	require.Contains(t, out, "< Non-Whitelisted-Header: foo\n")
+}
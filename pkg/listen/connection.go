@@ -10,7 +10,7 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
-func getConnections(client *hookdeckclient.Client, sources []*hookdecksdk.Source, connectionFilterString string, isMultiSource bool, path string) ([]*hookdecksdk.Connection, error) {
+func getConnections(client *hookdeckclient.Client, sources []*hookdecksdk.Source, connectionFilterString string, isMultiSource bool, isSourcePattern bool, path string) ([]*hookdecksdk.Connection, error) {
 	sourceIDs := []*string{}
 
 	for _, source := range sources {
@@ -29,7 +29,11 @@ func getConnections(client *hookdeckclient.Client, sources []*hookdecksdk.Source
 		return []*hookdecksdk.Connection{}, err
 	}
 
-	connections, err = ensureConnections(client, connections, sources, isMultiSource, connectionFilterString, path)
+	if isSourcePattern && connectionFilterString == "" {
+		connections, err = ensureSourcesConnections(client, connections, sources)
+	} else {
+		connections, err = ensureConnections(client, connections, sources, isMultiSource, connectionFilterString, path)
+	}
 	if err != nil {
 		return []*hookdecksdk.Connection{}, err
 	}
@@ -98,14 +102,7 @@ func ensureConnections(client *hookdeckclient.Client, connections []*hookdecksdk
 		connectionDetails.Path = path
 	}
 
-	connection, err := client.Connection.Create(context.Background(), &hookdecksdk.ConnectionCreateRequest{
-		Name:     hookdecksdk.OptionalOrNull(&connectionDetails.ConnectionName),
-		SourceId: hookdecksdk.OptionalOrNull(&sources[0].Id),
-		Destination: hookdecksdk.OptionalOrNull(&hookdecksdk.ConnectionCreateRequestDestination{
-			Name:    connectionDetails.DestinationName,
-			CliPath: &connectionDetails.Path,
-		}),
-	})
+	connection, err := createConnection(client, sources[0], connectionDetails.ConnectionName, connectionDetails.DestinationName, connectionDetails.Path)
 	if err != nil {
 		return connections, err
 	}
@@ -113,3 +110,40 @@ func ensureConnections(client *hookdeckclient.Client, connections []*hookdecksdk
 
 	return connections, nil
 }
+
+// When users listen to the sources matching a glob pattern, we set up a CLI
+// connection for each matched source that doesn't have one yet.
+func ensureSourcesConnections(client *hookdeckclient.Client, connections []*hookdecksdk.Connection, sources []*hookdecksdk.Source) ([]*hookdecksdk.Connection, error) {
+	connectedSources := map[string]bool{}
+	for _, connection := range connections {
+		connectedSources[connection.Source.Id] = true
+	}
+
+	for _, source := range sources {
+		if connectedSources[source.Id] {
+			continue
+		}
+
+		log.Debug(fmt.Sprintf("No connection found. Creating a connection for Source \"%s\"", source.Name))
+
+		destinationName := fmt.Sprintf("%s-%s", "cli", source.Name)
+		connection, err := createConnection(client, source, fmt.Sprintf("%s_to_%s", source.Name, destinationName), destinationName, "/")
+		if err != nil {
+			return connections, err
+		}
+		connections = append(connections, connection)
+	}
+
+	return connections, nil
+}
+
+func createConnection(client *hookdeckclient.Client, source *hookdecksdk.Source, connectionName string, destinationName string, path string) (*hookdecksdk.Connection, error) {
+	return client.Connection.Create(context.Background(), &hookdecksdk.ConnectionCreateRequest{
+		Name:     hookdecksdk.OptionalOrNull(&connectionName),
+		SourceId: hookdecksdk.OptionalOrNull(&source.Id),
+		Destination: hookdecksdk.OptionalOrNull(&hookdecksdk.ConnectionCreateRequestDestination{
+			Name:    destinationName,
+			CliPath: &path,
+		}),
+	})
+}
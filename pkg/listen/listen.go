@@ -17,12 +17,18 @@ package listen
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"net/url"
+	"os"
 	"regexp"
 	"strings"
+	"time"
 
+	"github.com/hookdeck/hookdeck-cli/pkg/ansi"
 	"github.com/hookdeck/hookdeck-cli/pkg/config"
 	"github.com/hookdeck/hookdeck-cli/pkg/login"
 	"github.com/hookdeck/hookdeck-cli/pkg/proxy"
@@ -30,9 +36,65 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
+// inspectorMaxEvents is the number of events kept by the inspector.
+const inspectorMaxEvents = 100
+
 type Flags struct {
-	NoWSS bool
-	Path  string
+	NoWSS         bool
+	Path          string
+	SigningSecret string
+	ResignSecret  string
+	Output        string
+	Filter        *proxy.EventFilter
+	RespondStatus int
+	RespondBody   string
+	NoAck         bool
+	// Expect marks the events whose response doesn't match it as failed
+	Expect *proxy.Expectation
+	// Mirrors receive a copy of each forwarded event
+	Mirrors []*url.URL
+	// InspectAddr is the address the inspector web UI is served on, it's
+	// disabled when empty
+	InspectAddr string
+	// InspectMaxBody is the size above which the inspector keeps bodies on
+	// disk and truncates them for display
+	InspectMaxBody int
+	Notify         bool
+	// HTTP2, IdleConnTimeout, ResponseHeaderTimeout and ConnectTimeout tune
+	// the requests to the local server
+	HTTP2                 bool
+	IdleConnTimeout       time.Duration
+	ResponseHeaderTimeout time.Duration
+	ConnectTimeout        time.Duration
+	// FailOn ends the session with an error when too many events fail, when
+	// set
+	FailOn *proxy.FailOn
+	// CircuitBreaker stops forwarding events while the local server is
+	// unreachable, when set
+	CircuitBreaker *proxy.CircuitBreaker
+	// Throttle limits the rate of the events forwarded, when set
+	Throttle *proxy.Throttle
+	// OnLocalError is what's reported to Hookdeck when an event can't be
+	// forwarded
+	OnLocalError proxy.OnLocalError
+	// MaxEvents and Timeout end the session after a number of events or a
+	// duration, unless 0
+	MaxEvents int
+	Timeout   time.Duration
+	// SummaryFile is where the summary of the session is written as JSON
+	// when it ends, if set
+	SummaryFile string
+	// Sinks receive a copy of each event, they're closed when the session
+	// ends. With NoForward, the events are only written to them.
+	Sinks     []proxy.Sink
+	NoForward bool
+	// Echo prints each event, along with NoForward
+	Echo bool
+	// Exec is a command run for each event instead of forwarding it
+	Exec string
+	// NoMetadataHeaders leaves out the delivery metadata headers of the
+	// forwarded requests
+	NoMetadataHeaders bool
 }
 
 // listenCmd represents the listen command
@@ -40,12 +102,18 @@ func Listen(URL *url.URL, sourceQuery string, connectionFilterString string, fla
 	var err error
 	var guestURL string
 
+	defer func() {
+		for _, sink := range flags.Sinks {
+			sink.Close()
+		}
+	}()
+
 	sourceAliases, err := parseSourceQuery(sourceQuery)
 	if err != nil {
 		return err
 	}
 
-	isMultiSource := len(sourceAliases) > 1 || (len(sourceAliases) == 1 && sourceAliases[0] == "*")
+	isMultiSource := len(sourceAliases) > 1 || (len(sourceAliases) == 1 && sourceAliases[0] == "*") || hasSourcePattern(sourceAliases)
 
 	if flags.Path != "" {
 		if isMultiSource {
@@ -66,6 +134,9 @@ func Listen(URL *url.URL, sourceQuery string, connectionFilterString string, fla
 		if guestURL == "" {
 			return err
 		}
+	} else {
+		// Keep showing the Console URL until the guest account is claimed
+		guestURL = config.Profile.GuestURL
 	}
 
 	sdkClient := config.GetClient()
@@ -77,16 +148,20 @@ func Listen(URL *url.URL, sourceQuery string, connectionFilterString string, fla
 		return err
 	}
 
-	connections, err := getConnections(sdkClient, sources, connectionFilterString, isMultiSource, flags.Path)
+	connections, err := getConnections(sdkClient, sources, connectionFilterString, isMultiSource, hasSourcePattern(sourceAliases), flags.Path)
 	if err != nil {
 		return err
 	}
 
 	if len(flags.Path) != 0 && len(connections) > 1 {
+		target := "--no-forward"
+		if URL != nil {
+			target = URL.String()
+		}
 		return errors.New(fmt.Errorf(`Multiple CLI destinations found. Cannot set the path on multiple destinations.
 Specify a single destination to update the path. For example, pass a connection name:
 			
-  hookdeck listen %s %s %s --path %s`, URL.String(), sources[0].Name, "<connection>", flags.Path).Error())
+  hookdeck listen %s %s %s --path %s`, target, sources[0].Name, "<connection>", flags.Path).Error())
 	}
 
 	// If the "--path" flag has been passed and the destination has a current cli path value but it's different, update destination path
@@ -116,37 +191,112 @@ Specify a single destination to update the path. For example, pass a connection
 		return err
 	}
 
-	// Start proxy
-	printListenMessage(config, isMultiSource)
-	fmt.Println()
-	printDashboardInformation(config, guestURL)
-	fmt.Println()
-	printSources(config, sources)
-	fmt.Println()
-	printConnections(config, connections)
-	fmt.Println()
+	// Start proxy. With JSON output, or a sink writing to stdout, stdout
+	// only contains the events so it can be piped to other tools.
+	out := io.Writer(os.Stdout)
+	if flags.Output == proxy.OutputJSON || proxy.HasStdoutSink(flags.Sinks) {
+		out = ansi.StatusWriter
+	}
+
+	printListenMessage(out, config, isMultiSource)
+	fmt.Fprintln(out)
+	printDashboardInformation(out, config, guestURL)
+	fmt.Fprintln(out)
+	printSources(out, config, sources, connections)
+	fmt.Fprintln(out)
+	printConnections(out, config, connections)
+	fmt.Fprintln(out)
+	if flags.NoAck {
+		fmt.Fprintln(out, ansi.Color(out).Yellow("Events are reported as not delivered to Hookdeck (--no-ack), so they'll be retried"))
+		fmt.Fprintln(out)
+	}
+	if flags.Exec != "" {
+		fmt.Fprintf(out, "Events are handled by running: %s\n", flags.Exec)
+		fmt.Fprintln(out)
+	} else if flags.Echo {
+		fmt.Fprintln(out, "Events are printed and acknowledged without being forwarded (--echo)")
+		fmt.Fprintln(out)
+	} else if flags.NoForward {
+		fmt.Fprintln(out, "Events are only written to the sinks (--no-forward)")
+		fmt.Fprintln(out)
+	}
+
+	var inspector *proxy.Inspector
+	if flags.InspectAddr != "" {
+		inspector = proxy.NewInspector(inspectorMaxEvents, flags.InspectMaxBody, config.Insecure)
+		defer inspector.Close()
+		go func() {
+			if err := inspector.ListenAndServe(flags.InspectAddr); err != nil {
+				log.Errorf("Failed to serve the inspector on %s: %v", flags.InspectAddr, err)
+			}
+		}()
+		fmt.Fprintf(out, "Inspect and replay events at http://%s\n\n", flags.InspectAddr)
+	}
 
 	p := proxy.New(&proxy.Config{
-		DeviceName:       config.DeviceName,
-		Key:              config.Profile.APIKey,
-		TeamID:           config.Profile.TeamID,
-		TeamMode:         config.Profile.TeamMode,
-		APIBaseURL:       config.APIBaseURL,
-		DashboardBaseURL: config.DashboardBaseURL,
-		ConsoleBaseURL:   config.ConsoleBaseURL,
-		WSBaseURL:        config.WSBaseURL,
-		NoWSS:            flags.NoWSS,
-		URL:              URL,
-		Log:              log.StandardLogger(),
-		Insecure:         config.Insecure,
+		DeviceName:            config.DeviceName,
+		Key:                   config.Profile.APIKey,
+		TeamID:                config.Profile.TeamID,
+		TeamMode:              config.Profile.TeamMode,
+		APIBaseURL:            config.APIBaseURL,
+		DashboardBaseURL:      config.DashboardBaseURL,
+		ConsoleBaseURL:        config.ConsoleBaseURL,
+		WSBaseURL:             config.WSBaseURL,
+		NoWSS:                 flags.NoWSS,
+		URL:                   URL,
+		Log:                   log.StandardLogger(),
+		Insecure:              config.Insecure,
+		SigningSecret:         flags.SigningSecret,
+		ResignSecret:          flags.ResignSecret,
+		Output:                flags.Output,
+		Filter:                flags.Filter,
+		RespondStatus:         flags.RespondStatus,
+		RespondBody:           flags.RespondBody,
+		NoAck:                 flags.NoAck,
+		Expect:                flags.Expect,
+		Mirrors:               flags.Mirrors,
+		Inspector:             inspector,
+		Notify:                flags.Notify,
+		HTTP2:                 flags.HTTP2,
+		IdleConnTimeout:       flags.IdleConnTimeout,
+		ResponseHeaderTimeout: flags.ResponseHeaderTimeout,
+		ConnectTimeout:        flags.ConnectTimeout,
+		FailOn:                flags.FailOn,
+		CircuitBreaker:        flags.CircuitBreaker,
+		Throttle:              flags.Throttle,
+		OnLocalError:          flags.OnLocalError,
+		MaxEvents:             flags.MaxEvents,
+		Timeout:               flags.Timeout,
+		Sinks:                 flags.Sinks,
+		NoForward:             flags.NoForward,
+		Echo:                  flags.Echo,
+		Exec:                  flags.Exec,
+		NoMetadataHeaders:     flags.NoMetadataHeaders,
 	}, connections)
 
 	err = p.Run(context.Background())
+
+	// The summary is reported whether the session ended normally or
+	// because of --fail-on
+	summary := p.Summary()
+	fmt.Fprintln(out)
+	summary.Print(out)
+	if flags.SummaryFile != "" {
+		if writeErr := writeSummary(flags.SummaryFile, summary); writeErr != nil && err == nil {
+			err = writeErr
+		}
+	}
+
+	return err
+}
+
+// writeSummary writes the summary of the session to a JSON file.
+func writeSummary(path string, summary proxy.Summary) error {
+	data, err := json.MarshalIndent(summary, "", "  ")
 	if err != nil {
 		return err
 	}
-
-	return nil
+	return ioutil.WriteFile(path, append(data, '\n'), 0644)
 }
 
 func parseSourceQuery(sourceQuery string) ([]string, error) {
@@ -2,27 +2,29 @@ package listen
 
 import (
 	"fmt"
+	"io"
 
 	"github.com/hookdeck/hookdeck-cli/pkg/ansi"
 	"github.com/hookdeck/hookdeck-cli/pkg/config"
 	hookdecksdk "github.com/hookdeck/hookdeck-go-sdk"
 )
 
-func printListenMessage(config *config.Config, isMultiSource bool) {
+func printListenMessage(out io.Writer, config *config.Config, isMultiSource bool) {
 	if !isMultiSource {
 		return
 	}
 
-	fmt.Println()
-	fmt.Println("Listening for events on Sources that have Connections with CLI Destinations")
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "Listening for events on Sources that have Connections with CLI Destinations")
 }
 
-func printDashboardInformation(config *config.Config, guestURL string) {
-	fmt.Println(ansi.Bold("Dashboard"))
+func printDashboardInformation(out io.Writer, config *config.Config, guestURL string) {
+	fmt.Fprintln(out, ansi.Bold("Dashboard"))
 	if guestURL != "" {
-		fmt.Println("👤 Console URL: " + guestURL)
-		fmt.Println("Sign up in the Console to make your webhook URL permanent.")
-		fmt.Println()
+		fmt.Fprintln(out, "👤 Console URL: "+guestURL)
+		fmt.Fprintln(out, "Sign up in the Console to make your webhook URL permanent.")
+		fmt.Fprintln(out, "You can also run `hookdeck session claim` to sign up later.")
+		fmt.Fprintln(out)
 	} else {
 		var url = config.DashboardBaseURL
 		if config.Profile.TeamID != "" {
@@ -31,21 +33,36 @@ func printDashboardInformation(config *config.Config, guestURL string) {
 		if config.Profile.TeamMode == "console" {
 			url = config.ConsoleBaseURL
 		}
-		fmt.Println("👉 Inspect and replay events: " + url)
+		fmt.Fprintln(out, "👉 Inspect and replay events: "+url)
 	}
 }
 
-func printSources(config *config.Config, sources []*hookdecksdk.Source) {
-	fmt.Println(ansi.Bold("Sources"))
+func printSources(out io.Writer, config *config.Config, sources []*hookdecksdk.Source, connections []*hookdecksdk.Connection) {
+	fmt.Fprintln(out, ansi.Bold("Sources"))
 
+	if len(sources) == 1 {
+		fmt.Fprintf(out, "🔌 %s URL: %s\n", sources[0].Name, sources[0].Url)
+		return
+	}
+
+	// With multiple sources, show how many connections each one forwards to
+	connectionCounts := map[string]int{}
+	for _, connection := range connections {
+		connectionCounts[connection.Source.Id]++
+	}
 	for _, source := range sources {
-		fmt.Printf("🔌 %s URL: %s\n", source.Name, source.Url)
+		count := connectionCounts[source.Id]
+		label := "connections"
+		if count == 1 {
+			label = "connection"
+		}
+		fmt.Fprintf(out, "🔌 %s URL: %s (%d %s)\n", source.Name, source.Url, count, label)
 	}
 }
 
-func printConnections(config *config.Config, connections []*hookdecksdk.Connection) {
-	fmt.Println(ansi.Bold("Connections"))
+func printConnections(out io.Writer, config *config.Config, connections []*hookdecksdk.Connection) {
+	fmt.Fprintln(out, ansi.Bold("Connections"))
 	for _, connection := range connections {
-		fmt.Println(*connection.FullName + " forwarding to " + *connection.Destination.CliPath)
+		fmt.Fprintln(out, *connection.FullName+" forwarding to "+*connection.Destination.CliPath)
 	}
 }
@@ -4,17 +4,22 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"path"
+	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/gosimple/slug"
+	"github.com/hookdeck/hookdeck-cli/pkg/hookdeck"
 	hookdecksdk "github.com/hookdeck/hookdeck-go-sdk"
 	hookdeckclient "github.com/hookdeck/hookdeck-go-sdk/client"
+	"golang.org/x/term"
 )
 
 // There are 4 cases:
 //
 // 1. search all sources (query string == '*')
-// 2. search multiple sources
+// 2. search multiple sources, or sources matching glob patterns
 // 3. search 1 source
 // 4. no specific source
 //
@@ -42,7 +47,7 @@ func getSources(sdkClient *hookdeckclient.Client, sourceQuery []string) ([]*hook
 		return validateSources(sources.Models)
 
 		// case 2
-	} else if len(sourceQuery) > 1 {
+	} else if len(sourceQuery) > 1 || hasSourcePattern(sourceQuery) {
 		searchedSources, err := listMultipleSources(sdkClient, sourceQuery)
 		if err != nil {
 			return []*hookdecksdk.Source{}, err
@@ -59,7 +64,22 @@ func getSources(sdkClient *hookdeckclient.Client, sourceQuery []string) ([]*hook
 			return validateSources(searchedSources)
 		}
 
-		// Create source with provided name
+		// Create source with provided name. When running interactively,
+		// confirm first since the name may just be a typo.
+		if isInteractive() {
+			create := false
+			err := survey.AskOne(&survey.Confirm{
+				Message: fmt.Sprintf("Source \"%s\" doesn't exist. Do you want to create it?", sourceQuery[0]),
+				Default: true,
+			}, &create)
+			if err != nil {
+				return []*hookdecksdk.Source{}, err
+			}
+			if !create {
+				return []*hookdecksdk.Source{}, fmt.Errorf("source \"%s\" not found", sourceQuery[0])
+			}
+		}
+
 		source, err := createSource(sdkClient, &sourceQuery[0])
 		if err != nil {
 			return []*hookdecksdk.Source{}, err
@@ -100,21 +120,100 @@ func getSources(sdkClient *hookdeckclient.Client, sourceQuery []string) ([]*hook
 }
 
 func listMultipleSources(sdkClient *hookdeckclient.Client, sourceQuery []string) ([]*hookdecksdk.Source, error) {
-	sources := []*hookdecksdk.Source{}
+	names := []string{}
+	patterns := []string{}
+	for _, alias := range sourceQuery {
+		if isSourcePattern(alias) {
+			patterns = append(patterns, alias)
+		} else {
+			names = append(names, alias)
+		}
+	}
 
-	for _, sourceName := range sourceQuery {
-		sourceQuery, err := sdkClient.Source.List(context.Background(), &hookdecksdk.SourceListRequest{
+	results, err := hookdeck.Batch(context.Background(), names, hookdeck.BatchOptions{}, func(ctx context.Context, sourceName string) (*hookdecksdk.SourcePaginatedResult, error) {
+		return sdkClient.Source.List(ctx, &hookdecksdk.SourceListRequest{
 			Name: &sourceName,
 		})
+	})
+	if err != nil {
+		return []*hookdecksdk.Source{}, err
+	}
+
+	sources := []*hookdecksdk.Source{}
+	for _, result := range results {
+		if len(result.Models) > 0 {
+			sources = append(sources, result.Models[0])
+		}
+	}
+
+	if len(patterns) > 0 {
+		matchedSources, err := matchSources(sdkClient, patterns)
+		if err != nil {
+			return []*hookdecksdk.Source{}, err
+		}
+		sources = append(sources, matchedSources...)
+	}
+
+	return uniqueSources(sources), nil
+}
+
+// matchSources returns the sources whose name matches one of the glob
+// patterns, listing all the sources of the project.
+func matchSources(sdkClient *hookdeckclient.Client, patterns []string) ([]*hookdecksdk.Source, error) {
+	limit := 255 // Hookdeck API limit
+	request := &hookdecksdk.SourceListRequest{Limit: &limit}
+
+	sources := []*hookdecksdk.Source{}
+	for {
+		availableSources, err := sdkClient.Source.List(context.Background(), request)
 		if err != nil {
 			return []*hookdecksdk.Source{}, err
 		}
-		if len(sourceQuery.Models) > 0 {
-			sources = append(sources, sourceQuery.Models[0])
+
+		for _, source := range availableSources.Models {
+			for _, pattern := range patterns {
+				if matched, _ := path.Match(pattern, source.Name); matched {
+					sources = append(sources, source)
+					break
+				}
+			}
+		}
+
+		pagination := availableSources.Pagination
+		if pagination == nil || pagination.Next == nil || *pagination.Next == "" {
+			return sources, nil
+		}
+		request.Next = pagination.Next
+	}
+}
+
+func uniqueSources(sources []*hookdecksdk.Source) []*hookdecksdk.Source {
+	seen := map[string]bool{}
+	unique := []*hookdecksdk.Source{}
+	for _, source := range sources {
+		if !seen[source.Id] {
+			seen[source.Id] = true
+			unique = append(unique, source)
 		}
 	}
+	return unique
+}
 
-	return sources, nil
+// isSourcePattern returns whether a source alias is a glob pattern, like
+// payments-*, rather than a source name.
+func isSourcePattern(alias string) bool {
+	return alias != "*" && strings.ContainsAny(alias, "*?[")
+}
+
+// hasSourcePattern returns whether one of the source aliases is a glob
+// pattern.
+func hasSourcePattern(sourceQuery []string) bool {
+	for _, alias := range sourceQuery {
+		if isSourcePattern(alias) {
+			return true
+		}
+	}
+	return false
 }
 
 func selectSources(availableSources []*hookdecksdk.Source) ([]*hookdecksdk.Source, error) {
@@ -180,13 +279,73 @@ func createSource(sdkClient *hookdeckclient.Client, name *string) (*hookdecksdk.
 		sourceName = answers.Label
 	}
 
-	source, err := sdkClient.Source.Create(context.Background(), &hookdecksdk.SourceCreateRequest{
+	request := &hookdecksdk.SourceCreateRequest{
 		Name: slug.Make(sourceName),
-	})
+	}
+
+	if isInteractive() {
+		verification, err := askSourceVerification()
+		if err != nil {
+			return nil, err
+		}
+		if verification != nil {
+			request.Verification = hookdecksdk.Optional(*verification)
+		}
+	}
+
+	source, err := sdkClient.Source.Create(context.Background(), request)
 
 	return source, err
 }
 
+// sourceTypes are the source types that can be selected when creating a
+// source from the CLI. Other types can be configured in the dashboard.
+var sourceTypes = []string{"Generic (no verification)", "Stripe", "GitHub", "Shopify"}
+
+// askSourceVerification asks for the type of the new source and its
+// webhook secret, so that Hookdeck can verify the incoming requests.
+func askSourceVerification() (*hookdecksdk.VerificationConfig, error) {
+	sourceType := sourceTypes[0]
+	err := survey.AskOne(&survey.Select{
+		Message: "What type of source is it?",
+		Options: sourceTypes,
+	}, &sourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	if sourceType == sourceTypes[0] {
+		return nil, nil
+	}
+
+	secret := ""
+	err = survey.AskOne(&survey.Password{
+		Message: fmt.Sprintf("What is your %s webhook signing secret?", sourceType),
+	}, &secret, survey.WithValidator(survey.Required))
+	if err != nil {
+		return nil, err
+	}
+
+	switch sourceType {
+	case "Stripe":
+		return hookdecksdk.NewVerificationConfigFromStripe(&hookdecksdk.VerificationStripe{
+			Configs: &hookdecksdk.VerificationStripeConfigs{WebhookSecretKey: secret},
+		}), nil
+	case "GitHub":
+		return hookdecksdk.NewVerificationConfigFromGithub(&hookdecksdk.VerificationGitHub{
+			Configs: &hookdecksdk.VerificationGitHubConfigs{WebhookSecretKey: secret},
+		}), nil
+	default:
+		return hookdecksdk.NewVerificationConfigFromShopify(&hookdecksdk.VerificationShopify{
+			Configs: &hookdecksdk.VerificationShopifyConfigs{WebhookSecretKey: secret},
+		}), nil
+	}
+}
+
+func isInteractive() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
+
 func validateSources(sources []*hookdecksdk.Source) ([]*hookdecksdk.Source, error) {
 	if len(sources) == 0 {
 		return []*hookdecksdk.Source{}, errors.New("unable to find any matching sources")
@@ -0,0 +1,55 @@
+package listen
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	hookdecksdk "github.com/hookdeck/hookdeck-go-sdk"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/hookdeck"
+)
+
+func TestMatchSourcesPaginates(t *testing.T) {
+	// 600 sources, listed 255 at a time
+	sources := []*hookdecksdk.Source{}
+	for i := 0; i < 600; i++ {
+		name := fmt.Sprintf("other-%d", i)
+		if i%250 == 0 {
+			name = fmt.Sprintf("payments-%d", i)
+		}
+		sources = append(sources, &hookdecksdk.Source{Id: fmt.Sprintf("src_%d", i), Name: name})
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.True(t, strings.HasSuffix(r.URL.Path, "/sources"), r.URL.Path)
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		start, _ := strconv.Atoi(r.URL.Query().Get("next"))
+		end := start + limit
+		result := &hookdecksdk.SourcePaginatedResult{Pagination: &hookdecksdk.SeekPagination{}}
+		if end < len(sources) {
+			next := strconv.Itoa(end)
+			result.Pagination.Next = &next
+		} else {
+			end = len(sources)
+		}
+		result.Models = sources[start:end]
+		json.NewEncoder(w).Encode(result)
+	}))
+	defer server.Close()
+
+	client := hookdeck.CreateSDKClient(hookdeck.SDKClientInit{APIBaseURL: server.URL, APIKey: "key"})
+	matched, err := matchSources(client, []string{"payments-*"})
+	require.NoError(t, err)
+
+	names := []string{}
+	for _, source := range matched {
+		names = append(names, source.Name)
+	}
+	require.Equal(t, []string{"payments-0", "payments-250", "payments-500"}, names)
+}
@@ -0,0 +1,173 @@
+// Package logfile writes logs to a file rotated by size and age, so that
+// long-running listeners keep their recent history without filling disks.
+package logfile
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// backupTimeFormat is the format of the timestamp in the name of rotated
+// files, e.g. hookdeck-2024-05-01T10-00-00.000.log. It sorts
+// chronologically.
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+// Writer appends to a log file, rotating it once it exceeds MaxSize bytes
+// or was started more than MaxAge ago. The rotated files are renamed with
+// their rotation time and only the MaxBackups most recent are kept.
+type Writer struct {
+	Path string
+	// MaxSize is the size rotating the file, 0 to not rotate by size
+	MaxSize int64
+	// MaxAge is the age rotating the file, 0 to not rotate by age
+	MaxAge time.Duration
+	// MaxBackups is the number of rotated files kept, 0 to keep them all
+	MaxBackups int
+
+	mu      sync.Mutex
+	file    *os.File
+	size    int64
+	started time.Time
+	now     func() time.Time
+}
+
+// Open opens the log file, creating it and its folder if needed.
+func Open(path string, maxSize int64, maxAge time.Duration, maxBackups int) (*Writer, error) {
+	w := &Writer{
+		Path:       path,
+		MaxSize:    maxSize,
+		MaxAge:     maxAge,
+		MaxBackups: maxBackups,
+		now:        time.Now,
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Write writes p to the log file, rotating it first if needed.
+func (w *Writer) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		return 0, os.ErrClosed
+	}
+	if w.shouldRotate(int64(len(p))) {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+// Close closes the log file.
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		return nil
+	}
+	err := w.file.Close()
+	w.file = nil
+	return err
+}
+
+func (w *Writer) shouldRotate(size int64) bool {
+	if w.size == 0 {
+		return false
+	}
+	if w.MaxSize > 0 && w.size+size > w.MaxSize {
+		return true
+	}
+	return w.MaxAge > 0 && w.now().Sub(w.started) >= w.MaxAge
+}
+
+// open opens the log file for appending. The age of an existing file is
+// counted from its last change, the time it was started being unknown.
+func (w *Writer) open() error {
+	file, err := os.OpenFile(w.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	w.file = file
+	w.size = info.Size()
+	w.started = w.now()
+	if w.size > 0 {
+		w.started = info.ModTime()
+	}
+	return nil
+}
+
+func (w *Writer) rotate() error {
+	if err := w.file.Close(); err != nil {
+		return err
+	}
+	w.file = nil
+
+	ext := filepath.Ext(w.Path)
+	backup := fmt.Sprintf("%s-%s%s", strings.TrimSuffix(w.Path, ext), w.now().Format(backupTimeFormat), ext)
+	if err := os.Rename(w.Path, backup); err != nil {
+		return err
+	}
+	if err := w.open(); err != nil {
+		return err
+	}
+	return w.removeOldBackups()
+}
+
+// Backups returns the rotated files of the log file, oldest first.
+func (w *Writer) Backups() ([]string, error) {
+	ext := filepath.Ext(w.Path)
+	prefix := strings.TrimSuffix(w.Path, ext) + "-"
+	matches, err := filepath.Glob(prefix + "*" + ext)
+	if err != nil {
+		return nil, err
+	}
+
+	backups := []string{}
+	for _, match := range matches {
+		timestamp := strings.TrimSuffix(strings.TrimPrefix(match, prefix), ext)
+		if _, err := time.Parse(backupTimeFormat, timestamp); err == nil {
+			backups = append(backups, match)
+		}
+	}
+	sort.Strings(backups)
+	return backups, nil
+}
+
+func (w *Writer) removeOldBackups() error {
+	if w.MaxBackups <= 0 {
+		return nil
+	}
+	backups, err := w.Backups()
+	if err != nil {
+		return err
+	}
+	for len(backups) > w.MaxBackups {
+		if err := os.Remove(backups[0]); err != nil {
+			return err
+		}
+		backups = backups[1:]
+	}
+	return nil
+}
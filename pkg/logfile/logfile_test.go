@@ -0,0 +1,71 @@
+package logfile
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriterRotatesBySize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "hookdeck.log")
+	w, err := Open(path, 10, 0, 2)
+	require.NoError(t, err)
+	defer w.Close()
+
+	clock := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	w.now = func() time.Time {
+		clock = clock.Add(time.Second)
+		return clock
+	}
+
+	for _, line := range []string{"first\n", "second\n", "third\n", "fourth\n"} {
+		_, err := w.Write([]byte(line))
+		require.NoError(t, err)
+	}
+
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "fourth\n", string(data))
+
+	// The oldest backup, of the first line, is removed
+	backups, err := w.Backups()
+	require.NoError(t, err)
+	require.Len(t, backups, 2)
+	data, err = ioutil.ReadFile(backups[0])
+	require.NoError(t, err)
+	require.Equal(t, "second\n", string(data))
+}
+
+func TestWriterRotatesByAge(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hookdeck.log")
+	w, err := Open(path, 0, time.Hour, 0)
+	require.NoError(t, err)
+	defer w.Close()
+
+	clock := w.started
+	w.now = func() time.Time { return clock }
+
+	_, err = w.Write([]byte("first\n"))
+	require.NoError(t, err)
+	clock = clock.Add(30 * time.Minute)
+	_, err = w.Write([]byte("second\n"))
+	require.NoError(t, err)
+
+	backups, err := w.Backups()
+	require.NoError(t, err)
+	require.Len(t, backups, 0)
+
+	clock = clock.Add(30 * time.Minute)
+	_, err = w.Write([]byte("third\n"))
+	require.NoError(t, err)
+
+	backups, err = w.Backups()
+	require.NoError(t, err)
+	require.Len(t, backups, 1)
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "third\n", string(data))
+}
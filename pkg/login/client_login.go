@@ -42,14 +42,14 @@ func Login(config *config.Config, input io.Reader) error {
 			"APIKey": config.Profile.APIKey,
 		}).Debug("Logging in with API key")
 
-		s = ansi.StartNewSpinner("Verifying credentials...", os.Stdout)
+		s = ansi.StartNewSpinner("Verifying credentials...", ansi.StatusWriter)
 		response, err := ValidateKey(config.APIBaseURL, config.Profile.APIKey, config.Profile.TeamID)
 		if err != nil {
 			return err
 		}
 
 		message := SuccessMessage(response.UserName, response.UserEmail, response.OrganizationName, response.TeamName, response.TeamMode == "console")
-		ansi.StopSpinner(s, message, os.Stdout)
+		ansi.StopSpinner(s, message, ansi.StatusWriter)
 
 		if err = config.Profile.SaveProfile(false); err != nil {
 			return err
@@ -69,18 +69,18 @@ func Login(config *config.Config, input io.Reader) error {
 	if isSSH() || !canOpenBrowser() {
 		fmt.Printf("To authenticate with Hookdeck, please go to: %s\n", links.BrowserURL)
 
-		s = ansi.StartNewSpinner("Waiting for confirmation...", os.Stdout)
+		s = ansi.StartNewSpinner("Waiting for confirmation...", ansi.StatusWriter)
 	} else {
 		fmt.Printf("Press Enter to open the browser (^C to quit)")
 		fmt.Fscanln(input)
 
-		s = ansi.StartNewSpinner("Waiting for confirmation...", os.Stdout)
+		s = ansi.StartNewSpinner("Waiting for confirmation...", ansi.StatusWriter)
 
 		err = openBrowser(links.BrowserURL)
 		if err != nil {
 			msg := fmt.Sprintf("Failed to open browser, please go to %s manually.", links.BrowserURL)
-			ansi.StopSpinner(s, msg, os.Stdout)
-			s = ansi.StartNewSpinner("Waiting for confirmation...", os.Stdout)
+			ansi.StopSpinner(s, msg, ansi.StatusWriter)
+			s = ansi.StartNewSpinner("Waiting for confirmation...", ansi.StatusWriter)
 		}
 	}
 
@@ -98,6 +98,7 @@ func Login(config *config.Config, input io.Reader) error {
 	config.Profile.APIKey = response.APIKey
 	config.Profile.TeamID = response.TeamID
 	config.Profile.TeamMode = response.TeamMode
+	config.Profile.GuestURL = ""
 
 	if err = config.Profile.SaveProfile(false); err != nil {
 		return err
@@ -107,7 +108,7 @@ func Login(config *config.Config, input io.Reader) error {
 	}
 
 	message := SuccessMessage(response.UserName, response.UserEmail, response.OrganizationName, response.TeamName, response.TeamMode == "console")
-	ansi.StopSpinner(s, message, os.Stdout)
+	ansi.StopSpinner(s, message, ansi.StatusWriter)
 
 	return nil
 }
@@ -119,12 +120,14 @@ func GuestLogin(config *config.Config) (string, error) {
 	}
 
 	client := &hookdeck.Client{
-		BaseURL: parsedBaseURL,
+		BaseURL:    parsedBaseURL,
+		MaxRetries: config.MaxRetries,
+		Verbosity:  config.Verbosity,
 	}
 
 	fmt.Println("🚩 Not connected with any account. Creating a guest account...")
 
-	guest_user, err := client.CreateGuestUser(hookdeck.CreateGuestUserInput{
+	guest_user, err := client.CreateGuestUser(context.Background(), hookdeck.CreateGuestUserInput{
 		DeviceName: config.DeviceName,
 	})
 	if err != nil {
@@ -144,6 +147,7 @@ func GuestLogin(config *config.Config) (string, error) {
 	config.Profile.APIKey = response.APIKey
 	config.Profile.TeamID = response.TeamID
 	config.Profile.TeamMode = response.TeamMode
+	config.Profile.GuestURL = guest_user.Url
 
 	if err = config.Profile.SaveProfile(false); err != nil {
 		return "", err
@@ -162,15 +166,17 @@ func CILogin(config *config.Config, apiKey string, name string) error {
 	}
 
 	client := &hookdeck.Client{
-		BaseURL: parsedBaseURL,
-		APIKey:  apiKey,
+		BaseURL:    parsedBaseURL,
+		APIKey:     apiKey,
+		MaxRetries: config.MaxRetries,
+		Verbosity:  config.Verbosity,
 	}
 
 	deviceName := name
 	if deviceName == "" {
 		deviceName = config.DeviceName
 	}
-	response, err := client.CreateCIClient(hookdeck.CreateCIClientInput{
+	response, err := client.CreateCIClient(context.Background(), hookdeck.CreateCIClientInput{
 		DeviceName: deviceName,
 	})
 	if err != nil {
@@ -184,6 +190,7 @@ func CILogin(config *config.Config, apiKey string, name string) error {
 	config.Profile.APIKey = response.APIKey
 	config.Profile.TeamID = response.TeamID
 	config.Profile.TeamMode = response.TeamMode
+	config.Profile.GuestURL = ""
 
 	if err = config.Profile.SaveProfile(false); err != nil {
 		return err
@@ -30,7 +30,7 @@ func InteractiveLogin(config *config.Config) error {
 
 	config.DeviceName = getConfigureDeviceName(os.Stdin)
 
-	s := ansi.StartNewSpinner("Waiting for confirmation...", os.Stdout)
+	s := ansi.StartNewSpinner("Waiting for confirmation...", ansi.StatusWriter)
 
 	// Call poll function
 	response, err := PollForKey(config.APIBaseURL+"/cli-auth/poll?key="+apiKey, 0, 0)
@@ -64,20 +64,21 @@ func InteractiveLogin(config *config.Config) error {
 
 	config.Profile.APIKey = response.APIKey
 	config.Profile.TeamMode = response.TeamMode
+	config.Profile.GuestURL = ""
 	config.Profile.TeamID = response.TeamID
 
 	if err = config.Profile.SaveProfile(false); err != nil {
-		ansi.StopSpinner(s, "", os.Stdout)
+		ansi.StopSpinner(s, "", ansi.StatusWriter)
 		return err
 	}
 	if err = config.Profile.UseProfile(); err != nil {
-		ansi.StopSpinner(s, "", os.Stdout)
+		ansi.StopSpinner(s, "", ansi.StatusWriter)
 		return err
 	}
 
 	message := SuccessMessage(response.UserName, response.UserEmail, response.OrganizationName, response.TeamName, response.TeamMode == "console")
 
-	ansi.StopSpinner(s, message, os.Stdout)
+	ansi.StopSpinner(s, message, ansi.StatusWriter)
 
 	return nil
 }
@@ -167,7 +168,7 @@ func protectTerminalState() (chan os.Signal, error) {
 		return nil, err
 	}
 
-	signalChan := make(chan os.Signal)
+	signalChan := make(chan os.Signal, 1)
 	signal.Notify(signalChan, os.Interrupt)
 
 	go func() {
@@ -21,6 +21,9 @@ func Logout(config *config.Config) error {
 	if err := config.Profile.RemoveProfile(); err != nil {
 		return err
 	}
+	if err := config.GetCache().Clear(); err != nil {
+		return err
+	}
 
 	color := ansi.Color(os.Stdout)
 	if profileName == "default" {
@@ -40,6 +43,9 @@ func All(cfg *config.Config) error {
 	if err != nil {
 		return err
 	}
+	if err := cfg.GetCache().Clear(); err != nil {
+		return err
+	}
 
 	fmt.Println("Credentials have been cleared for all projects.")
 
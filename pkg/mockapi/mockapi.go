@@ -0,0 +1,639 @@
+// Package mockapi implements an in-memory version of the subset of the
+// Hookdeck API used by the CLI. It lets tests and demos run the CLI without
+// a Hookdeck account, by pointing the CLI to it with HOOKDECK_MOCK_SERVER.
+package mockapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/hookdeck"
+	hookdecksdk "github.com/hookdeck/hookdeck-go-sdk"
+	log "github.com/sirupsen/logrus"
+)
+
+// EnvVar is the environment variable used to point the CLI to a mock server.
+const EnvVar = "HOOKDECK_MOCK_SERVER"
+
+// APIKey is the key returned by the mock server when logging in. Any key is
+// accepted by the mock server.
+const APIKey = "mock_api_key"
+
+// Project is the project the mock server starts with, and the one used by
+// the mock credentials.
+var Project = hookdeck.Project{
+	Id:             "tm_mock",
+	Name:           "Mock Project",
+	Mode:           "inbound",
+	OrganizationId: Organization.Id,
+}
+
+// Organization is the only organization available on the mock server.
+var Organization = hookdeck.Organization{
+	Id:   "org_mock",
+	Name: "Mock Organization",
+}
+
+// Server is an in-memory mock of the Hookdeck API.
+type Server struct {
+	mu           sync.Mutex
+	counter      int
+	sources      []*hookdecksdk.Source
+	connections  []*hookdecksdk.Connection
+	destinations []*hookdecksdk.Destination
+	requests     []*hookdecksdk.Request
+	bodies       map[string]string
+	projects     []hookdeck.Project
+	mux          *http.ServeMux
+}
+
+// New creates a mock server with no resources.
+func New() *Server {
+	s := &Server{
+		bodies:   map[string]string{},
+		projects: []hookdeck.Project{Project},
+	}
+
+	s.mux = http.NewServeMux()
+	s.mux.HandleFunc("/teams", s.handleTeams)
+	s.mux.HandleFunc("/teams/", s.handleTeam)
+	s.mux.HandleFunc("/organizations", s.handleOrganizations)
+	s.mux.HandleFunc("/cli-auth/validate", s.handleValidate)
+	s.mux.HandleFunc("/cli-auth/ci", s.handleLogin)
+	s.mux.HandleFunc("/cli/guest", s.handleLogin)
+	s.mux.HandleFunc("/cli-sessions", s.handleSessions)
+	s.mux.HandleFunc("/e/", s.handleEvent)
+
+	// Endpoints used through the Go SDK are versioned
+	s.mux.HandleFunc("/sources", s.handleSources)
+	s.mux.HandleFunc("/sources/", s.handleSource)
+	s.mux.HandleFunc("/connections", s.handleConnections)
+	s.mux.HandleFunc("/connections/", s.handleConnection)
+	s.mux.HandleFunc("/destinations", s.handleDestinations)
+	s.mux.HandleFunc("/destinations/", s.handleDestination)
+	s.mux.HandleFunc("/requests", s.handleRequests)
+	s.mux.HandleFunc("/requests/", s.handleRequestBody)
+
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	log.WithFields(log.Fields{
+		"prefix": "mockapi.Server.ServeHTTP",
+	}).Debugf("%s %s", r.Method, r.URL.String())
+
+	// Strip the API version, e.g. /2024-03-01/sources
+	parts := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/"), "/", 2)
+	if len(parts) == 2 && len(parts[0]) == len("2006-01-02") && parts[0][0] >= '0' && parts[0][0] <= '9' {
+		r.URL.Path = "/" + parts[1]
+	}
+
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) handleTeams(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, s.projects)
+
+	case http.MethodPost:
+		input := struct {
+			Name           string `json:"name"`
+			OrganizationId string `json:"organization_id"`
+		}{}
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil || input.Name == "" {
+			writeError(w, http.StatusUnprocessableEntity, "\"name\" is required")
+			return
+		}
+		if input.OrganizationId != "" && input.OrganizationId != Organization.Id {
+			writeError(w, http.StatusNotFound, "Organization not found")
+			return
+		}
+
+		project := hookdeck.Project{
+			Id:             s.nextID("tm"),
+			Name:           input.Name,
+			Mode:           "inbound",
+			OrganizationId: Organization.Id,
+		}
+		s.projects = append(s.projects, project)
+
+		writeJSON(w, http.StatusOK, project)
+
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (s *Server) handleTeam(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := strings.TrimPrefix(r.URL.Path, "/teams/")
+	index := -1
+	for i, project := range s.projects {
+		if project.Id == id {
+			index = i
+		}
+	}
+	if index < 0 {
+		writeError(w, http.StatusNotFound, "Project not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		input := struct {
+			Name string `json:"name"`
+		}{}
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil || input.Name == "" {
+			writeError(w, http.StatusUnprocessableEntity, "\"name\" is required")
+			return
+		}
+		s.projects[index].Name = input.Name
+
+		writeJSON(w, http.StatusOK, s.projects[index])
+
+	case http.MethodDelete:
+		project := s.projects[index]
+		s.projects = append(s.projects[:index], s.projects[index+1:]...)
+
+		writeJSON(w, http.StatusOK, project)
+
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (s *Server) handleOrganizations(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, []hookdeck.Organization{Organization})
+}
+
+func (s *Server) handleValidate(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{
+		"user_id":           "usr_mock",
+		"user_name":         "Mock User",
+		"user_email":        "mock@example.com",
+		"organization_name": Organization.Name,
+		"organization_id":   Organization.Id,
+		"team_id":           Project.Id,
+		"team_name_no_org":  Project.Name,
+		"team_mode":         Project.Mode,
+		"client_id":         "cl_mock",
+	})
+}
+
+func (s *Server) handleLogin(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"claimed":           true,
+		"user_id":           "usr_mock",
+		"user_name":         "Mock User",
+		"organization_name": "Mock Organization",
+		"organization_id":   "org_mock",
+		"team_id":           Project.Id,
+		"team_name":         Project.Name,
+		"team_mode":         Project.Mode,
+		"key":               APIKey,
+		"client_id":         "cl_mock",
+		"id":                "usr_mock",
+		"link":              "http://" + r.Host,
+		"browser_url":       "http://" + r.Host,
+	})
+}
+
+func (s *Server) handleSessions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	s.mu.Lock()
+	id := s.nextID("ses")
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, map[string]string{"id": id})
+}
+
+func (s *Server) handleEvent(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, "/e/")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	source := s.findSource(func(source *hookdecksdk.Source) bool { return source.Id == id })
+	if source == nil {
+		writeError(w, http.StatusNotFound, "Source not found")
+		return
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	now := time.Now()
+	request := &hookdecksdk.Request{
+		Id:        s.nextID("req"),
+		TeamId:    Project.Id,
+		SourceId:  source.Id,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	// Most recent requests first, like the API
+	s.requests = append([]*hookdecksdk.Request{request}, s.requests...)
+	s.bodies[request.Id] = string(body)
+
+	writeJSON(w, http.StatusOK, map[string]string{
+		"status":  "SUCCESS",
+		"message": "Request handled by the Hookdeck mock server",
+	})
+}
+
+func (s *Server) handleRequests(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	query := r.URL.Query()
+	sourceIDs := query["source_id"]
+	limit, err := strconv.Atoi(query.Get("limit"))
+	if err != nil {
+		limit = 100
+	}
+
+	requests := []*hookdecksdk.Request{}
+	for _, request := range s.requests {
+		if len(requests) == limit {
+			break
+		}
+		if len(sourceIDs) == 0 || contains(sourceIDs, request.SourceId) {
+			requests = append(requests, request)
+		}
+	}
+	count := len(requests)
+	writeJSON(w, http.StatusOK, &hookdecksdk.RequestPaginatedResult{
+		Count:  &count,
+		Models: requests,
+	})
+}
+
+func (s *Server) handleRequestBody(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/requests/"), "/raw_body")
+
+	s.mu.Lock()
+	body, ok := s.bodies[id]
+	s.mu.Unlock()
+
+	if !ok {
+		writeError(w, http.StatusNotFound, "Request not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, &hookdecksdk.RawBody{Body: body})
+}
+
+func (s *Server) handleSources(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodGet:
+		names := r.URL.Query()["name"]
+		sources := []*hookdecksdk.Source{}
+		for _, source := range s.sources {
+			if len(names) == 0 || contains(names, source.Name) {
+				sources = append(sources, source)
+			}
+		}
+		count := len(sources)
+		writeJSON(w, http.StatusOK, &hookdecksdk.SourcePaginatedResult{
+			Count:  &count,
+			Models: sources,
+		})
+
+	case http.MethodPost:
+		input := struct {
+			Name string `json:"name"`
+		}{}
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil || input.Name == "" {
+			writeError(w, http.StatusUnprocessableEntity, "\"name\" is required")
+			return
+		}
+		if s.findSource(func(source *hookdecksdk.Source) bool { return source.Name == input.Name }) != nil {
+			writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("A source named \"%s\" already exists", input.Name))
+			return
+		}
+		writeJSON(w, http.StatusOK, s.createSource(r, input.Name))
+
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (s *Server) handleSource(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, "/sources/")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	source := s.findSource(func(source *hookdecksdk.Source) bool { return source.Id == id })
+	if source == nil {
+		writeError(w, http.StatusNotFound, "Source not found")
+		return
+	}
+
+	if r.Method == http.MethodPut {
+		input := struct {
+			Name *string `json:"name"`
+		}{}
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+		if input.Name != nil && *input.Name != source.Name {
+			if s.findSource(func(other *hookdecksdk.Source) bool { return other.Name == *input.Name }) != nil {
+				writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("A source named \"%s\" already exists", *input.Name))
+				return
+			}
+			// The full names of the connections start with the source name
+			for _, connection := range s.connections {
+				if connection.Source.Id == source.Id && connection.FullName != nil {
+					fullName := *input.Name + strings.TrimPrefix(*connection.FullName, source.Name)
+					connection.FullName = &fullName
+				}
+			}
+			source.Name = *input.Name
+			source.UpdatedAt = time.Now().UTC()
+		}
+	}
+
+	writeJSON(w, http.StatusOK, source)
+}
+
+func (s *Server) handleConnections(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodGet:
+		query := r.URL.Query()
+		connections := []*hookdecksdk.Connection{}
+		for _, connection := range s.connections {
+			if len(query["source_id"]) > 0 && !contains(query["source_id"], connection.Source.Id) {
+				continue
+			}
+			if query.Get("name") != "" && (connection.Name == nil || *connection.Name != query.Get("name")) {
+				continue
+			}
+			if query.Get("full_name") != "" && *connection.FullName != query.Get("full_name") {
+				continue
+			}
+			connections = append(connections, connection)
+		}
+		count := len(connections)
+		writeJSON(w, http.StatusOK, &hookdecksdk.ConnectionPaginatedResult{
+			Count:  &count,
+			Models: connections,
+		})
+
+	case http.MethodPost:
+		input := struct {
+			Name     *string `json:"name"`
+			SourceId *string `json:"source_id"`
+			Source   *struct {
+				Name string `json:"name"`
+			} `json:"source"`
+			Destination *struct {
+				Name    string  `json:"name"`
+				Url     *string `json:"url"`
+				CliPath *string `json:"cli_path"`
+			} `json:"destination"`
+		}{}
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		var source *hookdecksdk.Source
+		if input.SourceId != nil {
+			source = s.findSource(func(source *hookdecksdk.Source) bool { return source.Id == *input.SourceId })
+		} else if input.Source != nil {
+			source = s.createSource(r, input.Source.Name)
+		}
+		if source == nil {
+			writeError(w, http.StatusUnprocessableEntity, "\"source_id\" or \"source\" is required")
+			return
+		}
+		if input.Destination == nil || input.Destination.Name == "" {
+			writeError(w, http.StatusUnprocessableEntity, "\"destination\" is required")
+			return
+		}
+
+		now := time.Now().UTC()
+		destination := &hookdecksdk.Destination{
+			Id:        s.nextID("des"),
+			Name:      input.Destination.Name,
+			TeamId:    Project.Id,
+			Url:       input.Destination.Url,
+			CliPath:   input.Destination.CliPath,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		s.destinations = append(s.destinations, destination)
+
+		fullName := source.Name + " -> " + destination.Name
+		connection := &hookdecksdk.Connection{
+			Id:          s.nextID("web"),
+			Name:        input.Name,
+			FullName:    &fullName,
+			TeamId:      Project.Id,
+			Source:      source,
+			Destination: destination,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+		s.connections = append(s.connections, connection)
+
+		writeJSON(w, http.StatusOK, connection)
+
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (s *Server) handleConnection(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, "/connections/")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var connection *hookdecksdk.Connection
+	for _, c := range s.connections {
+		if c.Id == id {
+			connection = c
+		}
+	}
+	if connection == nil {
+		writeError(w, http.StatusNotFound, "Connection not found")
+		return
+	}
+
+	if r.Method == http.MethodPut {
+		input := struct {
+			Name        *string             `json:"name"`
+			Description *string             `json:"description"`
+			Rules       []*hookdecksdk.Rule `json:"rules"`
+		}{}
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+		if input.Name != nil {
+			connection.Name = input.Name
+		}
+		if input.Description != nil {
+			connection.Description = input.Description
+		}
+		if input.Rules != nil {
+			connection.Rules = input.Rules
+		}
+		connection.UpdatedAt = time.Now().UTC()
+	}
+
+	writeJSON(w, http.StatusOK, connection)
+}
+
+func (s *Server) handleDestinations(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	names := r.URL.Query()["name"]
+	destinations := []*hookdecksdk.Destination{}
+	for _, destination := range s.destinations {
+		if len(names) == 0 || contains(names, destination.Name) {
+			destinations = append(destinations, destination)
+		}
+	}
+	count := len(destinations)
+	writeJSON(w, http.StatusOK, &hookdecksdk.DestinationPaginatedResult{
+		Count:  &count,
+		Models: destinations,
+	})
+}
+
+func (s *Server) handleDestination(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, "/destinations/")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var destination *hookdecksdk.Destination
+	for _, d := range s.destinations {
+		if d.Id == id {
+			destination = d
+		}
+	}
+	if destination == nil {
+		writeError(w, http.StatusNotFound, "Destination not found")
+		return
+	}
+
+	if r.Method == http.MethodPut {
+		input := struct {
+			Name       *string                                  `json:"name"`
+			Url        *string                                  `json:"url"`
+			CliPath    *string                                  `json:"cli_path"`
+			AuthMethod *hookdecksdk.DestinationAuthMethodConfig `json:"auth_method"`
+		}{}
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+		if input.Name != nil {
+			destination.Name = *input.Name
+		}
+		if input.Url != nil {
+			destination.Url = input.Url
+		}
+		if input.CliPath != nil {
+			destination.CliPath = input.CliPath
+		}
+		if input.AuthMethod != nil {
+			destination.AuthMethod = input.AuthMethod
+		}
+		destination.UpdatedAt = time.Now().UTC()
+	}
+
+	writeJSON(w, http.StatusOK, destination)
+}
+
+func (s *Server) createSource(r *http.Request, name string) *hookdecksdk.Source {
+	now := time.Now().UTC()
+	id := s.nextID("src")
+	source := &hookdecksdk.Source{
+		Id:        id,
+		Name:      name,
+		TeamId:    Project.Id,
+		Url:       "http://" + r.Host + "/e/" + id,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	s.sources = append(s.sources, source)
+
+	return source
+}
+
+func (s *Server) findSource(match func(*hookdecksdk.Source) bool) *hookdecksdk.Source {
+	for _, source := range s.sources {
+		if match(source) {
+			return source
+		}
+	}
+	return nil
+}
+
+func (s *Server) nextID(prefix string) string {
+	s.counter++
+	return fmt.Sprintf("%s_mock%06d", prefix, s.counter)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]interface{}{
+		"code":    strings.ToUpper(strings.ReplaceAll(http.StatusText(status), " ", "_")),
+		"status":  status,
+		"message": message,
+	})
+}
@@ -0,0 +1,110 @@
+package mockapi
+
+import (
+	"context"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/hookdeck"
+	hookdecksdk "github.com/hookdeck/hookdeck-go-sdk"
+)
+
+func TestServer_SDK(t *testing.T) {
+	ts := httptest.NewServer(New())
+	defer ts.Close()
+
+	client := hookdeck.CreateSDKClient(hookdeck.SDKClientInit{
+		APIBaseURL: ts.URL,
+		APIKey:     APIKey,
+	})
+
+	name := "stripe"
+	source, err := client.Source.Create(context.Background(), &hookdecksdk.SourceCreateRequest{Name: name})
+	require.NoError(t, err)
+	require.Equal(t, ts.URL+"/e/"+source.Id, source.Url)
+
+	sources, err := client.Source.List(context.Background(), &hookdecksdk.SourceListRequest{Name: &name})
+	require.NoError(t, err)
+	require.Equal(t, 1, *sources.Count)
+
+	connectionName := "stripe_to_cli"
+	path := "/webhooks"
+	connection, err := client.Connection.Create(context.Background(), &hookdecksdk.ConnectionCreateRequest{
+		Name:     hookdecksdk.OptionalOrNull(&connectionName),
+		SourceId: hookdecksdk.OptionalOrNull(&source.Id),
+		Destination: hookdecksdk.OptionalOrNull(&hookdecksdk.ConnectionCreateRequestDestination{
+			Name:    "cli-stripe",
+			CliPath: &path,
+		}),
+	})
+	require.NoError(t, err)
+	require.Equal(t, source.Id, connection.Source.Id)
+
+	connections, err := client.Connection.List(context.Background(), &hookdecksdk.ConnectionListRequest{
+		SourceId: []*string{&source.Id},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, *connections.Count)
+	require.Equal(t, path, *connections.Models[0].Destination.CliPath)
+
+	_, err = client.Source.Create(context.Background(), &hookdecksdk.SourceCreateRequest{Name: name})
+	apiErr, ok := hookdeck.AsAPIError(err)
+	require.True(t, ok)
+	require.True(t, apiErr.IsValidationError())
+}
+
+func TestServer_Client(t *testing.T) {
+	ts := httptest.NewServer(New())
+	defer ts.Close()
+
+	baseURL, _ := url.Parse(ts.URL)
+	client := &hookdeck.Client{
+		BaseURL: baseURL,
+		APIKey:  APIKey,
+	}
+
+	projects, err := client.ListProjects(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []hookdeck.Project{Project}, projects)
+
+	session, err := client.CreateSession(context.Background(), hookdeck.CreateSessionInput{})
+	require.NoError(t, err)
+	require.NotEmpty(t, session.Id)
+}
+
+func TestServer_Projects(t *testing.T) {
+	ts := httptest.NewServer(New())
+	defer ts.Close()
+
+	baseURL, _ := url.Parse(ts.URL)
+	client := &hookdeck.Client{
+		BaseURL: baseURL,
+		APIKey:  APIKey,
+	}
+
+	organizations, err := client.ListOrganizations(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []hookdeck.Organization{Organization}, organizations)
+
+	project, err := client.CreateProject(context.Background(), "staging", Organization.Id)
+	require.NoError(t, err)
+	require.Equal(t, "staging", project.Name)
+
+	project, err = client.RenameProject(context.Background(), project.Id, "production")
+	require.NoError(t, err)
+	require.Equal(t, "production", project.Name)
+
+	projects, err := client.ListProjects(context.Background())
+	require.NoError(t, err)
+	require.Len(t, projects, 2)
+
+	require.NoError(t, client.DeleteProject(context.Background(), project.Id))
+	require.Error(t, client.DeleteProject(context.Background(), project.Id))
+
+	projects, err = client.ListProjects(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []hookdeck.Project{Project}, projects)
+}
@@ -0,0 +1,46 @@
+package notify
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+)
+
+var execCommand = exec.Command
+
+// The title and message are passed to the notification scripts through
+// environment variables, so they don't need to be escaped
+const (
+	titleEnvVar   = "HOOKDECK_NOTIFY_TITLE"
+	messageEnvVar = "HOOKDECK_NOTIFY_MESSAGE"
+)
+
+const darwinScript = `display notification (system attribute "` + messageEnvVar + `") with title (system attribute "` + titleEnvVar + `")`
+
+const windowsScript = `[Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime] > $null
+$template = [Windows.UI.Notifications.ToastNotificationManager]::GetTemplateContent([Windows.UI.Notifications.ToastTemplateType]::ToastText02)
+$text = $template.GetElementsByTagName('text')
+$text.Item(0).AppendChild($template.CreateTextNode($env:` + titleEnvVar + `)) > $null
+$text.Item(1).AppendChild($template.CreateTextNode($env:` + messageEnvVar + `)) > $null
+[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier('Hookdeck CLI').Show([Windows.UI.Notifications.ToastNotification]::new($template))`
+
+// Desktop shows a native desktop notification on the operating system
+func Desktop(title string, message string) error {
+	var cmd *exec.Cmd
+
+	switch runtime.GOOS {
+	case "linux":
+		cmd = execCommand("notify-send", "--app-name=Hookdeck CLI", title, message)
+	case "windows":
+		cmd = execCommand("powershell", "-NoProfile", "-NonInteractive", "-Command", windowsScript)
+	case "darwin":
+		cmd = execCommand("osascript", "-e", darwinScript)
+	default:
+		return fmt.Errorf("unsupported platform")
+	}
+
+	cmd.Env = append(os.Environ(), titleEnvVar+"="+title, messageEnvVar+"="+message)
+
+	return cmd.Run()
+}
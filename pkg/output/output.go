@@ -0,0 +1,194 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/itchyny/gojq"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v2"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/redact"
+)
+
+// Supported output formats
+const (
+	FormatTable = "table"
+	FormatJSON  = "json"
+	FormatYAML  = "yaml"
+)
+
+// DefaultFormat is the format used when the `--output` flag is not set. It
+// can be changed with the `output` config value.
+var DefaultFormat = FormatTable
+
+// Row is a single table row, keyed by column name.
+type Row map[string]string
+
+// Options holds the output flags shared by list commands.
+type Options struct {
+	Format  string
+	Columns []string
+	// Query is a jq expression applied to the JSON output
+	Query string
+
+	// DefaultColumns are the columns displayed in table format when the
+	// `--columns` flag is not provided.
+	DefaultColumns []string
+	// AvailableColumns are all the columns a command is able to display.
+	AvailableColumns []string
+}
+
+// AddFlags registers the `--output` and `--columns` flags.
+func (o *Options) AddFlags(flags *pflag.FlagSet) {
+	flags.StringVarP(&o.Format, "output", "o", "", "Output format (table, json, yaml) (default \"table\")")
+	flags.StringSliceVar(&o.Columns, "columns", nil, fmt.Sprintf("Columns to display in table format (%s)", strings.Join(o.AvailableColumns, ", ")))
+	flags.StringVar(&o.Query, "query", "", "jq expression used to filter the JSON output (e.g. '.[].id')")
+}
+
+// Validate checks that the flag values are supported.
+func (o *Options) Validate() error {
+	if o.Format == "" {
+		o.Format = DefaultFormat
+	}
+
+	switch o.Format {
+	case FormatTable, FormatJSON, FormatYAML:
+	default:
+		return fmt.Errorf("unsupported output format %q. Expected one of table, json, yaml", o.Format)
+	}
+
+	for _, column := range o.Columns {
+		if !contains(o.AvailableColumns, column) {
+			return fmt.Errorf("unknown column %q. Available columns: %s", column, strings.Join(o.AvailableColumns, ", "))
+		}
+	}
+
+	if o.Query != "" {
+		if _, err := gojq.Parse(o.Query); err != nil {
+			return fmt.Errorf("invalid query: %s", err)
+		}
+	}
+
+	return nil
+}
+
+// Print writes data in the selected format. The raw data is used for the
+// JSON and YAML formats, and the rows are used for the table format.
+func (o *Options) Print(w io.Writer, data interface{}, rows []Row) error {
+	if o.Query != "" {
+		return printQuery(w, o.Query, data)
+	}
+
+	switch o.Format {
+	case FormatJSON:
+		return printJSON(w, data)
+	case FormatYAML:
+		return printYAML(w, data)
+	default:
+		columns := o.Columns
+		if len(columns) == 0 {
+			columns = o.DefaultColumns
+		}
+		return printTable(w, columns, rows)
+	}
+}
+
+func printJSON(w io.Writer, data interface{}) error {
+	b, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(redact.JSON(b)))
+	return err
+}
+
+func printYAML(w io.Writer, data interface{}) error {
+	// Round trip through JSON so the YAML keys match the JSON field names
+	b, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	var generic interface{}
+	if err := yaml.Unmarshal(redact.JSON(b), &generic); err != nil {
+		return err
+	}
+	out, err := yaml.Marshal(generic)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(out)
+	return err
+}
+
+// printQuery runs a jq expression against the JSON representation of data
+// and prints every result on its own line. Like `jq -r`, strings are
+// printed without quotes so they can be used directly in scripts.
+func printQuery(w io.Writer, expression string, data interface{}) error {
+	query, err := gojq.Parse(expression)
+	if err != nil {
+		return fmt.Errorf("invalid query: %s", err)
+	}
+
+	// gojq operates on generic values, so round trip through JSON first
+	b, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	var generic interface{}
+	if err := json.Unmarshal(redact.JSON(b), &generic); err != nil {
+		return err
+	}
+
+	iter := query.Run(generic)
+	for {
+		v, ok := iter.Next()
+		if !ok {
+			break
+		}
+		if err, ok := v.(error); ok {
+			return fmt.Errorf("query error: %s", err)
+		}
+		if str, ok := v.(string); ok {
+			fmt.Fprintln(w, str)
+			continue
+		}
+		if err := printJSON(w, v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func printTable(w io.Writer, columns []string, rows []Row) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+
+	headers := make([]string, len(columns))
+	for i, column := range columns {
+		headers[i] = strings.ToUpper(column)
+	}
+	fmt.Fprintln(tw, strings.Join(headers, "\t"))
+
+	for _, row := range rows {
+		values := make([]string, len(columns))
+		for i, column := range columns {
+			values[i] = row[column]
+		}
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+
+	return tw.Flush()
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testData struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestPrint_Table(t *testing.T) {
+	opts := Options{
+		Format:           FormatTable,
+		DefaultColumns:   []string{"name", "id"},
+		AvailableColumns: []string{"id", "name"},
+	}
+	rows := []Row{{"id": "prj_1", "name": "first"}, {"id": "prj_2", "name": "second"}}
+
+	var b bytes.Buffer
+	require.NoError(t, opts.Print(&b, nil, rows))
+	require.Equal(t, "NAME     ID\nfirst    prj_1\nsecond   prj_2\n", b.String())
+
+	b.Reset()
+	opts.Columns = []string{"id"}
+	require.NoError(t, opts.Print(&b, nil, rows))
+	require.Equal(t, "ID\nprj_1\nprj_2\n", b.String())
+}
+
+func TestPrint_YAML(t *testing.T) {
+	opts := Options{Format: FormatYAML}
+
+	var b bytes.Buffer
+	require.NoError(t, opts.Print(&b, []testData{{ID: "prj_1", Name: "first"}}, nil))
+	require.Equal(t, "- id: prj_1\n  name: first\n", b.String())
+}
+
+func TestValidate(t *testing.T) {
+	opts := Options{Format: "xml", AvailableColumns: []string{"id"}}
+	require.Error(t, opts.Validate())
+
+	opts = Options{Format: FormatJSON, Columns: []string{"nope"}, AvailableColumns: []string{"id"}}
+	require.Error(t, opts.Validate())
+
+	opts = Options{Format: FormatTable, Columns: []string{"id"}, AvailableColumns: []string{"id"}}
+	require.NoError(t, opts.Validate())
+}
+
+func TestPrint_Query(t *testing.T) {
+	opts := Options{Format: FormatTable, Query: ".[].id"}
+	data := []testData{{ID: "prj_1", Name: "first"}, {ID: "prj_2", Name: "second"}}
+
+	var b bytes.Buffer
+	require.NoError(t, opts.Print(&b, data, nil))
+	require.Equal(t, "prj_1\nprj_2\n", b.String())
+
+	b.Reset()
+	opts.Query = ".[0]"
+	require.NoError(t, opts.Print(&b, data, nil))
+	require.Equal(t, "{\n  \"id\": \"prj_1\",\n  \"name\": \"first\"\n}\n", b.String())
+}
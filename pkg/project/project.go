@@ -1,22 +1,131 @@
 package project
 
 import (
+	"context"
+	"fmt"
 	"net/url"
+	"time"
 
 	"github.com/hookdeck/hookdeck-cli/pkg/config"
 	"github.com/hookdeck/hookdeck-cli/pkg/hookdeck"
 )
 
+// ProjectsCacheTTL is how long the list of projects is cached for lookups.
+const ProjectsCacheTTL = 5 * time.Minute
+
+// ListProjects lists the projects of the API key, and caches them for
+// CachedProjects.
 func ListProjects(config *config.Config) ([]hookdeck.Project, error) {
-	parsedBaseURL, err := url.Parse(config.APIBaseURL)
+	client, err := newClient(config)
+	if err != nil {
+		return nil, err
+	}
+
+	projects, err := client.ListProjects(context.Background())
 	if err != nil {
 		return nil, err
 	}
+	// The cache only saves an API call, failing to write it isn't an error
+	config.GetCache().Set(projectsCacheKey(config), projects)
 
-	client := &hookdeck.Client{
-		BaseURL: parsedBaseURL,
-		APIKey:  config.Profile.APIKey,
+	return projects, nil
+}
+
+// CachedProjects lists the projects of the API key like ListProjects, but
+// returns the cached list when it's more recent than ProjectsCacheTTL. It's
+// meant for looking up projects by name, listings should use ListProjects.
+func CachedProjects(config *config.Config) ([]hookdeck.Project, error) {
+	projects := []hookdeck.Project{}
+	if config.GetCache().Get(projectsCacheKey(config), ProjectsCacheTTL, &projects) {
+		return projects, nil
+	}
+	return ListProjects(config)
+}
+
+// CreateProject creates a project in the given organization, or in the
+// organization of the API key when organizationID is empty.
+func CreateProject(config *config.Config, name string, organizationID string) (hookdeck.Project, error) {
+	client, err := newClient(config)
+	if err != nil {
+		return hookdeck.Project{}, err
+	}
+
+	defer invalidateProjects(config)
+	return client.CreateProject(context.Background(), name, organizationID)
+}
+
+// RenameProject changes the name of a project.
+func RenameProject(config *config.Config, id string, name string) (hookdeck.Project, error) {
+	client, err := newClient(config)
+	if err != nil {
+		return hookdeck.Project{}, err
+	}
+
+	defer invalidateProjects(config)
+	return client.RenameProject(context.Background(), id, name)
+}
+
+// DeleteProject deletes a project with all its resources.
+func DeleteProject(config *config.Config, id string) error {
+	client, err := newClient(config)
+	if err != nil {
+		return err
+	}
+
+	defer invalidateProjects(config)
+	return client.DeleteProject(context.Background(), id)
+}
+
+// ListOrganizations lists the organizations of the user.
+func ListOrganizations(config *config.Config) ([]hookdeck.Organization, error) {
+	client, err := newClient(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.ListOrganizations(context.Background())
+}
+
+// FindProject returns the project with the given name or ID.
+func FindProject(projects []hookdeck.Project, nameOrID string) (hookdeck.Project, error) {
+	for _, project := range projects {
+		if project.Id == nameOrID || project.Name == nameOrID {
+			return project, nil
+		}
+	}
+	return hookdeck.Project{}, fmt.Errorf("project %q not found", nameOrID)
+}
+
+// FindOrganization returns the organization with the given name or ID.
+func FindOrganization(organizations []hookdeck.Organization, nameOrID string) (hookdeck.Organization, error) {
+	for _, organization := range organizations {
+		if organization.Id == nameOrID || organization.Name == nameOrID {
+			return organization, nil
+		}
+	}
+	return hookdeck.Organization{}, fmt.Errorf("organization %q not found", nameOrID)
+}
+
+// projectsCacheKey is the cache key of the projects of the API key.
+func projectsCacheKey(config *config.Config) string {
+	return "projects:" + config.APIBaseURL + ":" + config.Profile.APIKey
+}
+
+// invalidateProjects removes the cached projects after a change.
+func invalidateProjects(config *config.Config) {
+	config.GetCache().Delete(projectsCacheKey(config))
+}
+
+func newClient(config *config.Config) (*hookdeck.Client, error) {
+	parsedBaseURL, err := url.Parse(config.APIBaseURL)
+	if err != nil {
+		return nil, err
 	}
 
-	return client.ListProjects()
+	return &hookdeck.Client{
+		BaseURL:    parsedBaseURL,
+		APIKey:     config.Profile.APIKey,
+		MaxRetries: config.MaxRetries,
+		Verbosity:  config.Verbosity,
+	}, nil
 }
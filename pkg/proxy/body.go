@@ -0,0 +1,76 @@
+package proxy
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"unicode/utf8"
+)
+
+// DefaultMaxBodyMemory is the size above which the inspector keeps request
+// bodies in temp files rather than in memory.
+const DefaultMaxBodyMemory = 1 << 20
+
+// storedBody is a body kept by the inspector, in memory or in a temp file
+// when it's larger than the memory threshold.
+type storedBody struct {
+	data string
+	file string
+	size int
+}
+
+// storeBody keeps a body in memory, or in a temp file of dir when it's larger
+// than max. dir is created when needed.
+func storeBody(dir *string, body string, max int) (storedBody, error) {
+	if max <= 0 || len(body) <= max {
+		return storedBody{data: body, size: len(body)}, nil
+	}
+
+	if *dir == "" {
+		created, err := ioutil.TempDir("", "hookdeck-inspector-")
+		if err != nil {
+			return storedBody{}, err
+		}
+		*dir = created
+	}
+
+	f, err := ioutil.TempFile(*dir, "body-")
+	if err != nil {
+		return storedBody{}, err
+	}
+	defer f.Close()
+
+	if _, err := f.WriteString(body); err != nil {
+		os.Remove(f.Name())
+		return storedBody{}, err
+	}
+
+	return storedBody{file: filepath.Clean(f.Name()), size: len(body)}, nil
+}
+
+func (b storedBody) read() (string, error) {
+	if b.file == "" {
+		return b.data, nil
+	}
+	data, err := ioutil.ReadFile(b.file)
+	return string(data), err
+}
+
+func (b storedBody) remove() {
+	if b.file != "" {
+		os.Remove(b.file)
+	}
+}
+
+// truncateBody truncates a body to max bytes for display, without splitting
+// a UTF-8 character, and returns whether it was truncated.
+func truncateBody(body string, max int) (string, bool) {
+	if max <= 0 || len(body) <= max {
+		return body, false
+	}
+	cut := max
+	for cut > 0 && !utf8.RuneStart(body[cut]) {
+		cut--
+	}
+	return body[:cut], true
+}
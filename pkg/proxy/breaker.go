@@ -0,0 +1,190 @@
+package proxy
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/ansi"
+	"github.com/hookdeck/hookdeck-cli/pkg/websocket"
+)
+
+// Defaults of the circuit breaker settings.
+const (
+	DefaultBreakerFailures = 5
+	DefaultBreakerCooldown = 30 * time.Second
+)
+
+// CircuitBreaker stops forwarding events to the local server once it's been
+// unreachable for Failures consecutive events. The next events are failed
+// right away for the Cooldown, after which an event is forwarded to probe
+// the local server, closing the breaker when it's back up.
+type CircuitBreaker struct {
+	Failures int
+	Cooldown time.Duration
+}
+
+// ParseCircuitBreaker parses the settings in the format of the
+// --circuit-breaker flag, e.g. "failures=5,cooldown=30s". Settings left out
+// have their default value.
+func ParseCircuitBreaker(value string) (*CircuitBreaker, error) {
+	breaker := &CircuitBreaker{Failures: DefaultBreakerFailures, Cooldown: DefaultBreakerCooldown}
+
+	for _, part := range strings.Split(value, ",") {
+		key, val, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid circuit breaker %q, expected key=value pairs such as failures=5,cooldown=30s", value)
+		}
+
+		var err error
+		switch key {
+		case "failures":
+			breaker.Failures, err = strconv.Atoi(val)
+			if err == nil && breaker.Failures < 1 {
+				err = fmt.Errorf("it must be at least 1")
+			}
+		case "cooldown":
+			breaker.Cooldown, err = time.ParseDuration(val)
+			if err == nil && breaker.Cooldown <= 0 {
+				err = fmt.Errorf("it must be positive")
+			}
+		default:
+			return nil, fmt.Errorf("invalid circuit breaker key %q, expected one of failures, cooldown", key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid circuit breaker %s %q: %w", key, val, err)
+		}
+	}
+
+	return breaker, nil
+}
+
+// Transitions of the circuit breaker on an event outcome.
+const (
+	breakerUnchanged = iota
+	// breakerTripped when the failures reach the threshold
+	breakerTripped
+	// breakerReopened when the probe event fails
+	breakerReopened
+	// breakerRecovered when an event reaches the local server again
+	breakerRecovered
+)
+
+// circuitBreaker is the state of a CircuitBreaker. It's closed while
+// failures stays below the threshold, open until openUntil once tripped,
+// then half-open while a single probe event is in flight.
+type circuitBreaker struct {
+	mu       sync.Mutex
+	settings CircuitBreaker
+	failures int
+	open     bool
+	// openUntil is the end of the cool-down while open
+	openUntil time.Time
+	probing   bool
+}
+
+// allow returns whether an event can be forwarded: when the breaker is
+// closed, or as the probe once the cool-down is over.
+func (b *circuitBreaker) allow(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.open {
+		return true
+	}
+	if b.probing || now.Before(b.openUntil) {
+		return false
+	}
+	b.probing = true
+	return true
+}
+
+// record records whether a forwarded event reached the local server, and
+// returns the resulting transition of the breaker.
+func (b *circuitBreaker) record(now time.Time, reachable bool) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if reachable {
+		b.failures = 0
+		if !b.open {
+			return breakerUnchanged
+		}
+		b.open = false
+		b.probing = false
+		return breakerRecovered
+	}
+
+	b.failures++
+	if b.open {
+		// Events forwarded before the breaker tripped can still fail, only
+		// the probe reopens it
+		if !b.probing {
+			return breakerUnchanged
+		}
+		b.probing = false
+		b.openUntil = now.Add(b.settings.Cooldown)
+		return breakerReopened
+	}
+	if b.failures < b.settings.Failures {
+		return breakerUnchanged
+	}
+	b.open = true
+	b.openUntil = now.Add(b.settings.Cooldown)
+	return breakerTripped
+}
+
+// recordReachable records the outcome of a forwarded event with the
+// circuit breaker, when enabled, and prints its transitions.
+func (p *Proxy) recordReachable(reachable bool) {
+	if p.breaker == nil {
+		return
+	}
+
+	w := p.out()
+	if p.cfg.Output == OutputJSON {
+		w = ansi.StatusWriter
+	}
+	color := ansi.Color(w)
+	localTime := color.Faint(time.Now().Format(timeLayout))
+	url := p.cfg.URL.Scheme + "://" + p.cfg.URL.Host
+
+	switch p.breaker.record(time.Now(), reachable) {
+	case breakerTripped:
+		fmt.Fprintf(w, "%s %s %s is unreachable after %d consecutive events. Events fail without being forwarded for %s, then the next one probes it.\n",
+			localTime, color.Bold(color.Red("LOCAL TARGET DOWN")), url, p.breaker.settings.Failures, p.breaker.settings.Cooldown)
+	case breakerReopened:
+		fmt.Fprintf(w, "%s %s %s is still unreachable, probing again in %s.\n",
+			localTime, color.Bold(color.Red("LOCAL TARGET DOWN")), url, p.breaker.settings.Cooldown)
+	case breakerRecovered:
+		fmt.Fprintf(w, "%s %s %s is reachable again, forwarding events.\n",
+			localTime, color.Bold(color.Green("LOCAL TARGET UP")), url)
+	}
+}
+
+// breakerAttempt fails an event without forwarding it while the circuit
+// breaker is open. It's reported as set with --on-local-error, or responded
+// to with --respond-status.
+func (p *Proxy) breakerAttempt(webhookEvent *websocket.Attempt, req *http.Request) {
+	p.recordOutcome(true)
+	p.summary.record(webhookEvent.Body.Path, 0, true, 0)
+
+	if p.cfg.RespondStatus != 0 {
+		p.respondOverride(webhookEvent, req)
+		return
+	}
+	if p.cfg.Output == OutputJSON {
+		p.printAttemptJSON(attemptOutput{
+			EventID:  webhookEvent.Body.EventID,
+			Method:   req.Method,
+			URL:      req.URL.String(),
+			Error:    "local target down, not forwarded",
+			EventURL: p.eventURL(webhookEvent),
+		})
+	}
+	p.failAttempt(webhookEvent, errors.New("local target down, not forwarded"))
+}
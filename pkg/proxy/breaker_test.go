@@ -0,0 +1,47 @@
+package proxy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCircuitBreaker(t *testing.T) {
+	breaker, err := ParseCircuitBreaker("failures=3")
+	require.NoError(t, err)
+	require.Equal(t, &CircuitBreaker{Failures: 3, Cooldown: DefaultBreakerCooldown}, breaker)
+
+	breaker, err = ParseCircuitBreaker("failures=1, cooldown=2s")
+	require.NoError(t, err)
+	require.Equal(t, &CircuitBreaker{Failures: 1, Cooldown: 2 * time.Second}, breaker)
+
+	for _, value := range []string{"", "failures", "failures=0", "cooldown=0s", "cooldown=soon", "retries=3"} {
+		_, err := ParseCircuitBreaker(value)
+		require.Error(t, err, value)
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	b := &circuitBreaker{settings: CircuitBreaker{Failures: 2, Cooldown: time.Minute}}
+	start := time.Now()
+
+	require.True(t, b.allow(start))
+	require.Equal(t, breakerUnchanged, b.record(start, false))
+	require.Equal(t, breakerTripped, b.record(start, false))
+
+	// Events fail right away during the cool-down
+	require.False(t, b.allow(start.Add(30*time.Second)))
+
+	// A single probe is forwarded after it, and reopens the breaker when it
+	// fails
+	require.True(t, b.allow(start.Add(time.Minute)))
+	require.False(t, b.allow(start.Add(time.Minute)))
+	require.Equal(t, breakerReopened, b.record(start.Add(time.Minute), false))
+	require.False(t, b.allow(start.Add(90*time.Second)))
+
+	require.True(t, b.allow(start.Add(2*time.Minute)))
+	require.Equal(t, breakerRecovered, b.record(start.Add(2*time.Minute), true))
+	require.True(t, b.allow(start.Add(2*time.Minute)))
+	require.Equal(t, breakerUnchanged, b.record(start.Add(2*time.Minute), false))
+}
@@ -0,0 +1,37 @@
+package proxy
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/ansi"
+)
+
+// printEcho prints the headers and body of an event for Echo, the body being
+// indented when it's JSON.
+func printEcho(w io.Writer, headers map[string]string, body string) {
+	color := ansi.Color(w)
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %s %s\n", color.Faint(name+":"), headers[name])
+	}
+
+	if body != "" {
+		fmt.Fprintln(w)
+		pretty := &bytes.Buffer{}
+		if json.Indent(pretty, []byte(body), "  ", "  ") == nil {
+			body = pretty.String()
+		}
+		fmt.Fprintf(w, "  %s\n", strings.TrimRight(body, "\n"))
+	}
+	fmt.Fprintln(w)
+}
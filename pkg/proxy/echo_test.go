@@ -0,0 +1,29 @@
+package proxy
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintEcho(t *testing.T) {
+	out := &bytes.Buffer{}
+	printEcho(out, map[string]string{"User-Agent": "Stripe/1.0", "Content-Type": "application/json"}, `{"type":"invoice.paid","data":{"id":"in_1"}}`)
+
+	require.Equal(t, `  Content-Type: application/json
+  User-Agent: Stripe/1.0
+
+  {
+    "type": "invoice.paid",
+    "data": {
+      "id": "in_1"
+    }
+  }
+
+`, out.String())
+
+	out.Reset()
+	printEcho(out, map[string]string{}, "a=1&b=2")
+	require.Equal(t, "\n  a=1&b=2\n\n", out.String())
+}
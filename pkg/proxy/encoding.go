@@ -0,0 +1,64 @@
+package proxy
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"io/ioutil"
+	"strings"
+)
+
+// decodeBody decompresses a body with the given Content-Encoding, for display
+// and filtering. Bodies are always forwarded as received. The body is
+// returned as is when the encoding isn't supported or the body can't be
+// decompressed.
+func decodeBody(encoding string, body []byte) []byte {
+	encodings := strings.Split(encoding, ",")
+
+	// Encodings are listed in the order they were applied
+	decoded := body
+	for i := len(encodings) - 1; i >= 0; i-- {
+		var reader io.ReadCloser
+		var err error
+
+		switch strings.ToLower(strings.TrimSpace(encodings[i])) {
+		case "", "identity":
+			continue
+		case "gzip", "x-gzip":
+			reader, err = gzip.NewReader(bytes.NewReader(decoded))
+		case "deflate":
+			// deflate is zlib-wrapped per the spec, but some servers send
+			// raw deflate data
+			reader, err = zlib.NewReader(bytes.NewReader(decoded))
+			if err != nil {
+				reader, err = flate.NewReader(bytes.NewReader(decoded)), nil
+			}
+		default:
+			return body
+		}
+		if err != nil {
+			return body
+		}
+
+		decoded, err = ioutil.ReadAll(reader)
+		reader.Close()
+		if err != nil {
+			return body
+		}
+	}
+
+	return decoded
+}
+
+// headerValue returns the value of a header, with a case insensitive lookup
+// of its name.
+func headerValue(headers map[string]string, name string) string {
+	for key, value := range headers {
+		if strings.EqualFold(key, name) {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,44 @@
+package proxy
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeBody(t *testing.T) {
+	body := []byte(`{"type":"invoice.paid"}`)
+
+	var gzipped bytes.Buffer
+	gw := gzip.NewWriter(&gzipped)
+	gw.Write(body)
+	gw.Close()
+	require.Equal(t, body, decodeBody("gzip", gzipped.Bytes()))
+	require.Equal(t, body, decodeBody("GZIP", gzipped.Bytes()))
+
+	var zlibbed bytes.Buffer
+	zw := zlib.NewWriter(&zlibbed)
+	zw.Write(body)
+	zw.Close()
+	require.Equal(t, body, decodeBody("deflate", zlibbed.Bytes()))
+
+	var deflated bytes.Buffer
+	fw, _ := flate.NewWriter(&deflated, flate.DefaultCompression)
+	fw.Write(body)
+	fw.Close()
+	require.Equal(t, body, decodeBody("deflate", deflated.Bytes()))
+
+	var both bytes.Buffer
+	gw = gzip.NewWriter(&both)
+	gw.Write(zlibbed.Bytes())
+	gw.Close()
+	require.Equal(t, body, decodeBody("deflate, gzip", both.Bytes()))
+
+	require.Equal(t, body, decodeBody("", body))
+	require.Equal(t, body, decodeBody("br", body))
+	require.Equal(t, body, decodeBody("gzip", body))
+}
@@ -0,0 +1,152 @@
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/ansi"
+	"github.com/hookdeck/hookdeck-cli/pkg/websocket"
+)
+
+// execAttempt runs the Exec command for an event instead of forwarding it.
+// The body is written to its stdin and the metadata of the event is set in
+// its environment. It succeeds with a 200 when it exits with 0, and fails
+// with a 500 otherwise, its stdout being the body of the response.
+func (p *Proxy) execAttempt(webhookEvent *websocket.Attempt, headers map[string]string, body string) {
+	timeout := time.Duration(webhookEvent.Body.Request.Timeout) * time.Millisecond
+	if timeout == 0 {
+		timeout = 30 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	// The output is written to a file rather than a pipe, so that the
+	// command returns on timeout even when the processes it started keep
+	// the output open
+	stdout, err := ioutil.TempFile("", "hookdeck-exec-")
+	if err != nil {
+		p.printExec(webhookEvent, 0, 0, err)
+		p.failAttempt(webhookEvent, err)
+		return
+	}
+	defer os.Remove(stdout.Name())
+	defer stdout.Close()
+
+	cmd := shellCommand(ctx, p.cfg.Exec)
+	cmd.Stdin = strings.NewReader(body)
+	cmd.Stdout = stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(), execEnv(webhookEvent, headers, p.eventURL(webhookEvent))...)
+
+	start := time.Now()
+	err = cmd.Run()
+	duration := time.Since(start)
+
+	status := http.StatusOK
+	var exitErr *exec.ExitError
+	switch {
+	case ctx.Err() != nil:
+		err = fmt.Errorf("command timed out after %s", timeout)
+	case errors.As(err, &exitErr):
+		status, err = http.StatusInternalServerError, nil
+	}
+
+	if err != nil {
+		p.printExec(webhookEvent, 0, duration, err)
+		p.notifyFailure(webhookEvent, err.Error())
+		p.recordOutcome(true)
+		p.summary.record(webhookEvent.Body.Path, 0, true, duration)
+		p.failAttempt(webhookEvent, err)
+		return
+	}
+
+	output, err := ioutil.ReadFile(stdout.Name())
+	if err != nil {
+		p.printExec(webhookEvent, 0, duration, err)
+		p.failAttempt(webhookEvent, err)
+		return
+	}
+
+	failed := status != http.StatusOK
+	p.printExec(webhookEvent, status, duration, nil)
+	if failed {
+		p.notifyFailure(webhookEvent, fmt.Sprintf("command exited with %d", exitErr.ExitCode()))
+	}
+	p.recordOutcome(failed)
+	p.summary.record(webhookEvent.Body.Path, status, failed, duration)
+
+	if p.cfg.NoAck {
+		p.nackAttempt(webhookEvent)
+		return
+	}
+
+	p.sendMessage(&websocket.OutgoingMessage{
+		AttemptResponse: &websocket.AttemptResponse{
+			Event: "attempt_response",
+			Body: websocket.AttemptResponseBody{
+				AttemptId: webhookEvent.Body.AttemptId,
+				CLIPath:   webhookEvent.Body.Path,
+				Status:    status,
+				Data:      string(output),
+			},
+		}})
+}
+
+func (p *Proxy) printExec(webhookEvent *websocket.Attempt, status int, duration time.Duration, err error) {
+	if p.cfg.Output == OutputJSON {
+		output := attemptOutput{
+			EventID:  webhookEvent.Body.EventID,
+			Method:   webhookEvent.Body.Request.Method,
+			URL:      webhookEvent.Body.Path,
+			Status:   status,
+			EventURL: p.eventURL(webhookEvent),
+		}
+		if err != nil {
+			output.Error = err.Error()
+		}
+		p.printAttemptJSON(output)
+		return
+	}
+
+	color := ansi.Color(p.out())
+	localTime := color.Faint(time.Now().Format(timeLayout))
+	if err != nil {
+		fmt.Fprintf(p.out(), "%s [%s] Failed to run the command for %s %s: %v\n", localTime, color.Red("ERROR"), webhookEvent.Body.Request.Method, webhookEvent.Body.Path, err)
+		return
+	}
+	fmt.Fprintf(p.out(), "%s [%d] %s %s %s | %s\n", localTime, ansi.ColorizeStatus(status), webhookEvent.Body.Request.Method, webhookEvent.Body.Path,
+		color.Faint(fmt.Sprintf("(exec, %dms)", duration.Milliseconds())), p.eventURL(webhookEvent))
+}
+
+// shellCommand returns the command running a command line with the shell
+// of the platform.
+func shellCommand(ctx context.Context, command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", command)
+	}
+	return exec.CommandContext(ctx, "sh", "-c", command)
+}
+
+// execEnv returns the environment variables describing an event to the Exec
+// command.
+func execEnv(webhookEvent *websocket.Attempt, headers map[string]string, eventURL string) []string {
+	headersJSON, _ := json.Marshal(headers)
+	return []string{
+		"HOOKDECK_EVENT_ID=" + webhookEvent.Body.EventID,
+		"HOOKDECK_ATTEMPT_ID=" + webhookEvent.Body.AttemptId,
+		"HOOKDECK_CONNECTION_ID=" + webhookEvent.Body.ConnectionId,
+		"HOOKDECK_METHOD=" + webhookEvent.Body.Request.Method,
+		"HOOKDECK_PATH=" + webhookEvent.Body.Path,
+		"HOOKDECK_HEADERS=" + string(headersJSON),
+		"HOOKDECK_EVENT_URL=" + eventURL,
+	}
+}
@@ -0,0 +1,44 @@
+package proxy
+
+import (
+	"net/http"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/websocket"
+)
+
+func TestExec(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test commands use sh")
+	}
+
+	attempt := func(id string) websocket.IncomingMessage {
+		return websocket.IncomingMessage{Attempt: &websocket.Attempt{
+			Body: websocket.AttemptBody{
+				Path:    "/webhooks",
+				EventID: id,
+				Request: websocket.AttemptRequest{
+					Method:     http.MethodPost,
+					DataString: `{"ok":true}`,
+					Headers:    []byte(`{}`),
+					Timeout:    500,
+				},
+			},
+		}}
+	}
+
+	p := New(&Config{Exec: `test "$(cat)" = '{"ok":true}' && test "$HOOKDECK_EVENT_ID" = evt_1`, Output: OutputJSON}, nil)
+	p.processAttempt(attempt("evt_1"))
+	p.processAttempt(attempt("evt_2"))
+	// The command is stopped at the timeout of the event
+	p.cfg.Exec = "exec sleep 5"
+	p.processAttempt(attempt("evt_3"))
+
+	summary := p.Summary()
+	require.Equal(t, 3, summary.Events)
+	require.Equal(t, 1, summary.Succeeded)
+	require.Equal(t, map[string]int{"200": 1, "500": 1, "error": 1}, summary.Statuses)
+}
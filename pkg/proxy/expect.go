@@ -0,0 +1,31 @@
+package proxy
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// Expectation is checked against the responses of the local server. Events
+// whose response doesn't match it are reported as failed, even with a 2xx
+// status.
+type Expectation struct {
+	// Status is the expected status code, any 2xx status when 0
+	Status int
+	// BodyContains must be in the response body, when set
+	BodyContains string
+	// Nack reports the events whose response doesn't match as not delivered
+	// to Hookdeck
+	Nack bool
+}
+
+// Check returns why a response doesn't match the expectation, or an empty
+// string when it does.
+func (e *Expectation) Check(status int, body []byte) string {
+	if e.Status != 0 && status != e.Status {
+		return fmt.Sprintf("expected status %d", e.Status)
+	}
+	if e.BodyContains != "" && !bytes.Contains(body, []byte(e.BodyContains)) {
+		return fmt.Sprintf("expected body to contain %q", e.BodyContains)
+	}
+	return ""
+}
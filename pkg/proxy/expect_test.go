@@ -0,0 +1,16 @@
+package proxy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpectation(t *testing.T) {
+	expect := &Expectation{Status: 200, BodyContains: "ok"}
+	require.Equal(t, "", expect.Check(200, []byte(`{"status":"ok"}`)))
+	require.Equal(t, "expected status 200", expect.Check(201, []byte(`{"status":"ok"}`)))
+	require.Equal(t, `expected body to contain "ok"`, expect.Check(200, []byte(`{"status":"error"}`)))
+
+	require.Equal(t, "", (&Expectation{BodyContains: "ok"}).Check(500, []byte("ok")))
+}
@@ -0,0 +1,146 @@
+package proxy
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultFailOnWindow is the window the error rate of --fail-on is computed
+// over when none is set.
+const DefaultFailOnWindow = 5 * time.Minute
+
+// FailOn is a threshold of failed events above which the listen session ends
+// with a FailOnError. An event failed when the local server couldn't be
+// reached or responded with a non-2xx status.
+type FailOn struct {
+	// ErrorRate is the maximum rate of failed events, between 0 and 1
+	ErrorRate float64
+	// Window is the duration the error rate is computed over
+	Window time.Duration
+	// MinEvents is the number of events in the window below which the error
+	// rate isn't checked, so that the first failures don't end the session
+	MinEvents int
+}
+
+// ParseFailOn parses a threshold in the format of the --fail-on flag, e.g.
+// "error-rate=0.2,window=5m,min-events=10". Only the error rate is required.
+func ParseFailOn(value string) (*FailOn, error) {
+	failOn := &FailOn{ErrorRate: -1, Window: DefaultFailOnWindow, MinEvents: 1}
+
+	for _, part := range strings.Split(value, ",") {
+		key, val, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid fail-on %q, expected key=value pairs such as error-rate=0.2,window=5m", value)
+		}
+
+		var err error
+		switch key {
+		case "error-rate":
+			failOn.ErrorRate, err = strconv.ParseFloat(val, 64)
+			if err == nil && (failOn.ErrorRate < 0 || failOn.ErrorRate >= 1) {
+				err = fmt.Errorf("it must be at least 0 and less than 1")
+			}
+		case "window":
+			failOn.Window, err = time.ParseDuration(val)
+			if err == nil && failOn.Window <= 0 {
+				err = fmt.Errorf("it must be positive")
+			}
+		case "min-events":
+			failOn.MinEvents, err = strconv.Atoi(val)
+			if err == nil && failOn.MinEvents < 1 {
+				err = fmt.Errorf("it must be at least 1")
+			}
+		default:
+			return nil, fmt.Errorf("invalid fail-on key %q, expected one of error-rate, window, min-events", key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid fail-on %s %q: %w", key, val, err)
+		}
+	}
+
+	if failOn.ErrorRate < 0 {
+		return nil, fmt.Errorf("invalid fail-on %q, error-rate is required", value)
+	}
+
+	return failOn, nil
+}
+
+// FailOnError is returned by Proxy.Run when the rate of failed events exceeds
+// the --fail-on threshold.
+type FailOnError struct {
+	Failed    int
+	Events    int
+	Window    time.Duration
+	ErrorRate float64
+}
+
+func (e *FailOnError) Error() string {
+	return fmt.Sprintf("%d of %d events failed in the last %s, above the error rate of %g set with --fail-on", e.Failed, e.Events, e.Window, e.ErrorRate)
+}
+
+type outcome struct {
+	at     time.Time
+	failed bool
+}
+
+// failureWindow keeps the outcomes of the events forwarded within the window
+// of a FailOn threshold.
+type failureWindow struct {
+	mu       sync.Mutex
+	failOn   FailOn
+	outcomes []outcome
+}
+
+// record adds the outcome of an event forwarded at the given time, and
+// returns a FailOnError if the threshold is now exceeded.
+func (w *failureWindow) record(at time.Time, failed bool) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.outcomes = append(w.outcomes, outcome{at: at, failed: failed})
+
+	// Outcomes are recorded in order, drop the ones out of the window
+	start := at.Add(-w.failOn.Window)
+	i := 0
+	for i < len(w.outcomes) && w.outcomes[i].at.Before(start) {
+		i++
+	}
+	w.outcomes = w.outcomes[i:]
+
+	if len(w.outcomes) < w.failOn.MinEvents {
+		return nil
+	}
+	nFailed := 0
+	for _, o := range w.outcomes {
+		if o.failed {
+			nFailed++
+		}
+	}
+	if float64(nFailed)/float64(len(w.outcomes)) <= w.failOn.ErrorRate {
+		return nil
+	}
+
+	return &FailOnError{
+		Failed:    nFailed,
+		Events:    len(w.outcomes),
+		Window:    w.failOn.Window,
+		ErrorRate: w.failOn.ErrorRate,
+	}
+}
+
+// recordOutcome checks the outcome of a forwarded event against the --fail-on
+// threshold, when set, and ends the session when it's exceeded.
+func (p *Proxy) recordOutcome(failed bool) {
+	if p.failures == nil {
+		return
+	}
+	if err := p.failures.record(time.Now(), failed); err != nil {
+		select {
+		case p.failed <- err:
+		default:
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package proxy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFailOn(t *testing.T) {
+	failOn, err := ParseFailOn("error-rate=0.2")
+	require.NoError(t, err)
+	require.Equal(t, &FailOn{ErrorRate: 0.2, Window: DefaultFailOnWindow, MinEvents: 1}, failOn)
+
+	failOn, err = ParseFailOn("error-rate=0, window=30s,min-events=10")
+	require.NoError(t, err)
+	require.Equal(t, &FailOn{ErrorRate: 0, Window: 30 * time.Second, MinEvents: 10}, failOn)
+
+	for _, value := range []string{"", "window=5m", "error-rate=1", "error-rate=-0.1", "error-rate=0.2,window=0s", "error-rate=0.2,min-events=0", "error-rate=0.2,rate=1", "error-rate"} {
+		_, err := ParseFailOn(value)
+		require.Error(t, err, value)
+	}
+}
+
+func TestFailureWindow(t *testing.T) {
+	w := &failureWindow{failOn: FailOn{ErrorRate: 0.5, Window: time.Minute, MinEvents: 4}}
+	start := time.Now()
+
+	// Below the minimum number of events
+	require.NoError(t, w.record(start, true))
+	require.NoError(t, w.record(start.Add(time.Second), true))
+	require.NoError(t, w.record(start.Add(2*time.Second), false))
+	// 2 of 4 isn't above the rate
+	require.NoError(t, w.record(start.Add(3*time.Second), false))
+
+	// The first failures are out of the window
+	require.NoError(t, w.record(start.Add(62*time.Second), true))
+	require.NoError(t, w.record(start.Add(62*time.Second), false))
+	require.NoError(t, w.record(start.Add(62*time.Second), true))
+	require.NoError(t, w.record(start.Add(62*time.Second), true))
+
+	err := w.record(start.Add(63*time.Second), true)
+	require.Equal(t, &FailOnError{Failed: 4, Events: 6, Window: time.Minute, ErrorRate: 0.5}, err)
+}
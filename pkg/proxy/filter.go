@@ -0,0 +1,118 @@
+package proxy
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/itchyny/gojq"
+)
+
+// EventFilter selects the events forwarded to the local server. Events that
+// don't match are acknowledged without being forwarded, so that Hookdeck
+// doesn't retry them.
+type EventFilter struct {
+	// Paths are glob patterns, one of which the event path must match
+	Paths []string
+	// Headers maps header names to glob patterns their value must match
+	Headers map[string]string
+	// Body is a jq expression that must return a truthy value for the event
+	// body
+	Body *gojq.Code
+}
+
+// NewEventFilter creates a filter from the values of the listen flags:
+// path globs, headers in the "Name: glob" format and a jq expression. It
+// returns nil when no filter is set.
+func NewEventFilter(paths []string, headers []string, body string) (*EventFilter, error) {
+	if len(paths) == 0 && len(headers) == 0 && body == "" {
+		return nil, nil
+	}
+
+	f := &EventFilter{Paths: paths, Headers: map[string]string{}}
+
+	for _, pattern := range paths {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid path filter %q: %s", pattern, err)
+		}
+	}
+
+	for _, header := range headers {
+		parts := strings.SplitN(header, ":", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid header filter %q, expected the \"Name: glob\" format", header)
+		}
+		pattern := strings.TrimSpace(parts[1])
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid header filter %q: %s", header, err)
+		}
+		f.Headers[strings.ToLower(strings.TrimSpace(parts[0]))] = pattern
+	}
+
+	if body != "" {
+		query, err := gojq.Parse(body)
+		if err != nil {
+			return nil, fmt.Errorf("invalid body filter: %s", err)
+		}
+		f.Body, err = gojq.Compile(query)
+		if err != nil {
+			return nil, fmt.Errorf("invalid body filter: %s", err)
+		}
+	}
+
+	return f, nil
+}
+
+// Match returns whether an event with the given path, headers and body is
+// selected by the filter.
+func (f *EventFilter) Match(eventPath string, headers map[string]string, body string) bool {
+	if f == nil {
+		return true
+	}
+
+	if len(f.Paths) > 0 {
+		matched := false
+		for _, pattern := range f.Paths {
+			if ok, _ := path.Match(pattern, eventPath); ok {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	if len(f.Headers) > 0 {
+		values := make(map[string]string, len(headers))
+		for name, value := range headers {
+			values[strings.ToLower(name)] = value
+		}
+		for name, pattern := range f.Headers {
+			value, ok := values[name]
+			if !ok {
+				return false
+			}
+			if matched, _ := path.Match(pattern, value); !matched {
+				return false
+			}
+		}
+	}
+
+	if f.Body != nil {
+		var data interface{}
+		if err := json.Unmarshal([]byte(body), &data); err != nil {
+			return false
+		}
+		result, ok := f.Body.Run(data).Next()
+		if !ok {
+			return false
+		}
+		if _, isErr := result.(error); isErr || result == nil || result == false {
+			return false
+		}
+	}
+
+	return true
+}
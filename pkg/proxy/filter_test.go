@@ -0,0 +1,30 @@
+package proxy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventFilter(t *testing.T) {
+	f, err := NewEventFilter(nil, nil, "")
+	require.NoError(t, err)
+	require.Nil(t, f)
+	require.True(t, f.Match("/", nil, ""))
+
+	f, err = NewEventFilter([]string{"/stripe/*"}, []string{"X-Event-Type: invoice.*"}, `.type == "invoice.paid"`)
+	require.NoError(t, err)
+
+	headers := map[string]string{"x-event-type": "invoice.paid"}
+	require.True(t, f.Match("/stripe/webhooks", headers, `{"type":"invoice.paid"}`))
+	require.False(t, f.Match("/github/webhooks", headers, `{"type":"invoice.paid"}`))
+	require.False(t, f.Match("/stripe/webhooks", map[string]string{"x-event-type": "charge.failed"}, `{"type":"invoice.paid"}`))
+	require.False(t, f.Match("/stripe/webhooks", nil, `{"type":"invoice.paid"}`))
+	require.False(t, f.Match("/stripe/webhooks", headers, `{"type":"invoice.created"}`))
+	require.False(t, f.Match("/stripe/webhooks", headers, `not json`))
+
+	_, err = NewEventFilter(nil, []string{"X-Event-Type"}, "")
+	require.Error(t, err)
+	_, err = NewEventFilter(nil, nil, ".type ==")
+	require.Error(t, err)
+}
@@ -0,0 +1,479 @@
+package proxy
+
+import (
+	"crypto/tls"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/websocket"
+	log "github.com/sirupsen/logrus"
+)
+
+//go:embed inspector.html
+var inspectorHTML []byte
+
+// DefaultInspectorAddr is the address the inspector listens on by default.
+const DefaultInspectorAddr = "localhost:4040"
+
+// InspectedEvent is an event forwarded to the local server, as shown in the
+// inspector.
+type InspectedEvent struct {
+	ID              int               `json:"id"`
+	EventID         string            `json:"event_id,omitempty"`
+	Time            time.Time         `json:"time"`
+	Method          string            `json:"method"`
+	URL             string            `json:"url"`
+	Headers         map[string]string `json:"headers"`
+	Body            string            `json:"body"`
+	BodySize        int               `json:"body_size"`
+	Status          int               `json:"status,omitempty"`
+	ResponseHeaders map[string]string `json:"response_headers,omitempty"`
+	ResponseBody    string            `json:"response_body,omitempty"`
+	Error           string            `json:"error,omitempty"`
+	// Unexpected is why the response doesn't match the --expect flags
+	Unexpected string `json:"unexpected,omitempty"`
+	DurationMs int64  `json:"duration_ms"`
+	// Timing is the latency breakdown of the forwarded events
+	Timing *Timing `json:"timing,omitempty"`
+	// Replay is set for the events replayed from the inspector, which are
+	// only sent to the local server
+	Replay bool `json:"replay,omitempty"`
+	// BodyTruncated and ResponseBodyTruncated are set when the bodies are
+	// larger than the memory threshold of the inspector. The full request
+	// body can be downloaded from the inspector.
+	BodyTruncated         bool `json:"body_truncated,omitempty"`
+	ResponseBodyTruncated bool `json:"response_body_truncated,omitempty"`
+
+	// rawBody is the request body as forwarded, Body is decompressed for
+	// display
+	rawBody storedBody
+}
+
+// Inspector is a local web UI listing the events forwarded by the proxy,
+// with their request and response, and replaying them to the local server.
+type Inspector struct {
+	mu     sync.Mutex
+	events []*InspectedEvent
+	lastID int
+	max    int
+	client *http.Client
+	stats  *Stats
+	// maxBodySize is the size above which request bodies are kept in temp
+	// files of dir, and bodies are truncated for display
+	maxBodySize int
+	dir         string
+	// addr is the address the inspector listens on, that the Host of the
+	// requests must match
+	addr string
+}
+
+// NewInspector creates an inspector keeping the last max events. Request
+// bodies larger than maxBodySize are kept in temp files instead of memory,
+// which are removed by Close.
+func NewInspector(max int, maxBodySize int, insecure bool) *Inspector {
+	return &Inspector{
+		max:         max,
+		maxBodySize: maxBodySize,
+		stats:       NewStats(),
+		client: &http.Client{
+			Timeout: 30 * time.Second,
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: insecure},
+			},
+		},
+	}
+}
+
+// Record adds an event to the inspector.
+func (i *Inspector) Record(event *InspectedEvent) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.lastID++
+	event.ID = i.lastID
+	i.events = append(i.events, event)
+	if len(i.events) > i.max {
+		evicted := i.events[:len(i.events)-i.max]
+		i.events = i.events[len(i.events)-i.max:]
+
+		// Replays share the body of the original event
+		retained := map[string]bool{}
+		for _, event := range i.events {
+			retained[event.rawBody.file] = true
+		}
+		for _, event := range evicted {
+			if !retained[event.rawBody.file] {
+				event.rawBody.remove()
+			}
+		}
+	}
+}
+
+// Close removes the temp files of the bodies kept by the inspector.
+func (i *Inspector) Close() error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.dir == "" {
+		return nil
+	}
+	return os.RemoveAll(i.dir)
+}
+
+// newEvent creates an event with its request, keeping its body in a temp
+// file when it's too large.
+func (i *Inspector) newEvent(method string, url string, headers map[string]string, body string) (*InspectedEvent, error) {
+	i.mu.Lock()
+	raw, err := storeBody(&i.dir, body, i.maxBodySize)
+	i.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	event := &InspectedEvent{
+		Time:     time.Now(),
+		Method:   method,
+		URL:      url,
+		Headers:  headers,
+		BodySize: len(body),
+		rawBody:  raw,
+	}
+	event.Body, event.BodyTruncated = truncateBody(string(decodeBody(headerValue(headers, "Content-Encoding"), []byte(body))), i.maxBodySize)
+	return event, nil
+}
+
+// setResponse sets the response of the local server to an event.
+func (i *Inspector) setResponse(event *InspectedEvent, resp *http.Response, body []byte) {
+	event.Status = resp.StatusCode
+	event.ResponseHeaders = flattenHeaders(resp.Header)
+	event.ResponseBody, event.ResponseBodyTruncated = truncateBody(string(decodeBody(resp.Header.Get("Content-Encoding"), body)), i.maxBodySize)
+}
+
+// Events returns the recorded events, most recent first.
+func (i *Inspector) Events() []*InspectedEvent {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	events := make([]*InspectedEvent, len(i.events))
+	for j, event := range i.events {
+		events[len(i.events)-1-j] = event
+	}
+	return events
+}
+
+func (i *Inspector) get(id int) *InspectedEvent {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	for _, event := range i.events {
+		if event.ID == id {
+			return event
+		}
+	}
+	return nil
+}
+
+// Replay sends the request of an event to the local server again, and
+// records the result as a new event. The event is sent to target instead when
+// set, a port or a URL whose scheme and host replace the original ones, e.g.
+// when the local server was restarted on another port.
+func (i *Inspector) Replay(event *InspectedEvent, target string) *InspectedEvent {
+	replay := &InspectedEvent{
+		EventID:       event.EventID,
+		Time:          time.Now(),
+		Method:        event.Method,
+		URL:           event.URL,
+		Headers:       event.Headers,
+		Body:          event.Body,
+		BodySize:      event.BodySize,
+		BodyTruncated: event.BodyTruncated,
+		Replay:        true,
+		rawBody:       event.rawBody,
+	}
+
+	var err error
+	replay.URL, err = replayURL(event.URL, target)
+	if err != nil {
+		replay.Error = err.Error()
+		i.Record(replay)
+		return replay
+	}
+
+	body, err := event.rawBody.read()
+	if err != nil {
+		replay.Error = err.Error()
+		i.Record(replay)
+		return replay
+	}
+
+	req, err := http.NewRequest(event.Method, replay.URL, strings.NewReader(body))
+	if err != nil {
+		replay.Error = err.Error()
+		i.Record(replay)
+		return replay
+	}
+	for name, value := range event.Headers {
+		req.Header.Set(name, value)
+	}
+
+	start := time.Now()
+	res, err := i.client.Do(req)
+	replay.DurationMs = time.Since(start).Milliseconds()
+	if err != nil {
+		replay.Error = err.Error()
+	} else {
+		body, _ := ioutil.ReadAll(res.Body)
+		res.Body.Close()
+		i.setResponse(replay, res, body)
+	}
+
+	i.Record(replay)
+	return replay
+}
+
+// ReplaySummary is the result of replaying several events at once.
+type ReplaySummary struct {
+	Replayed  int               `json:"replayed"`
+	Succeeded int               `json:"succeeded"`
+	Failed    int               `json:"failed"`
+	Events    []*InspectedEvent `json:"events"`
+}
+
+// ReplayAll replays the events one after the other, in the given order, and
+// returns the aggregated results. A replay succeeded when the local server
+// responded with a 2xx status.
+func (i *Inspector) ReplayAll(events []*InspectedEvent, target string) ReplaySummary {
+	summary := ReplaySummary{Events: []*InspectedEvent{}}
+	for _, event := range events {
+		replay := i.Replay(event, target)
+		summary.Replayed++
+		if replay.Error == "" && replay.Status >= 200 && replay.Status < 300 {
+			summary.Succeeded++
+		} else {
+			summary.Failed++
+		}
+		summary.Events = append(summary.Events, replay)
+	}
+	return summary
+}
+
+func (i *Inspector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := i.checkOrigin(r); err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+	// Requiring JSON makes browsers check cross-origin POSTs with a
+	// preflight request, which the inspector doesn't allow
+	if r.Method == http.MethodPost && !isJSON(r.Header.Get("Content-Type")) {
+		http.Error(w, "expected an application/json request", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	switch {
+	case r.URL.Path == "/":
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(inspectorHTML)
+
+	case r.URL.Path == "/api/events" && r.Method == http.MethodGet:
+		writeInspectorJSON(w, http.StatusOK, i.Events())
+
+	case r.URL.Path == "/api/stats" && r.Method == http.MethodGet:
+		writeInspectorJSON(w, http.StatusOK, i.stats.Snapshot())
+
+	case r.URL.Path == "/api/replay" && r.Method == http.MethodPost:
+		var request struct {
+			IDs    []int  `json:"ids"`
+			Target string `json:"target"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		events := make([]*InspectedEvent, 0, len(request.IDs))
+		for _, id := range request.IDs {
+			event := i.get(id)
+			if event == nil {
+				http.Error(w, fmt.Sprintf("event %d not found", id), http.StatusNotFound)
+				return
+			}
+			events = append(events, event)
+		}
+		writeInspectorJSON(w, http.StatusOK, i.ReplayAll(events, request.Target))
+
+	case strings.HasSuffix(r.URL.Path, "/replay") && r.Method == http.MethodPost:
+		event := i.eventFromPath(r.URL.Path, "/replay")
+		if event == nil {
+			http.NotFound(w, r)
+			return
+		}
+		writeInspectorJSON(w, http.StatusOK, i.Replay(event, r.URL.Query().Get("target")))
+
+	case strings.HasSuffix(r.URL.Path, "/body") && r.Method == http.MethodGet:
+		event := i.eventFromPath(r.URL.Path, "/body")
+		if event == nil {
+			http.NotFound(w, r)
+			return
+		}
+		body, err := event.rawBody.read()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if contentType := headerValue(event.Headers, "Content-Type"); contentType != "" {
+			w.Header().Set("Content-Type", contentType)
+		}
+		w.Write(decodeBody(headerValue(event.Headers, "Content-Encoding"), []byte(body)))
+
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// eventFromPath returns the event of an /api/events/{id}{suffix} path.
+func (i *Inspector) eventFromPath(path string, suffix string) *InspectedEvent {
+	if !strings.HasPrefix(path, "/api/events/") {
+		return nil
+	}
+	id, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(path, "/api/events/"), suffix))
+	if err != nil {
+		return nil
+	}
+	return i.get(id)
+}
+
+// replayURL returns the URL of an event replayed to target.
+func replayURL(eventURL string, target string) (string, error) {
+	if target == "" {
+		return eventURL, nil
+	}
+
+	u, err := url.Parse(eventURL)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := strconv.Atoi(target); err == nil {
+		u.Host = u.Hostname() + ":" + target
+		return u.String(), nil
+	}
+
+	t, err := url.Parse(target)
+	if err != nil || t.Scheme == "" || t.Host == "" {
+		return "", fmt.Errorf("invalid replay target %q, expected a port or a URL like http://localhost:3000", target)
+	}
+	// The events, with their signature headers, are only replayed to the
+	// local machine
+	if !isLoopbackHost(t.Hostname()) {
+		return "", fmt.Errorf("invalid replay target %q, only localhost can be replayed to", target)
+	}
+	u.Scheme = t.Scheme
+	u.Host = t.Host
+	return u.String(), nil
+}
+
+// checkOrigin rejects the requests not made from the inspector itself, as
+// the captured events can't be exposed to other web pages. The Host is checked
+// against DNS rebinding, and the Origin sent by browsers with cross-origin
+// requests against the other pages.
+func (i *Inspector) checkOrigin(r *http.Request) error {
+	if !i.validHost(r.Host) {
+		return fmt.Errorf("invalid host %q", r.Host)
+	}
+	if origin := r.Header.Get("Origin"); origin != "" && origin != "http://"+r.Host {
+		return fmt.Errorf("invalid origin %q", origin)
+	}
+	return nil
+}
+
+// validHost returns whether host is the address the inspector listens on, or
+// a loopback host with the same port.
+func (i *Inspector) validHost(host string) bool {
+	if i.addr != "" && strings.EqualFold(host, i.addr) {
+		return true
+	}
+
+	hostname, port, err := net.SplitHostPort(host)
+	if err != nil || !isLoopbackHost(hostname) {
+		return false
+	}
+	if i.addr == "" {
+		return true
+	}
+	_, addrPort, err := net.SplitHostPort(i.addr)
+	return err == nil && port == addrPort
+}
+
+func isJSON(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && mediaType == "application/json"
+}
+
+func isLoopbackHost(host string) bool {
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// ListenAndServe serves the inspector on addr.
+func (i *Inspector) ListenAndServe(addr string) error {
+	i.addr = addr
+	return http.ListenAndServe(addr, i)
+}
+
+// inspect records a forwarded event in the inspector, with the response of
+// the local server or the error forwarding it. unexpected is why the response
+// doesn't match the --expect flags, if it doesn't.
+func (p *Proxy) inspect(webhookEvent *websocket.Attempt, req *http.Request, timing Timing, resp *http.Response, respBody []byte, err error, unexpected string) {
+	if p.cfg.Inspector == nil {
+		return
+	}
+
+	succeeded := resp != nil && resp.StatusCode >= 200 && resp.StatusCode < 300 && unexpected == ""
+	p.cfg.Inspector.stats.Record(webhookEvent.Body.Path, succeeded, timing.Total())
+
+	event, storeErr := p.cfg.Inspector.newEvent(req.Method, req.URL.String(), flattenHeaders(req.Header), webhookEvent.Body.Request.DataString)
+	if storeErr != nil {
+		p.cfg.Log.WithFields(log.Fields{
+			"prefix": "proxy.Proxy.inspect",
+		}).Errorf("Failed to keep the body of event %s: %v", webhookEvent.Body.EventID, storeErr)
+		return
+	}
+	event.EventID = webhookEvent.Body.EventID
+	event.DurationMs = timing.TotalMs
+	event.Timing = &timing
+	event.Unexpected = unexpected
+	if err != nil {
+		event.Error = err.Error()
+	} else {
+		p.cfg.Inspector.setResponse(event, resp, respBody)
+	}
+
+	p.cfg.Inspector.Record(event)
+}
+
+func writeInspectorJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
+func flattenHeaders(header http.Header) map[string]string {
+	headers := make(map[string]string, len(header))
+	for name := range header {
+		headers[name] = header.Get(name)
+	}
+	return headers
+}
@@ -0,0 +1,250 @@
+package proxy
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInspectorRecord(t *testing.T) {
+	inspector := NewInspector(2, 0, false)
+	inspector.Record(&InspectedEvent{EventID: "evt_1"})
+	inspector.Record(&InspectedEvent{EventID: "evt_2"})
+	inspector.Record(&InspectedEvent{EventID: "evt_3"})
+
+	events := inspector.Events()
+	require.Len(t, events, 2)
+	require.Equal(t, "evt_3", events[0].EventID)
+	require.Equal(t, 3, events[0].ID)
+	require.Equal(t, "evt_2", events[1].EventID)
+}
+
+func TestInspectorReplay(t *testing.T) {
+	local := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		require.Equal(t, `{"type":"invoice.paid"}`, string(body))
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte("ok"))
+	}))
+	defer local.Close()
+
+	inspector := NewInspector(10, 0, false)
+	inspector.Record(&InspectedEvent{
+		EventID: "evt_1",
+		Method:  http.MethodPost,
+		URL:     local.URL + "/webhooks",
+		Headers: map[string]string{"Content-Type": "application/json"},
+		Body:    `{"type":"invoice.paid"}`,
+		rawBody: storedBody{data: `{"type":"invoice.paid"}`},
+	})
+
+	ui := httptest.NewServer(inspector)
+	defer ui.Close()
+
+	res, err := http.Post(ui.URL+"/api/events/1/replay?target="+local.URL, "application/json", nil)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var replay InspectedEvent
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&replay))
+	require.True(t, replay.Replay)
+	require.Equal(t, http.StatusAccepted, replay.Status)
+	require.Equal(t, "ok", replay.ResponseBody)
+	require.Len(t, inspector.Events(), 2)
+
+	res, err = http.Post(ui.URL+"/api/events/42/replay", "application/json", nil)
+	require.NoError(t, err)
+	res.Body.Close()
+	require.Equal(t, http.StatusNotFound, res.StatusCode)
+}
+
+func TestInspectorReplayAll(t *testing.T) {
+	local := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer local.Close()
+
+	inspector := NewInspector(10, 0, false)
+	for _, path := range []string{"/ok", "/fail", "/ok"} {
+		inspector.Record(&InspectedEvent{Method: http.MethodPost, URL: local.URL + path})
+	}
+
+	ui := httptest.NewServer(inspector)
+	defer ui.Close()
+
+	res, err := http.Post(ui.URL+"/api/replay", "application/json", strings.NewReader(`{"ids":[1,2,3]}`))
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var summary ReplaySummary
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&summary))
+	require.Equal(t, 3, summary.Replayed)
+	require.Equal(t, 2, summary.Succeeded)
+	require.Equal(t, 1, summary.Failed)
+	require.Len(t, summary.Events, 3)
+	require.Len(t, inspector.Events(), 6)
+
+	res, err = http.Post(ui.URL+"/api/replay", "application/json", strings.NewReader(`{"ids":[42]}`))
+	require.NoError(t, err)
+	res.Body.Close()
+	require.Equal(t, http.StatusNotFound, res.StatusCode)
+}
+
+func TestReplayURL(t *testing.T) {
+	url, err := replayURL("http://localhost:3000/webhooks?a=1", "")
+	require.NoError(t, err)
+	require.Equal(t, "http://localhost:3000/webhooks?a=1", url)
+
+	url, err = replayURL("http://localhost:3000/webhooks?a=1", "3001")
+	require.NoError(t, err)
+	require.Equal(t, "http://localhost:3001/webhooks?a=1", url)
+
+	url, err = replayURL("http://localhost:3000/webhooks", "https://127.0.0.1:8443")
+	require.NoError(t, err)
+	require.Equal(t, "https://127.0.0.1:8443/webhooks", url)
+
+	_, err = replayURL("http://localhost:3000/webhooks", "localhost")
+	require.Error(t, err)
+
+	for _, target := range []string{"https://attacker.example", "http://10.0.0.1:3000", "http://localhost.attacker.example"} {
+		_, err = replayURL("http://localhost:3000/webhooks", target)
+		require.Error(t, err, target)
+		require.Contains(t, err.Error(), "only localhost")
+	}
+}
+
+func TestInspectorReplayRejections(t *testing.T) {
+	var replayed int32
+	local := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&replayed, 1)
+	}))
+	defer local.Close()
+
+	inspector := NewInspector(10, 0, false)
+	inspector.Record(&InspectedEvent{Method: http.MethodPost, URL: local.URL + "/webhooks"})
+	inspector.addr = "localhost:4040"
+
+	for _, c := range []struct {
+		name        string
+		path        string
+		body        string
+		host        string
+		origin      string
+		contentType string
+		status      int
+	}{
+		{"no content type", "/api/events/1/replay", "", "localhost:4040", "", "", http.StatusUnsupportedMediaType},
+		{"form", "/api/replay", "ids=1", "localhost:4040", "", "application/x-www-form-urlencoded", http.StatusUnsupportedMediaType},
+		{"text", "/api/replay", `{"ids":[1]}`, "localhost:4040", "", "text/plain", http.StatusUnsupportedMediaType},
+		{"other origin", "/api/replay", `{"ids":[1]}`, "localhost:4040", "https://attacker.example", "application/json", http.StatusForbidden},
+		{"other host", "/api/replay", `{"ids":[1]}`, "attacker.example:4040", "", "application/json", http.StatusForbidden},
+		{"remote target", "/api/events/1/replay?target=https://attacker.example", "", "localhost:4040", "", "application/json", http.StatusOK},
+	} {
+		req := httptest.NewRequest(http.MethodPost, c.path, strings.NewReader(c.body))
+		req.Host = c.host
+		if c.origin != "" {
+			req.Header.Set("Origin", c.origin)
+		}
+		if c.contentType != "" {
+			req.Header.Set("Content-Type", c.contentType)
+		}
+		rec := httptest.NewRecorder()
+		inspector.ServeHTTP(rec, req)
+		require.Equal(t, c.status, rec.Code, c.name)
+	}
+
+	// The remote target is reported as the error of the replay
+	events := inspector.Events()
+	require.Contains(t, events[0].Error, "only localhost")
+	require.Equal(t, int32(0), atomic.LoadInt32(&replayed))
+}
+
+func TestInspectorLargeBodies(t *testing.T) {
+	inspector := NewInspector(1, 8, false)
+	defer inspector.Close()
+
+	event, err := inspector.newEvent(http.MethodPost, "http://localhost:3000/webhooks", map[string]string{"Content-Type": "application/json"}, `{"type":"invoice.paid"}`)
+	require.NoError(t, err)
+	require.Equal(t, `{"type":`, event.Body)
+	require.True(t, event.BodyTruncated)
+	require.Equal(t, 23, event.BodySize)
+	require.NotEmpty(t, event.rawBody.file)
+	inspector.Record(event)
+
+	ui := httptest.NewServer(inspector)
+	defer ui.Close()
+
+	res, err := http.Get(ui.URL + "/api/events/1/body")
+	require.NoError(t, err)
+	body, _ := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	require.Equal(t, `{"type":"invoice.paid"}`, string(body))
+	require.Equal(t, "application/json", res.Header.Get("Content-Type"))
+
+	// The body is removed with the last event using it
+	small, err := inspector.newEvent(http.MethodPost, "http://localhost:3000/webhooks", nil, "{}")
+	require.NoError(t, err)
+	require.Empty(t, small.rawBody.file)
+	inspector.Record(small)
+	_, err = os.Stat(event.rawBody.file)
+	require.True(t, os.IsNotExist(err))
+
+	require.NoError(t, inspector.Close())
+	_, err = os.Stat(inspector.dir)
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestTruncateBody(t *testing.T) {
+	body, truncated := truncateBody("héllo", 2)
+	require.Equal(t, "h", body)
+	require.True(t, truncated)
+
+	body, truncated = truncateBody("héllo", 0)
+	require.Equal(t, "héllo", body)
+	require.False(t, truncated)
+}
+
+func TestInspectorRejectsOtherOrigins(t *testing.T) {
+	inspector := NewInspector(10, 0, false)
+	inspector.Record(&InspectedEvent{EventID: "evt_1", rawBody: storedBody{data: "secret"}})
+	inspector.addr = "localhost:4040"
+
+	for _, c := range []struct {
+		path   string
+		host   string
+		origin string
+		status int
+	}{
+		{"/api/events", "localhost:4040", "", http.StatusOK},
+		{"/api/events", "127.0.0.1:4040", "http://127.0.0.1:4040", http.StatusOK},
+		{"/api/events/1/body", "[::1]:4040", "", http.StatusOK},
+		// DNS rebinding
+		{"/api/events", "attacker.example:4040", "", http.StatusForbidden},
+		{"/api/events/1/body", "attacker.example:4040", "http://attacker.example:4040", http.StatusForbidden},
+		{"/api/events", "localhost:4041", "", http.StatusForbidden},
+		// Cross-origin requests
+		{"/api/events", "localhost:4040", "https://attacker.example", http.StatusForbidden},
+		{"/api/events/1/body", "localhost:4040", "null", http.StatusForbidden},
+	} {
+		req := httptest.NewRequest(http.MethodGet, c.path, nil)
+		req.Host = c.host
+		if c.origin != "" {
+			req.Header.Set("Origin", c.origin)
+		}
+		rec := httptest.NewRecorder()
+		inspector.ServeHTTP(rec, req)
+		require.Equal(t, c.status, rec.Code, "%s with host %s and origin %q", c.path, c.host, c.origin)
+	}
+}
@@ -0,0 +1,73 @@
+package proxy
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/websocket"
+)
+
+// Actions reporting to Hookdeck an event the local server couldn't handle,
+// e.g. because it's down.
+const (
+	// LocalErrorNack reports the attempt as failed, the default
+	LocalErrorNack = "error"
+	// LocalErrorStatus responds with a synthetic status code
+	LocalErrorStatus = "status"
+	// LocalErrorSkip doesn't respond, leaving the attempt to time out
+	LocalErrorSkip = "skip"
+)
+
+// OnLocalError is what the CLI reports to Hookdeck when an event can't be
+// forwarded to the local server. Status is the synthetic status code of
+// LocalErrorStatus.
+type OnLocalError struct {
+	Action string
+	Status int
+}
+
+// ParseOnLocalError parses the value of the --on-local-error flag: error,
+// skip, or status:<code>, e.g. status:503.
+func ParseOnLocalError(value string) (OnLocalError, error) {
+	switch {
+	case value == LocalErrorNack || value == LocalErrorSkip:
+		return OnLocalError{Action: value}, nil
+	case strings.HasPrefix(value, LocalErrorStatus+":"):
+		status, err := strconv.Atoi(strings.TrimPrefix(value, LocalErrorStatus+":"))
+		if err != nil || status < 100 || status > 599 {
+			return OnLocalError{}, fmt.Errorf("invalid on-local-error status in %q, expected a status code between 100 and 599", value)
+		}
+		return OnLocalError{Action: LocalErrorStatus, Status: status}, nil
+	default:
+		return OnLocalError{}, fmt.Errorf("invalid on-local-error %q, expected error, status:<code> or skip", value)
+	}
+}
+
+// failAttempt reports to Hookdeck an event the local server couldn't
+// handle, as set with --on-local-error.
+func (p *Proxy) failAttempt(webhookEvent *websocket.Attempt, cause error) {
+	switch {
+	case p.cfg.NoAck:
+		p.nackAttempt(webhookEvent)
+	case p.cfg.OnLocalError.Action == LocalErrorSkip:
+		p.cfg.Log.WithFields(log.Fields{
+			"prefix": "proxy.Proxy.failAttempt",
+		}).Debugf("Not responding to attempt %s: %v", webhookEvent.Body.AttemptId, cause)
+	case p.cfg.OnLocalError.Action == LocalErrorStatus:
+		p.sendMessage(&websocket.OutgoingMessage{
+			AttemptResponse: &websocket.AttemptResponse{
+				Event: "attempt_response",
+				Body: websocket.AttemptResponseBody{
+					AttemptId: webhookEvent.Body.AttemptId,
+					CLIPath:   webhookEvent.Body.Path,
+					Status:    p.cfg.OnLocalError.Status,
+					Data:      fmt.Sprintf("Hookdeck CLI: %s", cause),
+				},
+			}})
+	default:
+		p.nackAttempt(webhookEvent)
+	}
+}
@@ -0,0 +1,26 @@
+package proxy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOnLocalError(t *testing.T) {
+	onLocalError, err := ParseOnLocalError("error")
+	require.NoError(t, err)
+	require.Equal(t, OnLocalError{Action: LocalErrorNack}, onLocalError)
+
+	onLocalError, err = ParseOnLocalError("status:503")
+	require.NoError(t, err)
+	require.Equal(t, OnLocalError{Action: LocalErrorStatus, Status: 503}, onLocalError)
+
+	onLocalError, err = ParseOnLocalError("skip")
+	require.NoError(t, err)
+	require.Equal(t, OnLocalError{Action: LocalErrorSkip}, onLocalError)
+
+	for _, value := range []string{"", "nack", "status", "status:", "status:99", "status:abc"} {
+		_, err := ParseOnLocalError(value)
+		require.Error(t, err, value)
+	}
+}
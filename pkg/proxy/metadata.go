@@ -0,0 +1,44 @@
+package proxy
+
+import (
+	"net/http"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/websocket"
+)
+
+// Headers describing the delivery, added to the forwarded requests unless
+// NoMetadataHeaders is set, so local handlers can correlate them with the
+// events of the dashboard.
+const (
+	eventIDHeader    = "X-Hookdeck-Event-Id"
+	attemptIDHeader  = "X-Hookdeck-Attempt-Id"
+	sourceNameHeader = "X-Hookdeck-Source-Name"
+	sessionHeader    = "X-Hookdeck-CLI-Session"
+)
+
+// setMetadataHeaders adds the delivery metadata headers of an event.
+func (p *Proxy) setMetadataHeaders(webhookEvent *websocket.Attempt, header http.Header) {
+	if p.cfg.NoMetadataHeaders {
+		return
+	}
+
+	header.Set(eventIDHeader, webhookEvent.Body.EventID)
+	header.Set(attemptIDHeader, webhookEvent.Body.AttemptId)
+	if name := p.sourceName(webhookEvent.Body.ConnectionId); name != "" {
+		header.Set(sourceNameHeader, name)
+	}
+	if p.sessionID != "" {
+		header.Set(sessionHeader, p.sessionID)
+	}
+}
+
+// sourceName returns the name of the source of a connection, empty when the
+// connection isn't one of the session.
+func (p *Proxy) sourceName(connectionID string) string {
+	for _, connection := range p.connections {
+		if connection.Id == connectionID && connection.Source != nil {
+			return connection.Source.Name
+		}
+	}
+	return ""
+}
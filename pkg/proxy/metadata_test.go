@@ -0,0 +1,49 @@
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	hookdecksdk "github.com/hookdeck/hookdeck-go-sdk"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/websocket"
+)
+
+func TestMetadataHeaders(t *testing.T) {
+	headers := make(chan http.Header, 2)
+	local := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers <- r.Header
+	}))
+	defer local.Close()
+
+	localURL, _ := url.Parse(local.URL)
+	connections := []*hookdecksdk.Connection{{Id: "web_1", Source: &hookdecksdk.Source{Name: "stripe"}}}
+	p := New(&Config{URL: localURL, Output: OutputJSON}, connections)
+	p.sessionID = "cs_1"
+
+	attempt := websocket.IncomingMessage{Attempt: &websocket.Attempt{
+		Body: websocket.AttemptBody{
+			Path:         "/webhooks",
+			EventID:      "evt_1",
+			AttemptId:    "atm_1",
+			ConnectionId: "web_1",
+			Request:      websocket.AttemptRequest{Method: http.MethodPost, Headers: []byte(`{}`)},
+		},
+	}}
+
+	p.processAttempt(attempt)
+	header := <-headers
+	require.Equal(t, "evt_1", header.Get("X-Hookdeck-Event-Id"))
+	require.Equal(t, "atm_1", header.Get("X-Hookdeck-Attempt-Id"))
+	require.Equal(t, "stripe", header.Get("X-Hookdeck-Source-Name"))
+	require.Equal(t, "cs_1", header.Get("X-Hookdeck-CLI-Session"))
+
+	p.cfg.NoMetadataHeaders = true
+	p.processAttempt(attempt)
+	header = <-headers
+	require.Empty(t, header.Get("X-Hookdeck-Event-Id"))
+	require.Empty(t, header.Get("X-Hookdeck-CLI-Session"))
+}
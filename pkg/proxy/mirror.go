@@ -0,0 +1,74 @@
+package proxy
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/ansi"
+	"github.com/hookdeck/hookdeck-cli/pkg/websocket"
+)
+
+// mirrorAttempt sends a copy of the request of an event to each of the
+// mirror targets. Their responses are only printed, Hookdeck receives the
+// response of the main target.
+func (p *Proxy) mirrorAttempt(webhookEvent *websocket.Attempt, header http.Header, timeout time.Duration) {
+	for _, target := range p.cfg.Mirrors {
+		go p.mirror(target, webhookEvent, header.Clone(), timeout)
+	}
+}
+
+func (p *Proxy) mirror(target *url.URL, webhookEvent *websocket.Attempt, header http.Header, timeout time.Duration) {
+	targetURL := target.Scheme + "://" + target.Host + target.Path + webhookEvent.Body.Path
+	method := webhookEvent.Body.Request.Method
+
+	req, err := http.NewRequest(method, targetURL, strings.NewReader(webhookEvent.Body.Request.DataString))
+	if err != nil {
+		p.printMirror(webhookEvent, method, targetURL, 0, 0, err)
+		return
+	}
+	req.Header = header
+
+	client := &http.Client{Timeout: timeout, Transport: p.transport}
+	start := time.Now()
+	res, err := client.Do(req)
+	if err != nil {
+		p.printMirror(webhookEvent, method, targetURL, 0, time.Since(start), err)
+		return
+	}
+	io.Copy(ioutil.Discard, res.Body)
+	res.Body.Close()
+
+	p.printMirror(webhookEvent, method, targetURL, res.StatusCode, time.Since(start), nil)
+}
+
+func (p *Proxy) printMirror(webhookEvent *websocket.Attempt, method string, targetURL string, status int, duration time.Duration, err error) {
+	if p.cfg.Output == OutputJSON {
+		output := attemptOutput{
+			EventID:  webhookEvent.Body.EventID,
+			Method:   method,
+			URL:      targetURL,
+			Status:   status,
+			Mirror:   true,
+			EventURL: p.eventURL(webhookEvent),
+		}
+		if err != nil {
+			output.Error = err.Error()
+		}
+		p.printAttemptJSON(output)
+		return
+	}
+
+	color := ansi.Color(p.out())
+	localTime := color.Faint(time.Now().Format(timeLayout))
+	if err != nil {
+		fmt.Fprintf(p.out(), "%s [%s] Mirror failed to %s %s: %v\n", localTime, color.Red("ERROR"), method, targetURL, err)
+		return
+	}
+	fmt.Fprintf(p.out(), "%s [%d] %s %s %s\n", localTime, ansi.ColorizeStatus(status), method, targetURL,
+		color.Faint(fmt.Sprintf("(mirror, %dms)", duration.Milliseconds())))
+}
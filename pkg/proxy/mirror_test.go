@@ -0,0 +1,57 @@
+package proxy
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/websocket"
+)
+
+func TestMirror(t *testing.T) {
+	type received struct {
+		path   string
+		header string
+		body   string
+	}
+	receivedCh := make(chan received, 2)
+	handler := func(status int) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			body, _ := ioutil.ReadAll(r.Body)
+			receivedCh <- received{path: r.URL.Path, header: r.Header.Get("X-Test"), body: string(body)}
+			w.WriteHeader(status)
+		}
+	}
+	primary := httptest.NewServer(handler(http.StatusOK))
+	defer primary.Close()
+	mirror := httptest.NewServer(handler(http.StatusInternalServerError))
+	defer mirror.Close()
+
+	primaryURL, _ := url.Parse(primary.URL)
+	mirrorURL, _ := url.Parse(mirror.URL + "/v2")
+	p := New(&Config{URL: primaryURL, Mirrors: []*url.URL{mirrorURL}, Output: OutputJSON}, nil)
+
+	p.processAttempt(websocket.IncomingMessage{Attempt: &websocket.Attempt{
+		Body: websocket.AttemptBody{
+			Path:    "/webhooks",
+			EventID: "evt_1",
+			Request: websocket.AttemptRequest{
+				Method:     http.MethodPost,
+				DataString: `{"ok":true}`,
+				Headers:    []byte(`{"X-Test":"1"}`),
+			},
+		},
+	}})
+
+	paths := map[string]received{}
+	for i := 0; i < 2; i++ {
+		r := <-receivedCh
+		paths[r.path] = r
+	}
+	require.Equal(t, received{path: "/webhooks", header: "1", body: `{"ok":true}`}, paths["/webhooks"])
+	require.Equal(t, received{path: "/v2/webhooks", header: "1", body: `{"ok":true}`}, paths["/v2/webhooks"])
+}
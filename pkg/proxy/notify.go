@@ -0,0 +1,56 @@
+package proxy
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/notify"
+	"github.com/hookdeck/hookdeck-cli/pkg/websocket"
+	log "github.com/sirupsen/logrus"
+)
+
+// notifyInterval is the minimum interval between two desktop notifications,
+// so that a burst of failures doesn't flood the desktop.
+const notifyInterval = 10 * time.Second
+
+// failureNotifier throttles the desktop notifications of failed events.
+type failureNotifier struct {
+	mu   sync.Mutex
+	last time.Time
+	// skipped is the number of failures since the last notification
+	skipped int
+}
+
+// notifyFailure shows a desktop notification for an event the local server
+// failed to handle, when enabled.
+func (p *Proxy) notifyFailure(webhookEvent *websocket.Attempt, reason string) {
+	if !p.cfg.Notify {
+		return
+	}
+
+	n := &p.notifier
+	n.mu.Lock()
+	if time.Since(n.last) < notifyInterval {
+		n.skipped++
+		n.mu.Unlock()
+		return
+	}
+	skipped := n.skipped
+	n.last = time.Now()
+	n.skipped = 0
+	n.mu.Unlock()
+
+	message := fmt.Sprintf("%s %s failed: %s", webhookEvent.Body.Request.Method, webhookEvent.Body.Path, reason)
+	if skipped > 0 {
+		message += fmt.Sprintf(" (and %d more failures)", skipped)
+	}
+
+	go func() {
+		if err := notify.Desktop("Hookdeck CLI", message); err != nil {
+			p.cfg.Log.WithFields(log.Fields{
+				"prefix": "proxy.Proxy.notifyFailure",
+			}).Debugf("Failed to show desktop notification: %v", err)
+		}
+	}()
+}
@@ -0,0 +1,47 @@
+package proxy
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Output formats of the forwarded events.
+const (
+	// OutputCompact prints a colored line for each forwarded event
+	OutputCompact = "compact"
+	// OutputJSON prints a JSON object per line for each forwarded event,
+	// for log collectors and scripts
+	OutputJSON = "json"
+)
+
+// attemptOutput is printed for each forwarded event with OutputJSON.
+type attemptOutput struct {
+	Time    string `json:"time"`
+	EventID string `json:"event_id"`
+	Method  string `json:"method"`
+	URL     string `json:"url"`
+	Status  int    `json:"status,omitempty"`
+	Error   string `json:"error,omitempty"`
+	// Unexpected is why the response doesn't match the --expect flags
+	Unexpected string `json:"unexpected,omitempty"`
+	Filtered   bool   `json:"filtered,omitempty"`
+	Mirror     bool   `json:"mirror,omitempty"`
+	// Throttled is what happened to an event overflowing the --throttle
+	// rate, dropped or nacked
+	Throttled string `json:"throttled,omitempty"`
+	// Timing is the latency breakdown of the events forwarded to the local
+	// server
+	Timing   *Timing `json:"timing,omitempty"`
+	EventURL string  `json:"event_url"`
+}
+
+func (p *Proxy) printAttemptJSON(output attemptOutput) {
+	output.Time = time.Now().Format(time.RFC3339)
+
+	data, err := json.Marshal(output)
+	if err != nil {
+		return
+	}
+	fmt.Fprintln(p.out(), string(data))
+}
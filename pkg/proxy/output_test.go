@@ -0,0 +1,54 @@
+package proxy
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/websocket"
+)
+
+func TestOutputJSONLines(t *testing.T) {
+	local := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer local.Close()
+	localURL, _ := url.Parse(local.URL)
+
+	stdout, err := os.Create(filepath.Join(t.TempDir(), "stdout"))
+	require.NoError(t, err)
+	defer func(previous *os.File) { os.Stdout = previous }(os.Stdout)
+	os.Stdout = stdout
+
+	p := New(&Config{URL: localURL, Output: OutputJSON}, nil)
+	for _, id := range []string{"evt_1", "evt_2"} {
+		p.processAttempt(websocket.IncomingMessage{Attempt: &websocket.Attempt{
+			Body: websocket.AttemptBody{
+				Path:    "/webhooks",
+				EventID: id,
+				Request: websocket.AttemptRequest{Method: http.MethodPost, Headers: []byte(`{}`)},
+			},
+		}})
+	}
+
+	data, err := ioutil.ReadFile(stdout.Name())
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 2)
+	for i, line := range lines {
+		var output attemptOutput
+		require.NoError(t, json.Unmarshal([]byte(line), &output), line)
+		require.Equal(t, []string{"evt_1", "evt_2"}[i], output.EventID)
+		require.Equal(t, http.MethodPost, output.Method)
+		require.Equal(t, local.URL+"/webhooks", output.URL)
+		require.Equal(t, http.StatusCreated, output.Status)
+	}
+}
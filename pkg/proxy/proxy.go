@@ -8,12 +8,15 @@ import (
 	"fmt"
 	"io/ioutil"
 	"math"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
 	"os/signal"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -50,8 +53,84 @@ type Config struct {
 	// Force use of unencrypted ws:// protocol instead of wss://
 	NoWSS    bool
 	Insecure bool
+	// SigningSecret is used to verify the Hookdeck signature of events before
+	// they are forwarded. The result is attached as a request header.
+	SigningSecret string
+	// ResignSecret is used to re-sign events with a locally configured secret
+	// before they are forwarded.
+	ResignSecret string
+	// Output is the format forwarded events are printed in, OutputCompact
+	// or OutputJSON
+	Output string
+	// Filter selects the events forwarded to the local server, all events
+	// are forwarded when nil
+	Filter *EventFilter
+	// RespondStatus and RespondBody override the response sent to Hookdeck
+	// for each event, whether or not the local server could be reached. No
+	// override is applied when RespondStatus is 0.
+	RespondStatus int
+	RespondBody   string
+	// NoAck reports every event as not delivered to Hookdeck, whatever the
+	// response of the local server, so that Hookdeck keeps retrying it. It
+	// takes precedence over RespondStatus and OnLocalError.
+	NoAck bool
+	// Expect marks the events whose response doesn't match it as failed,
+	// when set
+	Expect *Expectation
+	// Mirrors receive a copy of each forwarded event, their responses are
+	// only printed
+	Mirrors []*url.URL
+	// Inspector records the forwarded events for the local web UI, when
+	// enabled
+	Inspector *Inspector
+	// Notify shows a desktop notification when the local server fails to
+	// handle an event
+	Notify bool
+	// HTTP2 attempts HTTP/2 with HTTPS local servers
+	HTTP2 bool
+	// IdleConnTimeout is how long keep-alive connections to the local server
+	// stay open when idle, DefaultIdleConnTimeout when 0
+	IdleConnTimeout time.Duration
+	// ResponseHeaderTimeout limits how long to wait for the local server's
+	// response headers, no limit other than the event timeout when 0
+	ResponseHeaderTimeout time.Duration
+	// ConnectTimeout limits how long to wait for a connection to the local
+	// server, so that events fail fast when it's down, no limit other than
+	// the event timeout when 0
+	ConnectTimeout time.Duration
+	// FailOn ends the session with a FailOnError when the rate of failed
+	// events exceeds it, when set
+	FailOn *FailOn
+	// OnLocalError is what's reported to Hookdeck when an event can't be
+	// forwarded, NACKing it when unset
+	OnLocalError OnLocalError
+	// Throttle limits the rate of the events forwarded, when set
+	Throttle *Throttle
+	// CircuitBreaker fails the events without forwarding them while the
+	// local server is unreachable, when set
+	CircuitBreaker *CircuitBreaker
+	// MaxEvents ends the session once this number of events is handled, and
+	// Timeout once it has been running for this duration, unless 0
+	MaxEvents int
+	Timeout   time.Duration
+	// Sinks receive a copy of each event. With NoForward, the events are
+	// only written to them and acknowledged, without a local server.
+	Sinks     []Sink
+	NoForward bool
+	// Echo prints the headers and body of each event, along with NoForward
+	Echo bool
+	// Exec is a command run for each event instead of forwarding it, its
+	// exit code and output being the response
+	Exec string
+	// NoMetadataHeaders leaves out the X-Hookdeck-Event-Id, Attempt-Id,
+	// Source-Name and CLI-Session headers of the forwarded requests
+	NoMetadataHeaders bool
 }
 
+// DefaultIdleConnTimeout is how long idle keep-alive connections to the local
+// server are kept by default.
+const DefaultIdleConnTimeout = 90 * time.Second
+
 // A Proxy opens a websocket connection with Hookdeck, listens for incoming
 // webhook events, forwards them to the local endpoint and sends the response
 // back to Hookdeck.
@@ -59,7 +138,36 @@ type Proxy struct {
 	cfg             *Config
 	connections     []*hookdecksdk.Connection
 	webSocketClient *websocket.Client
+	// sender sends the responses to Hookdeck, the websocket client once
+	// connected
+	sender          messageSender
 	connectionTimer *time.Timer
+	notifier        failureNotifier
+	// transport is shared by the requests to the local server so that
+	// connections are kept alive between events
+	transport *http.Transport
+	// failures tracks the failed events for the FailOn threshold, and failed
+	// receives the error when it's exceeded
+	failures *failureWindow
+	failed   chan error
+	// breaker is the state of the CircuitBreaker, and throttle of the
+	// Throttle, when set
+	breaker  *circuitBreaker
+	throttle *tokenBucket
+	// received counts the events received, and done receives why the
+	// session ends once MaxEvents are handled. Events are handled with a
+	// read lock of handling, so that stopping waits for the ones in flight.
+	// stopped is closed when stopping, to end the waits for the throttle.
+	received int64
+	handling sync.RWMutex
+	stopping bool
+	done     chan string
+	stopped  chan struct{}
+	stopOnce sync.Once
+	// summary records the session for its Summary
+	summary *sessionSummary
+	// sessionID is the ID of the CLI session, once created
+	sessionID string
 }
 
 func withSIGTERMCancel(ctx context.Context, onCancel func()) context.Context {
@@ -105,6 +213,13 @@ func (p *Proxy) Run(parentCtx context.Context) error {
 		}).Debug("Ctrl+C received, cleaning up...")
 	})
 
+	var timeout <-chan time.Time
+	if p.cfg.Timeout > 0 {
+		timer := time.NewTimer(p.cfg.Timeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
 	s := ansi.StartNewSpinner("Getting ready...", p.cfg.Log.Out)
 
 	session, err := p.createSession(signalCtx)
@@ -117,6 +232,7 @@ func (p *Proxy) Run(parentCtx context.Context) error {
 		ansi.StopSpinner(s, "", p.cfg.Log.Out)
 		p.cfg.Log.Fatalf("Error while starting a new session")
 	}
+	p.sessionID = session.Id
 
 	// Main loop to keep attempting to connect to Hookdeck once
 	// we have created a session.
@@ -130,8 +246,10 @@ func (p *Proxy) Run(parentCtx context.Context) error {
 				Log:          p.cfg.Log,
 				NoWSS:        p.cfg.NoWSS,
 				EventHandler: websocket.EventHandlerFunc(p.processAttempt),
+				Proxy:        hookdeck.Proxy,
 			},
 		)
+		p.sender = p.webSocketClient
 
 		// Monitor the websocket for connection and update the spinner appropriately.
 		go func() {
@@ -153,8 +271,21 @@ func (p *Proxy) Run(parentCtx context.Context) error {
 		case <-signalCtx.Done():
 			ansi.StopSpinner(s, "", p.cfg.Log.Out)
 			return nil
+		case err := <-p.failed:
+			ansi.StopSpinner(s, "", p.cfg.Log.Out)
+			p.webSocketClient.Stop()
+			return err
+		case reason := <-p.done:
+			ansi.StopSpinner(s, "", p.cfg.Log.Out)
+			p.stop(reason)
+			return nil
+		case <-timeout:
+			ansi.StopSpinner(s, "", p.cfg.Log.Out)
+			p.stop(fmt.Sprintf("Stopped after %s", p.cfg.Timeout))
+			return nil
 		case <-p.webSocketClient.NotifyExpired:
 			if canConnect() {
+				p.summary.reconnected()
 				ansi.StopSpinner(s, "", p.cfg.Log.Out)
 				s = ansi.StartNewSpinner("Connection lost, reconnecting...", p.cfg.Log.Out)
 			} else {
@@ -183,6 +314,13 @@ func (p *Proxy) Run(parentCtx context.Context) error {
 			case <-signalCtx.Done():
 				p.connectionTimer.Stop()
 				return nil
+			case err := <-p.failed:
+				p.connectionTimer.Stop()
+				return err
+			case <-timeout:
+				p.connectionTimer.Stop()
+				p.stop(fmt.Sprintf("Stopped after %s", p.cfg.Timeout))
+				return nil
 			}
 		}
 	}
@@ -218,7 +356,7 @@ func (p *Proxy) createSession(ctx context.Context) (hookdeck.Session, error) {
 	}
 
 	for i := 0; i <= 5; i++ {
-		session, err = client.CreateSession(hookdeck.CreateSessionInput{
+		session, err = client.CreateSession(ctx, hookdeck.CreateSessionInput{
 			ConnectionIds: connectionIDs,
 		})
 
@@ -244,18 +382,58 @@ func (p *Proxy) processAttempt(msg websocket.IncomingMessage) {
 
 	webhookEvent := msg.Attempt
 
+	// Once the session is ending, e.g. after MaxEvents, the next events are
+	// left for Hookdeck to retry
+	p.handling.RLock()
+	defer p.handling.RUnlock()
+	received := atomic.AddInt64(&p.received, 1)
+	if p.stopping || (p.cfg.MaxEvents > 0 && received > int64(p.cfg.MaxEvents)) {
+		return
+	}
+	if p.cfg.MaxEvents > 0 && received == int64(p.cfg.MaxEvents) {
+		defer func() {
+			p.done <- fmt.Sprintf("Stopped after %d events", p.cfg.MaxEvents)
+		}()
+	}
+
 	p.cfg.Log.WithFields(log.Fields{
 		"prefix": "proxy.Proxy.processAttempt",
 	}).Debugf("Processing webhook event")
 
+	// The body is only copied when it needs to be decompressed, to keep
+	// memory bounded with large payloads
+	headers := attemptHeaders(webhookEvent)
+	body := webhookEvent.Body.Request.DataString
+	if encoding := headerValue(headers, "Content-Encoding"); encoding != "" {
+		body = string(decodeBody(encoding, []byte(body)))
+	}
+
+	if p.cfg.Filter != nil && !p.cfg.Filter.Match(webhookEvent.Body.Path, headers, body) {
+		p.skipAttempt(webhookEvent)
+		return
+	}
+
+	p.sinkAttempt(webhookEvent, headers, body)
+	queued := 0
+	if !p.cfg.NoForward {
+		var ok bool
+		if queued, ok = p.throttleAttempt(webhookEvent); !ok {
+			return
+		}
+	}
+	if p.cfg.Exec != "" {
+		p.execAttempt(webhookEvent, headers, body)
+		return
+	}
+	if p.cfg.NoForward {
+		p.ackAttempt(webhookEvent, headers, body)
+		return
+	}
+
 	if p.cfg.PrintJSON {
-		fmt.Println(webhookEvent.Body.Request.DataString)
+		fmt.Fprintln(p.out(), body)
 	} else {
 		url := p.cfg.URL.Scheme + "://" + p.cfg.URL.Host + p.cfg.URL.Path + webhookEvent.Body.Path
-		tr := &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: p.cfg.Insecure},
-		}
-
 		timeout := webhookEvent.Body.Request.Timeout
 		if timeout == 0 {
 			timeout = 1000 * 30
@@ -263,18 +441,18 @@ func (p *Proxy) processAttempt(msg websocket.IncomingMessage) {
 
 		client := &http.Client{
 			Timeout:   time.Duration(timeout) * time.Millisecond,
-			Transport: tr,
+			Transport: p.transport,
 		}
 
 		req, err := http.NewRequest(webhookEvent.Body.Request.Method, url, nil)
 		if err != nil {
-			fmt.Printf("Error: %s\n", err)
+			fmt.Fprintf(p.out(), "Error: %s\n", err)
 			return
 		}
 		x := make(map[string]json.RawMessage)
 		err = json.Unmarshal(webhookEvent.Body.Request.Headers, &x)
 		if err != nil {
-			fmt.Printf("Error: %s\n", err)
+			fmt.Fprintf(p.out(), "Error: %s\n", err)
 			return
 		}
 
@@ -282,78 +460,293 @@ func (p *Proxy) processAttempt(msg websocket.IncomingMessage) {
 			unquoted_value, _ := strconv.Unquote(string(value))
 			req.Header.Set(key, unquoted_value)
 		}
+		p.setMetadataHeaders(webhookEvent, req.Header)
+
+		if p.cfg.SigningSecret != "" || p.cfg.ResignSecret != "" {
+			verified := applySignature(p.cfg, webhookEvent.Body.Request.DataString, req.Header)
+			if p.cfg.SigningSecret != "" && !verified {
+				p.cfg.Log.WithFields(log.Fields{
+					"prefix": "proxy.Proxy.processAttempt",
+				}).Warnf("Signature verification failed for event %s", webhookEvent.Body.EventID)
+			}
+		}
 
+		// The body is forwarded as received, along with its Content-Encoding
 		req.Body = ioutil.NopCloser(strings.NewReader(webhookEvent.Body.Request.DataString))
 		req.ContentLength = int64(len(webhookEvent.Body.Request.DataString))
 
-		res, err := client.Do(req)
+		p.mirrorAttempt(webhookEvent, req.Header, client.Timeout)
+
+		if p.breaker != nil && !p.breaker.allow(time.Now()) {
+			p.breakerAttempt(webhookEvent, req)
+			return
+		}
 
+		timer := newRequestTimer(webhookEvent.ReceivedAt)
+		timer.queued = queued
+		res, err := client.Do(timer.trace(req))
+		p.recordReachable(err == nil)
+		var timing Timing
 		if err != nil {
-			color := ansi.Color(os.Stdout)
-			localTime := time.Now().Format(timeLayout)
-
-			errStr := fmt.Sprintf("%s [%s] Failed to %s: %v",
-				color.Faint(localTime),
-				color.Red("ERROR"),
-				webhookEvent.Body.Request.Method,
-				err,
-			)
+			timing = timer.done()
+			p.inspect(webhookEvent, req, timing, nil, nil, err, "")
+			p.notifyFailure(webhookEvent, err.Error())
+			p.recordOutcome(true)
+			p.summary.record(webhookEvent.Body.Path, 0, true, timing.Total())
+		}
 
-			fmt.Println(errStr)
-			p.webSocketClient.SendMessage(&websocket.OutgoingMessage{
-				ErrorAttemptResponse: &websocket.ErrorAttemptResponse{
-					Event: "attempt_response",
-					Body: websocket.ErrorAttemptBody{
-						AttemptId: webhookEvent.Body.AttemptId,
-						Error:     true,
-					},
-				}})
+		if err != nil && p.cfg.RespondStatus != 0 {
+			// The local server isn't required to respond to Hookdeck with a
+			// fixed response, e.g. to only observe the events
+			p.cfg.Log.WithFields(log.Fields{
+				"prefix": "proxy.Proxy.processAttempt",
+			}).Debugf("Failed to forward event %s: %v", webhookEvent.Body.EventID, err)
+			p.respondOverride(webhookEvent, req)
+		} else if err != nil {
+			if p.cfg.Output == OutputJSON {
+				p.printAttemptJSON(attemptOutput{
+					EventID:  webhookEvent.Body.EventID,
+					Method:   webhookEvent.Body.Request.Method,
+					URL:      url,
+					Error:    err.Error(),
+					Timing:   &timing,
+					EventURL: p.eventURL(webhookEvent),
+				})
+			} else {
+				color := ansi.Color(p.out())
+				localTime := time.Now().Format(timeLayout)
+
+				errStr := fmt.Sprintf("%s [%s] Failed to %s: %v",
+					color.Faint(localTime),
+					color.Red("ERROR"),
+					webhookEvent.Body.Request.Method,
+					err,
+				)
+
+				fmt.Fprintln(p.out(), errStr)
+			}
+			p.failAttempt(webhookEvent, err)
 		} else {
-			p.processEndpointResponse(webhookEvent, res)
+			p.processEndpointResponse(webhookEvent, res, timer)
 		}
 	}
 }
 
-func (p *Proxy) processEndpointResponse(webhookEvent *websocket.Attempt, resp *http.Response) {
+func (p *Proxy) processEndpointResponse(webhookEvent *websocket.Attempt, resp *http.Response, timer *requestTimer) {
 	localTime := time.Now().Format(timeLayout)
-	color := ansi.Color(os.Stdout)
-	var url = p.cfg.DashboardBaseURL + "/cli/events/" + webhookEvent.Body.EventID
-	if p.cfg.TeamMode == "console" {
-		url = p.cfg.ConsoleBaseURL + "/?event_id=" + webhookEvent.Body.EventID
-	}
-	outputStr := fmt.Sprintf("%s [%d] %s %s | %s",
-		color.Faint(localTime),
-		ansi.ColorizeStatus(resp.StatusCode),
-		resp.Request.Method,
-		resp.Request.URL,
-		url,
-	)
-	fmt.Println(outputStr)
-
-	buf, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
+	color := ansi.Color(p.out())
+	url := p.eventURL(webhookEvent)
+
+	buf, readErr := ioutil.ReadAll(resp.Body)
+	timing := timer.done()
+	unexpected := ""
+	if readErr == nil && p.cfg.Expect != nil {
+		unexpected = p.cfg.Expect.Check(resp.StatusCode, buf)
+	}
+
+	if p.cfg.Output == OutputJSON {
+		p.printAttemptJSON(attemptOutput{
+			EventID:    webhookEvent.Body.EventID,
+			Method:     resp.Request.Method,
+			URL:        resp.Request.URL.String(),
+			Status:     resp.StatusCode,
+			Unexpected: unexpected,
+			Timing:     &timing,
+			EventURL:   url,
+		})
+	} else {
+		outputStr := fmt.Sprintf("%s [%d] %s %s %s | %s",
+			color.Faint(localTime),
+			ansi.ColorizeStatus(resp.StatusCode),
+			resp.Request.Method,
+			resp.Request.URL,
+			color.Faint("("+timing.String()+")"),
+			url,
+		)
+		if unexpected != "" {
+			outputStr += " " + color.Red("✗ "+unexpected).String()
+		}
+		fmt.Fprintln(p.out(), outputStr)
+	}
+
+	if readErr != nil {
 		errStr := fmt.Sprintf("%s [%s] Failed to read response from endpoint, error = %v\n",
 			color.Faint(localTime),
 			color.Red("ERROR"),
-			err,
+			readErr,
 		)
 		log.Errorf(errStr)
 
 		return
 	}
+	p.inspect(webhookEvent, resp.Request, timing, resp, buf, nil, unexpected)
+	failed := resp.StatusCode < 200 || resp.StatusCode >= 300 || unexpected != ""
+	if failed {
+		reason := resp.Status
+		if unexpected != "" {
+			reason = unexpected
+		}
+		p.notifyFailure(webhookEvent, reason)
+	}
+	p.recordOutcome(failed)
+	p.summary.record(webhookEvent.Body.Path, resp.StatusCode, failed, timing.Total())
+
+	if p.cfg.NoAck || (unexpected != "" && p.cfg.Expect.Nack) {
+		p.nackAttempt(webhookEvent)
+		return
+	}
+
+	status, data := resp.StatusCode, string(buf)
+	if p.cfg.RespondStatus != 0 {
+		status, data = p.cfg.RespondStatus, p.cfg.RespondBody
+	}
+
+	p.sendMessage(&websocket.OutgoingMessage{
+		AttemptResponse: &websocket.AttemptResponse{
+			Event: "attempt_response",
+			Body: websocket.AttemptResponseBody{
+				AttemptId: webhookEvent.Body.AttemptId,
+				CLIPath:   webhookEvent.Body.Path,
+				Status:    status,
+				Data:      data,
+			},
+		}})
+}
+
+// stop ends the session once the events being handled are responded to.
+func (p *Proxy) stop(reason string) {
+	p.stopOnce.Do(func() { close(p.stopped) })
+	p.handling.Lock()
+	p.stopping = true
+	p.handling.Unlock()
 
 	if p.webSocketClient != nil {
-		p.webSocketClient.SendMessage(&websocket.OutgoingMessage{
-			AttemptResponse: &websocket.AttemptResponse{
-				Event: "attempt_response",
-				Body: websocket.AttemptResponseBody{
-					AttemptId: webhookEvent.Body.AttemptId,
-					CLIPath:   webhookEvent.Body.Path,
-					Status:    resp.StatusCode,
-					Data:      string(buf),
-				},
-			}})
+		p.webSocketClient.Stop()
+	}
+	fmt.Fprintln(p.cfg.Log.Out, reason)
+}
+
+// respondOverride responds to Hookdeck with the fixed response of the respond
+// flags, for an event the local server couldn't be reached for.
+func (p *Proxy) respondOverride(webhookEvent *websocket.Attempt, req *http.Request) {
+	if p.cfg.Output == OutputJSON {
+		p.printAttemptJSON(attemptOutput{
+			EventID:  webhookEvent.Body.EventID,
+			Method:   req.Method,
+			URL:      req.URL.String(),
+			Status:   p.cfg.RespondStatus,
+			EventURL: p.eventURL(webhookEvent),
+		})
+	} else {
+		color := ansi.Color(p.out())
+		fmt.Fprintf(p.out(), "%s [%d] %s %s | %s\n",
+			color.Faint(time.Now().Format(timeLayout)),
+			ansi.ColorizeStatus(p.cfg.RespondStatus),
+			req.Method,
+			req.URL,
+			p.eventURL(webhookEvent),
+		)
+	}
+
+	if p.cfg.NoAck {
+		p.nackAttempt(webhookEvent)
+		return
+	}
+
+	p.sendMessage(&websocket.OutgoingMessage{
+		AttemptResponse: &websocket.AttemptResponse{
+			Event: "attempt_response",
+			Body: websocket.AttemptResponseBody{
+				AttemptId: webhookEvent.Body.AttemptId,
+				CLIPath:   webhookEvent.Body.Path,
+				Status:    p.cfg.RespondStatus,
+				Data:      p.cfg.RespondBody,
+			},
+		}})
+}
+
+// skipAttempt acknowledges an event filtered out by the filter flags, without
+// forwarding it, so that Hookdeck doesn't retry it.
+func (p *Proxy) skipAttempt(webhookEvent *websocket.Attempt) {
+	if p.cfg.Output == OutputJSON {
+		p.printAttemptJSON(attemptOutput{
+			EventID:  webhookEvent.Body.EventID,
+			Method:   webhookEvent.Body.Request.Method,
+			URL:      webhookEvent.Body.Path,
+			Filtered: true,
+			EventURL: p.eventURL(webhookEvent),
+		})
+	} else {
+		color := ansi.Color(p.out())
+		fmt.Fprintln(p.out(), color.Faint(fmt.Sprintf("%s [FILTERED] %s %s | %s",
+			time.Now().Format(timeLayout),
+			webhookEvent.Body.Request.Method,
+			webhookEvent.Body.Path,
+			p.eventURL(webhookEvent),
+		)))
+	}
+
+	if p.cfg.NoAck {
+		p.nackAttempt(webhookEvent)
+		return
 	}
+
+	p.sendMessage(&websocket.OutgoingMessage{
+		AttemptResponse: &websocket.AttemptResponse{
+			Event: "attempt_response",
+			Body: websocket.AttemptResponseBody{
+				AttemptId: webhookEvent.Body.AttemptId,
+				CLIPath:   webhookEvent.Body.Path,
+				Status:    http.StatusOK,
+				Data:      "Filtered out by the Hookdeck CLI",
+			},
+		}})
+}
+
+// messageSender sends messages to Hookdeck. It's implemented by the websocket
+// client.
+type messageSender interface {
+	SendMessage(msg *websocket.OutgoingMessage)
+}
+
+// sendMessage sends a message to Hookdeck, when connected.
+func (p *Proxy) sendMessage(msg *websocket.OutgoingMessage) {
+	if p.sender != nil {
+		p.sender.SendMessage(msg)
+	}
+}
+
+// nackAttempt reports an event as not delivered to Hookdeck, so that it's
+// retried.
+func (p *Proxy) nackAttempt(webhookEvent *websocket.Attempt) {
+	p.sendMessage(&websocket.OutgoingMessage{
+		ErrorAttemptResponse: &websocket.ErrorAttemptResponse{
+			Event: "attempt_response",
+			Body: websocket.ErrorAttemptBody{
+				AttemptId: webhookEvent.Body.AttemptId,
+				Error:     true,
+			},
+		}})
+}
+
+// attemptHeaders returns the headers of the event request.
+func attemptHeaders(webhookEvent *websocket.Attempt) map[string]string {
+	raw := make(map[string]interface{})
+	json.Unmarshal(webhookEvent.Body.Request.Headers, &raw)
+
+	headers := make(map[string]string, len(raw))
+	for name, value := range raw {
+		headers[name] = fmt.Sprint(value)
+	}
+	return headers
+}
+
+// eventURL returns the dashboard URL of the event.
+func (p *Proxy) eventURL(webhookEvent *websocket.Attempt) string {
+	if p.cfg.TeamMode == "console" {
+		return p.cfg.ConsoleBaseURL + "/?event_id=" + webhookEvent.Body.EventID
+	}
+	return p.cfg.DashboardBaseURL + "/cli/events/" + webhookEvent.Body.EventID
 }
 
 //
@@ -370,7 +763,48 @@ func New(cfg *Config, connections []*hookdecksdk.Connection) *Proxy {
 		cfg:             cfg,
 		connections:     connections,
 		connectionTimer: time.NewTimer(0), // Defaults to no delay
+		transport:       newForwardTransport(cfg),
+		failed:          make(chan error, 1),
+		done:            make(chan string, 1),
+		stopped:         make(chan struct{}),
+		summary:         newSessionSummary(),
+	}
+	if cfg.FailOn != nil {
+		p.failures = &failureWindow{failOn: *cfg.FailOn}
+	}
+	if cfg.CircuitBreaker != nil {
+		p.breaker = &circuitBreaker{settings: *cfg.CircuitBreaker}
+	}
+	if cfg.Throttle != nil {
+		p.throttle = newTokenBucket(*cfg.Throttle, time.Now())
 	}
 
 	return p
 }
+
+// newForwardTransport returns the transport of the requests to the local
+// server.
+func newForwardTransport(cfg *Config) *http.Transport {
+	idleConnTimeout := cfg.IdleConnTimeout
+	if idleConnTimeout == 0 {
+		idleConnTimeout = DefaultIdleConnTimeout
+	}
+
+	// The dial is canceled with the request, on the event timeout
+	dialer := &net.Dialer{
+		Timeout:   cfg.ConnectTimeout,
+		KeepAlive: 30 * time.Second,
+	}
+
+	return &http.Transport{
+		DialContext:     dialer.DialContext,
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: cfg.Insecure},
+		// Don't negotiate a compression the original request didn't ask
+		// for, so the response is relayed to Hookdeck as sent
+		DisableCompression:    true,
+		ForceAttemptHTTP2:     cfg.HTTP2,
+		MaxIdleConnsPerHost:   10,
+		IdleConnTimeout:       idleConnTimeout,
+		ResponseHeaderTimeout: cfg.ResponseHeaderTimeout,
+	}
+}
@@ -0,0 +1,150 @@
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/websocket"
+)
+
+func TestMaxEvents(t *testing.T) {
+	var forwarded int32
+	local := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&forwarded, 1)
+	}))
+	defer local.Close()
+
+	localURL, _ := url.Parse(local.URL)
+	p := New(&Config{URL: localURL, Output: OutputJSON, MaxEvents: 2}, nil)
+
+	attempt := websocket.IncomingMessage{Attempt: &websocket.Attempt{
+		Body: websocket.AttemptBody{
+			Path:    "/webhooks",
+			EventID: "evt_1",
+			Request: websocket.AttemptRequest{Method: http.MethodPost, Headers: []byte(`{}`)},
+		},
+	}}
+
+	p.processAttempt(attempt)
+	require.Empty(t, p.done)
+	p.processAttempt(attempt)
+	require.Equal(t, "Stopped after 2 events", <-p.done)
+
+	// Events received once the session is ending aren't forwarded
+	p.processAttempt(attempt)
+	require.Equal(t, int32(2), atomic.LoadInt32(&forwarded))
+}
+
+// recordingSender records the messages sent to Hookdeck.
+type recordingSender struct {
+	messages []*websocket.OutgoingMessage
+}
+
+func (s *recordingSender) SendMessage(msg *websocket.OutgoingMessage) {
+	s.messages = append(s.messages, msg)
+}
+
+func TestNoAck(t *testing.T) {
+	local := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer local.Close()
+
+	localURL, _ := url.Parse(local.URL)
+	p := New(&Config{URL: localURL, Output: OutputJSON, NoAck: true}, nil)
+	sender := &recordingSender{}
+	p.sender = sender
+
+	p.processAttempt(websocket.IncomingMessage{Attempt: &websocket.Attempt{
+		Body: websocket.AttemptBody{
+			Path:      "/webhooks",
+			EventID:   "evt_1",
+			AttemptId: "atm_1",
+			Request:   websocket.AttemptRequest{Method: http.MethodPost, Headers: []byte(`{}`)},
+		},
+	}})
+
+	require.Len(t, sender.messages, 1)
+	require.Nil(t, sender.messages[0].AttemptResponse)
+	require.NotNil(t, sender.messages[0].ErrorAttemptResponse)
+	require.Equal(t, "atm_1", sender.messages[0].ErrorAttemptResponse.Body.AttemptId)
+	require.True(t, sender.messages[0].ErrorAttemptResponse.Body.Error)
+}
+
+func TestRespondOverride(t *testing.T) {
+	local := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("local response"))
+	}))
+	defer local.Close()
+	localURL, _ := url.Parse(local.URL)
+
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	downURL, _ := url.Parse(down.URL)
+	down.Close()
+
+	for name, u := range map[string]*url.URL{"forwarded": localURL, "local error": downURL} {
+		t.Run(name, func(t *testing.T) {
+			p := New(&Config{URL: u, Output: OutputJSON, RespondStatus: http.StatusAccepted, RespondBody: "accepted"}, nil)
+			sender := &recordingSender{}
+			p.sender = sender
+
+			p.processAttempt(websocket.IncomingMessage{Attempt: &websocket.Attempt{
+				Body: websocket.AttemptBody{
+					Path:      "/webhooks",
+					EventID:   "evt_1",
+					AttemptId: "atm_1",
+					Request:   websocket.AttemptRequest{Method: http.MethodPost, Headers: []byte(`{}`)},
+				},
+			}})
+
+			require.Len(t, sender.messages, 1)
+			require.NotNil(t, sender.messages[0].AttemptResponse)
+			require.Equal(t, websocket.AttemptResponseBody{
+				AttemptId: "atm_1",
+				CLIPath:   "/webhooks",
+				Status:    http.StatusAccepted,
+				Data:      "accepted",
+			}, sender.messages[0].AttemptResponse.Body)
+		})
+	}
+}
+
+func TestNoAckTakesPrecedence(t *testing.T) {
+	local := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	localURL, _ := url.Parse(local.URL)
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	downURL, _ := url.Parse(down.URL)
+	down.Close()
+	defer local.Close()
+
+	for name, cfg := range map[string]*Config{
+		"respond status":          {URL: localURL, RespondStatus: http.StatusAccepted},
+		"respond status on error": {URL: downURL, RespondStatus: http.StatusAccepted},
+		"on local error status":   {URL: downURL, OnLocalError: OnLocalError{Action: LocalErrorStatus, Status: http.StatusServiceUnavailable}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cfg.Output = OutputJSON
+			cfg.NoAck = true
+			p := New(cfg, nil)
+			sender := &recordingSender{}
+			p.sender = sender
+
+			p.processAttempt(websocket.IncomingMessage{Attempt: &websocket.Attempt{
+				Body: websocket.AttemptBody{
+					Path:      "/webhooks",
+					EventID:   "evt_1",
+					AttemptId: "atm_1",
+					Request:   websocket.AttemptRequest{Method: http.MethodPost, Headers: []byte(`{}`)},
+				},
+			}})
+
+			require.Len(t, sender.messages, 1)
+			require.Nil(t, sender.messages[0].AttemptResponse)
+			require.NotNil(t, sender.messages[0].ErrorAttemptResponse)
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package proxy
+
+import (
+	"crypto/hmac"
+	"net/http"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/signature"
+)
+
+// Headers used by Hookdeck to sign outgoing requests. The second header is
+// only set while a signing secret is being rotated.
+const (
+	signatureHeader  = "X-Hookdeck-Signature"
+	signature2Header = "X-Hookdeck-Signature-2"
+
+	// verifiedHeader is added to forwarded requests to report the result of
+	// the local signature verification.
+	verifiedHeader = "X-Hookdeck-CLI-Signature-Verified"
+)
+
+// verifySignature checks the Hookdeck signature headers against the body.
+func verifySignature(secret string, body string, header http.Header) bool {
+	expected := signature.Hookdeck(secret, body)
+
+	for _, name := range []string{signatureHeader, signature2Header} {
+		value := header.Get(name)
+		if value != "" && hmac.Equal([]byte(value), []byte(expected)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// applySignature verifies and/or re-signs a request before it is forwarded
+// to the local server, depending on which secrets are configured.
+func applySignature(cfg *Config, body string, header http.Header) bool {
+	verified := false
+
+	if cfg.SigningSecret != "" {
+		verified = verifySignature(cfg.SigningSecret, body, header)
+		if verified {
+			header.Set(verifiedHeader, "true")
+		} else {
+			header.Set(verifiedHeader, "false")
+		}
+	}
+
+	if cfg.ResignSecret != "" {
+		header.Set(signatureHeader, signature.Hookdeck(cfg.ResignSecret, body))
+		header.Del(signature2Header)
+	}
+
+	return verified
+}
@@ -0,0 +1,34 @@
+package proxy
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/signature"
+)
+
+func TestVerifySignature(t *testing.T) {
+	body := `{"hello":"world"}`
+	header := http.Header{}
+	header.Set(signatureHeader, signature.Hookdeck("secret", body))
+
+	require.True(t, verifySignature("secret", body, header))
+	require.False(t, verifySignature("other", body, header))
+
+	header.Set(signature2Header, signature.Hookdeck("other", body))
+	require.True(t, verifySignature("other", body, header))
+}
+
+func TestApplySignature(t *testing.T) {
+	body := `{"hello":"world"}`
+	header := http.Header{}
+	header.Set(signatureHeader, "invalid")
+
+	verified := applySignature(&Config{SigningSecret: "secret", ResignSecret: "local"}, body, header)
+
+	require.False(t, verified)
+	require.Equal(t, "false", header.Get(verifiedHeader))
+	require.Equal(t, signature.Hookdeck("local", body), header.Get(signatureHeader))
+}
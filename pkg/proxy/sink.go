@@ -0,0 +1,181 @@
+package proxy
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/ansi"
+	"github.com/hookdeck/hookdeck-cli/pkg/websocket"
+)
+
+// SinkEvent is a received event as written to the sinks, one JSON object
+// per line. Body is decoded when the request has a Content-Encoding.
+type SinkEvent struct {
+	EventID    string            `json:"event_id"`
+	ReceivedAt string            `json:"received_at"`
+	Method     string            `json:"method"`
+	Path       string            `json:"path"`
+	Headers    map[string]string `json:"headers"`
+	Body       string            `json:"body"`
+}
+
+// A Sink receives a copy of each event, in addition to or instead of the
+// local server.
+type Sink interface {
+	Write(event SinkEvent) error
+	Close() error
+}
+
+// OpenSink opens the sink of a URL: "stdout", or "file://path" to append the
+// events to a file as NDJSON.
+func OpenSink(rawURL string) (Sink, error) {
+	switch {
+	case rawURL == "stdout":
+		return &writerSink{w: os.Stdout, stdout: true}, nil
+	case strings.HasPrefix(rawURL, "file://"):
+		path := strings.TrimPrefix(rawURL, "file://")
+		if path == "" {
+			return nil, fmt.Errorf("invalid sink %q, expected file://path", rawURL)
+		}
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			return nil, err
+		}
+		return &writerSink{w: f, closer: f}, nil
+	case strings.HasPrefix(rawURL, "kafka://"):
+		return nil, fmt.Errorf("kafka sinks aren't supported yet, write the events to a file:// sink and produce them from it")
+	default:
+		return nil, fmt.Errorf("unsupported sink %q, expected stdout or file://path", rawURL)
+	}
+}
+
+// HasStdoutSink returns whether one of the sinks writes to stdout, in which
+// case stdout is kept for the events.
+func HasStdoutSink(sinks []Sink) bool {
+	for _, sink := range sinks {
+		if ws, ok := sink.(*writerSink); ok && ws.stdout {
+			return true
+		}
+	}
+	return false
+}
+
+// out returns where the events are printed: stdout, unless a sink writes
+// the events there, in which case they're printed to the status writer so
+// that stdout only contains the events.
+func (p *Proxy) out() io.Writer {
+	if HasStdoutSink(p.cfg.Sinks) {
+		return ansi.StatusWriter
+	}
+	return os.Stdout
+}
+
+// writerSink writes the events to a writer as NDJSON.
+type writerSink struct {
+	mu     sync.Mutex
+	w      io.Writer
+	closer io.Closer
+	stdout bool
+}
+
+func (s *writerSink) Write(event SinkEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.w.Write(append(data, '\n'))
+	return err
+}
+
+func (s *writerSink) Close() error {
+	if s.closer == nil {
+		return nil
+	}
+	return s.closer.Close()
+}
+
+// sinkAttempt writes the event to each of the sinks. Failing to write to a
+// sink doesn't fail the event.
+func (p *Proxy) sinkAttempt(webhookEvent *websocket.Attempt, headers map[string]string, body string) {
+	if len(p.cfg.Sinks) == 0 {
+		return
+	}
+
+	event := SinkEvent{
+		EventID:    webhookEvent.Body.EventID,
+		ReceivedAt: time.Now().Format(time.RFC3339),
+		Method:     webhookEvent.Body.Request.Method,
+		Path:       webhookEvent.Body.Path,
+		Headers:    headers,
+		Body:       body,
+	}
+	for _, sink := range p.cfg.Sinks {
+		if err := sink.Write(event); err != nil {
+			p.cfg.Log.WithFields(log.Fields{
+				"prefix": "proxy.Proxy.sinkAttempt",
+			}).Warnf("Failed to write event %s to a sink: %v", webhookEvent.Body.EventID, err)
+		}
+	}
+}
+
+// ackAttempt acknowledges an event without forwarding it, when NoForward is
+// set, and prints it with Echo.
+func (p *Proxy) ackAttempt(webhookEvent *websocket.Attempt, headers map[string]string, body string) {
+	status, data := http.StatusOK, "Received by the Hookdeck CLI"
+	if p.cfg.RespondStatus != 0 {
+		status, data = p.cfg.RespondStatus, p.cfg.RespondBody
+	}
+	p.summary.record(webhookEvent.Body.Path, status, false, 0)
+
+	switch {
+	case HasStdoutSink(p.cfg.Sinks):
+		// The events written to stdout are their own output
+	case p.cfg.Output == OutputJSON:
+		p.printAttemptJSON(attemptOutput{
+			EventID:  webhookEvent.Body.EventID,
+			Method:   webhookEvent.Body.Request.Method,
+			URL:      webhookEvent.Body.Path,
+			Status:   status,
+			EventURL: p.eventURL(webhookEvent),
+		})
+	default:
+		color := ansi.Color(p.out())
+		fmt.Fprintf(p.out(), "%s [%d] %s %s | %s\n",
+			color.Faint(time.Now().Format(timeLayout)),
+			ansi.ColorizeStatus(status),
+			webhookEvent.Body.Request.Method,
+			webhookEvent.Body.Path,
+			p.eventURL(webhookEvent),
+		)
+		if p.cfg.Echo {
+			printEcho(p.out(), headers, body)
+		}
+	}
+
+	if p.cfg.NoAck {
+		p.nackAttempt(webhookEvent)
+		return
+	}
+
+	p.sendMessage(&websocket.OutgoingMessage{
+		AttemptResponse: &websocket.AttemptResponse{
+			Event: "attempt_response",
+			Body: websocket.AttemptResponseBody{
+				AttemptId: webhookEvent.Body.AttemptId,
+				CLIPath:   webhookEvent.Body.Path,
+				Status:    status,
+				Data:      data,
+			},
+		}})
+}
@@ -0,0 +1,106 @@
+package proxy
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/ansi"
+	"github.com/hookdeck/hookdeck-cli/pkg/websocket"
+)
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.ndjson")
+	sink, err := OpenSink("file://" + path)
+	require.NoError(t, err)
+
+	p := New(&Config{Sinks: []Sink{sink}, NoForward: true, Output: OutputJSON}, nil)
+	for _, id := range []string{"evt_1", "evt_2"} {
+		p.processAttempt(websocket.IncomingMessage{Attempt: &websocket.Attempt{
+			Body: websocket.AttemptBody{
+				Path:    "/webhooks",
+				EventID: id,
+				Request: websocket.AttemptRequest{
+					Method:     http.MethodPost,
+					DataString: `{"ok":true}`,
+					Headers:    []byte(`{"Content-Type":"application/json"}`),
+				},
+			},
+		}})
+	}
+	require.NoError(t, sink.Close())
+
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 2)
+
+	var event SinkEvent
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &event))
+	require.Equal(t, "evt_2", event.EventID)
+	require.Equal(t, "/webhooks", event.Path)
+	require.Equal(t, map[string]string{"Content-Type": "application/json"}, event.Headers)
+	require.Equal(t, `{"ok":true}`, event.Body)
+
+	summary := p.Summary()
+	require.Equal(t, 2, summary.Succeeded)
+	require.Equal(t, map[string]int{"200": 2}, summary.Statuses)
+}
+
+func TestOpenSink(t *testing.T) {
+	sink, err := OpenSink("stdout")
+	require.NoError(t, err)
+	require.True(t, HasStdoutSink([]Sink{sink}))
+
+	_, err = OpenSink("kafka://localhost:9092/events")
+	require.Error(t, err)
+	_, err = OpenSink("s3://bucket")
+	require.EqualError(t, err, `unsupported sink "s3://bucket", expected stdout or file://path`)
+}
+
+func TestStdoutSinkKeepsStdoutForEvents(t *testing.T) {
+	local := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer local.Close()
+	localURL, _ := url.Parse(local.URL)
+
+	stdout, err := os.Create(filepath.Join(t.TempDir(), "stdout"))
+	require.NoError(t, err)
+	defer func(previous *os.File) { os.Stdout = previous }(os.Stdout)
+	os.Stdout = stdout
+	status := &bytes.Buffer{}
+	defer func(previous io.Writer) { ansi.StatusWriter = previous }(ansi.StatusWriter)
+	ansi.StatusWriter = status
+
+	sink, err := OpenSink("stdout")
+	require.NoError(t, err)
+	p := New(&Config{URL: localURL, Sinks: []Sink{sink}}, nil)
+	for _, id := range []string{"evt_1", "evt_2"} {
+		p.processAttempt(websocket.IncomingMessage{Attempt: &websocket.Attempt{
+			Body: websocket.AttemptBody{
+				Path:    "/webhooks",
+				EventID: id,
+				Request: websocket.AttemptRequest{Method: http.MethodPost, Headers: []byte(`{}`)},
+			},
+		}})
+	}
+
+	data, err := ioutil.ReadFile(stdout.Name())
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 2)
+	for _, line := range lines {
+		var event SinkEvent
+		require.NoError(t, json.Unmarshal([]byte(line), &event), line)
+	}
+	require.Contains(t, status.String(), "POST "+local.URL+"/webhooks")
+}
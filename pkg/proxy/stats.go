@@ -0,0 +1,94 @@
+package proxy
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Stats aggregates the forwarded events of a listen session: their rate,
+// success rate, latency and paths.
+type Stats struct {
+	mu        sync.Mutex
+	start     time.Time
+	events    int
+	succeeded int
+	// durations are the forward latencies in milliseconds
+	durations []int64
+	paths     map[string]int
+}
+
+// StatsSnapshot is the state of the stats at a given time.
+type StatsSnapshot struct {
+	Events          int            `json:"events"`
+	EventsPerMinute float64        `json:"events_per_minute"`
+	SuccessRate     float64        `json:"success_rate"`
+	P50Ms           int64          `json:"p50_ms"`
+	P95Ms           int64          `json:"p95_ms"`
+	Paths           map[string]int `json:"paths"`
+}
+
+// NewStats creates the stats of a session starting now.
+func NewStats() *Stats {
+	return &Stats{
+		start: time.Now(),
+		paths: map[string]int{},
+	}
+}
+
+// Record adds a forwarded event. An event succeeded when the local server
+// responded with a 2xx status matching the --expect flags.
+func (s *Stats) Record(path string, succeeded bool, duration time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.events++
+	if succeeded {
+		s.succeeded++
+	}
+	s.durations = append(s.durations, duration.Milliseconds())
+	s.paths[path]++
+}
+
+// Snapshot returns the current stats.
+func (s *Stats) Snapshot() StatsSnapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshot := StatsSnapshot{
+		Events: s.events,
+		Paths:  make(map[string]int, len(s.paths)),
+	}
+	for path, count := range s.paths {
+		snapshot.Paths[path] = count
+	}
+	if s.events == 0 {
+		return snapshot
+	}
+
+	// The rate is averaged over at least a minute so the first events of
+	// the session don't show a spike
+	minutes := time.Since(s.start).Minutes()
+	if minutes < 1 {
+		minutes = 1
+	}
+	snapshot.EventsPerMinute = float64(s.events) / minutes
+	snapshot.SuccessRate = float64(s.succeeded) / float64(s.events)
+
+	durations := make([]int64, len(s.durations))
+	copy(durations, s.durations)
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	snapshot.P50Ms = percentile(durations, 50)
+	snapshot.P95Ms = percentile(durations, 95)
+
+	return snapshot
+}
+
+// percentile returns the nearest-rank percentile of sorted values.
+func percentile(sorted []int64, p int) int64 {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
@@ -0,0 +1,26 @@
+package proxy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStats(t *testing.T) {
+	stats := NewStats()
+	require.Equal(t, 0, stats.Snapshot().Events)
+
+	for i := 1; i <= 20; i++ {
+		stats.Record("/webhooks", i%4 != 0, time.Duration(i)*time.Millisecond)
+	}
+	stats.Record("/other", false, 100*time.Millisecond)
+
+	snapshot := stats.Snapshot()
+	require.Equal(t, 21, snapshot.Events)
+	require.InDelta(t, 15.0/21.0, snapshot.SuccessRate, 0.001)
+	require.Equal(t, int64(11), snapshot.P50Ms)
+	require.Equal(t, int64(20), snapshot.P95Ms)
+	require.Equal(t, map[string]int{"/webhooks": 20, "/other": 1}, snapshot.Paths)
+	require.InDelta(t, 21.0, snapshot.EventsPerMinute, 0.001)
+}
@@ -0,0 +1,169 @@
+package proxy
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/ansi"
+)
+
+// maxSlowestPaths is the number of paths listed in the summary.
+const maxSlowestPaths = 5
+
+// Summary is the report of a listen session, printed when it ends.
+type Summary struct {
+	DurationMs int64 `json:"duration_ms"`
+	Events     int   `json:"events"`
+	Succeeded  int   `json:"succeeded"`
+	Failed     int   `json:"failed"`
+	// Statuses counts the events by the status of the local server's
+	// response, "error" when it couldn't be reached
+	Statuses map[string]int `json:"statuses"`
+	// SlowestPaths are the paths with the highest forwarding latency
+	SlowestPaths []PathLatency `json:"slowest_paths"`
+	Reconnects   int           `json:"reconnects"`
+}
+
+// PathLatency is the forwarding latency of the events of a path.
+type PathLatency struct {
+	Path   string `json:"path"`
+	Events int    `json:"events"`
+	MaxMs  int64  `json:"max_ms"`
+	AvgMs  int64  `json:"avg_ms"`
+}
+
+// sessionSummary records the events of a session for its Summary.
+type sessionSummary struct {
+	mu         sync.Mutex
+	start      time.Time
+	events     int
+	succeeded  int
+	statuses   map[string]int
+	paths      map[string]*PathLatency
+	totalMs    map[string]int64
+	reconnects int
+}
+
+func newSessionSummary() *sessionSummary {
+	return &sessionSummary{
+		start:    time.Now(),
+		statuses: map[string]int{},
+		paths:    map[string]*PathLatency{},
+		totalMs:  map[string]int64{},
+	}
+}
+
+// record adds a forwarded event, status being 0 when the local server
+// couldn't be reached.
+func (s *sessionSummary) record(path string, status int, failed bool, duration time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.events++
+	if !failed {
+		s.succeeded++
+	}
+	if status == 0 {
+		s.statuses["error"]++
+	} else {
+		s.statuses[strconv.Itoa(status)]++
+	}
+
+	latency, ok := s.paths[path]
+	if !ok {
+		latency = &PathLatency{Path: path}
+		s.paths[path] = latency
+	}
+	ms := duration.Milliseconds()
+	latency.Events++
+	s.totalMs[path] += ms
+	latency.AvgMs = s.totalMs[path] / int64(latency.Events)
+	if ms > latency.MaxMs {
+		latency.MaxMs = ms
+	}
+}
+
+func (s *sessionSummary) reconnected() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.reconnects++
+}
+
+func (s *sessionSummary) summary() Summary {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	summary := Summary{
+		DurationMs:   time.Since(s.start).Milliseconds(),
+		Events:       s.events,
+		Succeeded:    s.succeeded,
+		Failed:       s.events - s.succeeded,
+		Statuses:     make(map[string]int, len(s.statuses)),
+		SlowestPaths: make([]PathLatency, 0, len(s.paths)),
+		Reconnects:   s.reconnects,
+	}
+	for status, count := range s.statuses {
+		summary.Statuses[status] = count
+	}
+	for _, latency := range s.paths {
+		summary.SlowestPaths = append(summary.SlowestPaths, *latency)
+	}
+	sort.Slice(summary.SlowestPaths, func(i, j int) bool {
+		a, b := summary.SlowestPaths[i], summary.SlowestPaths[j]
+		if a.MaxMs != b.MaxMs {
+			return a.MaxMs > b.MaxMs
+		}
+		return a.Path < b.Path
+	})
+	if len(summary.SlowestPaths) > maxSlowestPaths {
+		summary.SlowestPaths = summary.SlowestPaths[:maxSlowestPaths]
+	}
+
+	return summary
+}
+
+// Summary returns the report of the session.
+func (p *Proxy) Summary() Summary {
+	return p.summary.summary()
+}
+
+// Print writes the summary for humans.
+func (s Summary) Print(w io.Writer) {
+	color := ansi.Color(w)
+	duration := (time.Duration(s.DurationMs) * time.Millisecond).Round(time.Second)
+
+	fmt.Fprintln(w, color.Bold("Session summary"))
+	fmt.Fprintf(w, "  Duration:    %s\n", duration)
+	fmt.Fprintf(w, "  Events:      %d (%s, %s)\n", s.Events,
+		color.Green(fmt.Sprintf("%d succeeded", s.Succeeded)),
+		color.Red(fmt.Sprintf("%d failed", s.Failed)))
+
+	if len(s.Statuses) > 0 {
+		statuses := make([]string, 0, len(s.Statuses))
+		for status := range s.Statuses {
+			statuses = append(statuses, status)
+		}
+		sort.Strings(statuses)
+		counts := make([]string, len(statuses))
+		for i, status := range statuses {
+			counts[i] = fmt.Sprintf("%s: %d", status, s.Statuses[status])
+		}
+		fmt.Fprintf(w, "  Statuses:    %s\n", strings.Join(counts, ", "))
+	}
+
+	for i, latency := range s.SlowestPaths {
+		label := "  Slowest:     "
+		if i > 0 {
+			label = "               "
+		}
+		fmt.Fprintf(w, "%s%s (max %dms, avg %dms, %d events)\n", label, latency.Path, latency.MaxMs, latency.AvgMs, latency.Events)
+	}
+
+	fmt.Fprintf(w, "  Reconnects:  %d\n", s.Reconnects)
+}
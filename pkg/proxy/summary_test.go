@@ -0,0 +1,33 @@
+package proxy
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionSummary(t *testing.T) {
+	s := newSessionSummary()
+	s.record("/stripe", 200, false, 100*time.Millisecond)
+	s.record("/stripe", 500, true, 300*time.Millisecond)
+	s.record("/shopify", 0, true, 50*time.Millisecond)
+	s.reconnected()
+
+	summary := s.summary()
+	require.Equal(t, 3, summary.Events)
+	require.Equal(t, 1, summary.Succeeded)
+	require.Equal(t, 2, summary.Failed)
+	require.Equal(t, map[string]int{"200": 1, "500": 1, "error": 1}, summary.Statuses)
+	require.Equal(t, []PathLatency{
+		{Path: "/stripe", Events: 2, MaxMs: 300, AvgMs: 200},
+		{Path: "/shopify", Events: 1, MaxMs: 50, AvgMs: 50},
+	}, summary.SlowestPaths)
+	require.Equal(t, 1, summary.Reconnects)
+
+	out := &bytes.Buffer{}
+	summary.Print(out)
+	require.Contains(t, out.String(), "Statuses:    200: 1, 500: 1, error: 1")
+	require.Contains(t, out.String(), "Slowest:     /stripe (max 300ms, avg 200ms, 2 events)")
+}
@@ -0,0 +1,189 @@
+package proxy
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/ansi"
+	"github.com/hookdeck/hookdeck-cli/pkg/websocket"
+)
+
+// Overflow policies of the throttle, for the events received faster than
+// its rate.
+const (
+	// ThrottleBuffer queues the events until they can be forwarded
+	ThrottleBuffer = "buffer"
+	// ThrottleDrop acknowledges the events without forwarding them
+	ThrottleDrop = "drop"
+	// ThrottleNack reports the events as not delivered, for Hookdeck to
+	// retry them
+	ThrottleNack = "nack"
+)
+
+// DefaultThrottleQueue is the maximum number of events queued by the
+// throttle with ThrottleBuffer.
+const DefaultThrottleQueue = 100
+
+// Throttle limits the rate of the events forwarded to the local server with
+// a token bucket, smoothing the bursts received from Hookdeck.
+type Throttle struct {
+	// Rate is the number of events forwarded per second
+	Rate float64
+	// Burst is the number of events forwarded at once after a pause
+	Burst int
+	// Overflow is the policy of the events received faster than the rate
+	Overflow string
+	// MaxQueue is the number of events queued with ThrottleBuffer above
+	// which the next ones are NACKed
+	MaxQueue int
+}
+
+// ParseThrottleRate parses a rate in the format of the --throttle flag, a
+// number of events per second, minute or hour, e.g. 20/s or 100/m.
+func ParseThrottleRate(value string) (float64, error) {
+	count, unit, ok := strings.Cut(value, "/")
+	n, err := strconv.ParseFloat(count, 64)
+	if !ok || err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid throttle %q, expected a number of events per second, minute or hour, e.g. 20/s", value)
+	}
+
+	switch unit {
+	case "s":
+		return n, nil
+	case "m":
+		return n / 60, nil
+	case "h":
+		return n / 3600, nil
+	default:
+		return 0, fmt.Errorf("invalid throttle unit %q, expected one of s, m, h", unit)
+	}
+}
+
+// tokenBucket is the state of a Throttle. Tokens are added at the rate of
+// the throttle, up to its burst, and each forwarded event takes one. The
+// tokens of the queued events are reserved in advance, so the bucket goes
+// negative while events are waiting.
+type tokenBucket struct {
+	mu       sync.Mutex
+	throttle Throttle
+	tokens   float64
+	last     time.Time
+	waiting  int
+}
+
+func newTokenBucket(throttle Throttle, now time.Time) *tokenBucket {
+	return &tokenBucket{throttle: throttle, tokens: float64(throttle.Burst), last: now}
+}
+
+// take takes a token for an event received at the given time. It returns
+// how long the event waits for it and the number of events queued ahead of
+// it, or ok false when the event overflows. done must be called once the
+// wait is over.
+func (b *tokenBucket) take(now time.Time) (wait time.Duration, ahead int, ok bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens += now.Sub(b.last).Seconds() * b.throttle.Rate
+	if b.tokens > float64(b.throttle.Burst) {
+		b.tokens = float64(b.throttle.Burst)
+	}
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0, 0, true
+	}
+	if b.throttle.Overflow != ThrottleBuffer || b.waiting >= b.throttle.MaxQueue {
+		return 0, b.waiting, false
+	}
+
+	b.tokens--
+	ahead = b.waiting
+	b.waiting++
+	return time.Duration(-b.tokens / b.throttle.Rate * float64(time.Second)), ahead, true
+}
+
+// done ends the wait of a queued event.
+func (b *tokenBucket) done() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.waiting > 0 {
+		b.waiting--
+	}
+}
+
+// throttleAttempt waits for the throttle, when set, before an event is
+// handled. It returns the number of events queued ahead of it, or ok false
+// when the event overflowed and was already responded to, or the session
+// stopped while it was queued, leaving it for Hookdeck to retry.
+func (p *Proxy) throttleAttempt(webhookEvent *websocket.Attempt) (ahead int, ok bool) {
+	if p.throttle == nil {
+		return 0, true
+	}
+
+	wait, ahead, ok := p.throttle.take(time.Now())
+	if ok {
+		if wait > 0 {
+			timer := time.NewTimer(wait)
+			defer timer.Stop()
+			defer p.throttle.done()
+			select {
+			case <-timer.C:
+			case <-p.stopped:
+				return ahead, false
+			}
+		}
+		return ahead, true
+	}
+
+	policy := p.throttle.throttle.Overflow
+	if policy == ThrottleBuffer {
+		// The queue is full
+		policy = ThrottleNack
+	}
+	label := "DROPPED"
+	if policy == ThrottleNack {
+		label = "NACKED"
+	}
+
+	if p.cfg.Output == OutputJSON {
+		p.printAttemptJSON(attemptOutput{
+			EventID:   webhookEvent.Body.EventID,
+			Method:    webhookEvent.Body.Request.Method,
+			URL:       webhookEvent.Body.Path,
+			Throttled: strings.ToLower(label),
+			EventURL:  p.eventURL(webhookEvent),
+		})
+	} else {
+		color := ansi.Color(p.out())
+		fmt.Fprintln(p.out(), color.Yellow(fmt.Sprintf("%s [THROTTLED] %s %s %s (%d queued) | %s",
+			time.Now().Format(timeLayout),
+			label,
+			webhookEvent.Body.Request.Method,
+			webhookEvent.Body.Path,
+			ahead,
+			p.eventURL(webhookEvent),
+		)))
+	}
+
+	if policy == ThrottleNack || p.cfg.NoAck {
+		p.nackAttempt(webhookEvent)
+	} else {
+		p.sendMessage(&websocket.OutgoingMessage{
+			AttemptResponse: &websocket.AttemptResponse{
+				Event: "attempt_response",
+				Body: websocket.AttemptResponseBody{
+					AttemptId: webhookEvent.Body.AttemptId,
+					CLIPath:   webhookEvent.Body.Path,
+					Status:    http.StatusOK,
+					Data:      "Dropped by the Hookdeck CLI throttle",
+				},
+			}})
+	}
+	return ahead, false
+}
@@ -0,0 +1,152 @@
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/websocket"
+)
+
+func TestParseThrottleRate(t *testing.T) {
+	rate, err := ParseThrottleRate("20/s")
+	require.NoError(t, err)
+	require.Equal(t, 20.0, rate)
+
+	rate, err = ParseThrottleRate("120/m")
+	require.NoError(t, err)
+	require.Equal(t, 2.0, rate)
+
+	for _, value := range []string{"", "20", "0/s", "-1/s", "20/d", "a/s"} {
+		_, err := ParseThrottleRate(value)
+		require.Error(t, err, value)
+	}
+}
+
+func TestTokenBucket(t *testing.T) {
+	start := time.Now()
+	b := newTokenBucket(Throttle{Rate: 10, Burst: 2, Overflow: ThrottleBuffer, MaxQueue: 2}, start)
+
+	// The burst is forwarded right away
+	for i := 0; i < 2; i++ {
+		wait, ahead, ok := b.take(start)
+		require.True(t, ok)
+		require.Equal(t, time.Duration(0), wait)
+		require.Equal(t, 0, ahead)
+	}
+
+	// The next events are queued at the rate
+	wait, ahead, ok := b.take(start)
+	require.True(t, ok)
+	require.Equal(t, 100*time.Millisecond, wait)
+	require.Equal(t, 0, ahead)
+	wait, ahead, ok = b.take(start)
+	require.True(t, ok)
+	require.Equal(t, 200*time.Millisecond, wait)
+	require.Equal(t, 1, ahead)
+
+	// Until the queue is full
+	_, ahead, ok = b.take(start)
+	require.False(t, ok)
+	require.Equal(t, 2, ahead)
+
+	b.done()
+	b.done()
+	wait, _, ok = b.take(start.Add(time.Second))
+	require.True(t, ok)
+	require.Equal(t, time.Duration(0), wait)
+
+	b = newTokenBucket(Throttle{Rate: 10, Burst: 1, Overflow: ThrottleDrop}, start)
+	_, _, ok = b.take(start)
+	require.True(t, ok)
+	_, _, ok = b.take(start)
+	require.False(t, ok)
+}
+
+func TestThrottleDropNoAck(t *testing.T) {
+	local := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer local.Close()
+	localURL, _ := url.Parse(local.URL)
+
+	for _, noAck := range []bool{false, true} {
+		p := New(&Config{
+			URL:      localURL,
+			Output:   OutputJSON,
+			NoAck:    noAck,
+			Throttle: &Throttle{Rate: 0.001, Burst: 1, Overflow: ThrottleDrop},
+		}, nil)
+		sender := &recordingSender{}
+		p.sender = sender
+
+		for _, id := range []string{"atm_1", "atm_2"} {
+			p.processAttempt(websocket.IncomingMessage{Attempt: &websocket.Attempt{
+				Body: websocket.AttemptBody{
+					Path:      "/webhooks",
+					AttemptId: id,
+					Request:   websocket.AttemptRequest{Method: http.MethodPost, Headers: []byte(`{}`)},
+				},
+			}})
+		}
+
+		// The second event is dropped
+		require.Len(t, sender.messages, 2)
+		dropped := sender.messages[1]
+		if noAck {
+			require.Nil(t, dropped.AttemptResponse)
+			require.Equal(t, "atm_2", dropped.ErrorAttemptResponse.Body.AttemptId)
+		} else {
+			require.Nil(t, dropped.ErrorAttemptResponse)
+			require.Equal(t, "atm_2", dropped.AttemptResponse.Body.AttemptId)
+			require.Equal(t, http.StatusOK, dropped.AttemptResponse.Body.Status)
+		}
+	}
+}
+
+func TestStopWhileThrottled(t *testing.T) {
+	var forwarded int32
+	local := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&forwarded, 1)
+	}))
+	defer local.Close()
+	localURL, _ := url.Parse(local.URL)
+
+	p := New(&Config{
+		URL:      localURL,
+		Output:   OutputJSON,
+		Throttle: &Throttle{Rate: 1, Burst: 1, Overflow: ThrottleBuffer, MaxQueue: 10},
+	}, nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.processAttempt(websocket.IncomingMessage{Attempt: &websocket.Attempt{
+				Body: websocket.AttemptBody{
+					Path:    "/webhooks",
+					Request: websocket.AttemptRequest{Method: http.MethodPost, Headers: []byte(`{}`)},
+				},
+			}})
+		}()
+	}
+	require.Eventually(t, func() bool {
+		p.throttle.mu.Lock()
+		defer p.throttle.mu.Unlock()
+		return p.throttle.waiting == 3
+	}, time.Second, 5*time.Millisecond)
+
+	start := time.Now()
+	p.stop("Stopped")
+	require.Less(t, time.Since(start), 500*time.Millisecond)
+	wg.Wait()
+
+	// The queued events are left for Hookdeck to retry
+	require.Equal(t, int32(1), atomic.LoadInt32(&forwarded))
+	require.Equal(t, 0, p.throttle.waiting)
+}
@@ -0,0 +1,123 @@
+package proxy
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// Timing is the breakdown of the latency of a forwarded event, telling the
+// time spent in the CLI and on the network from the time spent by the local
+// server.
+type Timing struct {
+	// QueueMs is the time from the receipt of the event to the start of the
+	// request, e.g. waiting for the other events to be handled
+	QueueMs int64 `json:"queue_ms"`
+	// Queued is the number of events queued ahead by the throttle
+	Queued int `json:"queued,omitempty"`
+	// DNSMs and ConnectMs are the time resolving the host and opening the
+	// connection, including the TLS handshake. They're 0 when a keep-alive
+	// connection is reused.
+	DNSMs     int64 `json:"dns_ms"`
+	ConnectMs int64 `json:"connect_ms"`
+	// TTFBMs is the time from the start of the request to the first byte of
+	// the response
+	TTFBMs int64 `json:"ttfb_ms"`
+	// TotalMs is the time from the start of the request to the end of the
+	// response
+	TotalMs int64 `json:"total_ms"`
+}
+
+// Total returns the total duration of the request.
+func (t Timing) Total() time.Duration {
+	return time.Duration(t.TotalMs) * time.Millisecond
+}
+
+func (t Timing) String() string {
+	queue := fmt.Sprintf("%dms", t.QueueMs)
+	if t.Queued > 0 {
+		queue += fmt.Sprintf(" behind %d", t.Queued)
+	}
+	return fmt.Sprintf("%dms: queue %s, dns %dms, connect %dms, ttfb %dms", t.TotalMs, queue, t.DNSMs, t.ConnectMs, t.TTFBMs)
+}
+
+// requestTimer measures the Timing of a request with httptrace.
+type requestTimer struct {
+	mu           sync.Mutex
+	received     time.Time
+	start        time.Time
+	dnsStart     time.Time
+	dnsDone      time.Time
+	connectStart time.Time
+	connectDone  time.Time
+	firstByte    time.Time
+	// queued is the number of events queued ahead by the throttle
+	queued int
+}
+
+// newRequestTimer returns the timer of the request of an event received at
+// the given time, which is zero when unknown.
+func newRequestTimer(received time.Time) *requestTimer {
+	return &requestTimer{received: received}
+}
+
+// trace starts the timer and returns the request traced by it.
+func (t *requestTimer) trace(req *http.Request) *http.Request {
+	trace := &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) { t.mark(&t.dnsStart, true) },
+		DNSDone:  func(httptrace.DNSDoneInfo) { t.mark(&t.dnsDone, false) },
+		// Several addresses can be dialed, the connection time is from the
+		// first dial to the end of the handshake of the one used
+		ConnectStart:         func(string, string) { t.mark(&t.connectStart, true) },
+		ConnectDone:          func(string, string, error) { t.mark(&t.connectDone, false) },
+		TLSHandshakeDone:     func(tls.ConnectionState, error) { t.mark(&t.connectDone, false) },
+		GotFirstResponseByte: func() { t.mark(&t.firstByte, true) },
+	}
+
+	t.mu.Lock()
+	t.start = time.Now()
+	t.mu.Unlock()
+	return req.WithContext(httptrace.WithClientTrace(req.Context(), trace))
+}
+
+// mark sets a time of the timer, unless it's already set and first is set.
+func (t *requestTimer) mark(at *time.Time, first bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if first && !at.IsZero() {
+		return
+	}
+	*at = time.Now()
+}
+
+// done returns the timing of the request, ending now.
+func (t *requestTimer) done() Timing {
+	end := time.Now()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	timing := Timing{
+		DNSMs:     elapsedMs(t.dnsStart, t.dnsDone),
+		ConnectMs: elapsedMs(t.connectStart, t.connectDone),
+		TTFBMs:    elapsedMs(t.start, t.firstByte),
+		TotalMs:   elapsedMs(t.start, end),
+		Queued:    t.queued,
+	}
+	if !t.received.IsZero() {
+		timing.QueueMs = elapsedMs(t.received, t.start)
+	}
+	return timing
+}
+
+// elapsedMs returns the milliseconds between two times, 0 when one of them
+// isn't set.
+func elapsedMs(from time.Time, to time.Time) int64 {
+	if from.IsZero() || to.IsZero() || to.Before(from) {
+		return 0
+	}
+	return to.Sub(from).Milliseconds()
+}
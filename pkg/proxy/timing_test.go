@@ -0,0 +1,38 @@
+package proxy
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestTimer(t *testing.T) {
+	local := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+		w.(http.Flusher).Flush()
+		time.Sleep(50 * time.Millisecond)
+	}))
+	defer local.Close()
+
+	timer := newRequestTimer(time.Now().Add(-20 * time.Millisecond))
+	req, err := http.NewRequest(http.MethodPost, local.URL, nil)
+	require.NoError(t, err)
+	res, err := http.DefaultClient.Do(timer.trace(req))
+	require.NoError(t, err)
+	ioutil.ReadAll(res.Body)
+	res.Body.Close()
+
+	timing := timer.done()
+	require.GreaterOrEqual(t, timing.QueueMs, int64(20))
+	require.GreaterOrEqual(t, timing.TTFBMs, int64(50))
+	require.Less(t, timing.TTFBMs, timing.TotalMs)
+	require.GreaterOrEqual(t, timing.TotalMs, int64(100))
+	// The host is an IP address, so there's no DNS lookup
+	require.Zero(t, timing.DNSMs)
+
+	require.Zero(t, newRequestTimer(time.Time{}).done().QueueMs)
+}
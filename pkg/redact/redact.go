@@ -0,0 +1,53 @@
+// Package redact scrubs secrets from the JSON printed by the CLI, such as
+// API responses in verbose logs and `--output json`, and from config files.
+package redact
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Placeholder replaces the redacted values.
+const Placeholder = "***"
+
+// Keys are the JSON keys whose string values are secrets, wherever they
+// appear in a document, e.g. auth_method.config.token.
+var Keys = []string{
+	"access_key_id",
+	"access_token",
+	"api_key",
+	"client_secret",
+	"password",
+	"refresh_token",
+	"secret",
+	"secret_access_key",
+	"signing_secret",
+	"token",
+	"webhook_secret_key",
+}
+
+// secretRegexp matches a secret key and its string value. Since quotes are
+// escaped inside JSON strings, keys can't be matched within string values.
+var secretRegexp = regexp.MustCompile(`("(?:` + strings.Join(Keys, "|") + `)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+
+// JSON replaces the secret string values of a JSON document with the
+// Placeholder. The formatting and the order of the keys are preserved, and
+// documents that aren't JSON are returned unchanged.
+func JSON(data []byte) []byte {
+	return secretRegexp.ReplaceAll(data, []byte(`$1"`+Placeholder+`"`))
+}
+
+// tomlSecretRegexp matches a secret key of a TOML document and its value,
+// up to the end of the line.
+var tomlSecretRegexp = regexp.MustCompile(`(?m)^(\s*"?(?:` + strings.Join(Keys, "|") + `)"?\s*=\s*).+$`)
+
+// urlPasswordRegexp matches the password of a URL, e.g. of a proxy.
+var urlPasswordRegexp = regexp.MustCompile(`(://[^/:@\s"']*:)[^/@\s"']+@`)
+
+// TOML replaces the secret values of a TOML document, such as a config
+// file, with the Placeholder, as well as the passwords of the URLs it
+// contains.
+func TOML(data []byte) []byte {
+	data = tomlSecretRegexp.ReplaceAll(data, []byte(`$1"`+Placeholder+`"`))
+	return urlPasswordRegexp.ReplaceAll(data, []byte(`$1`+Placeholder+`@`))
+}
@@ -0,0 +1,182 @@
+package scaffold
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Options are the answers given to `hookdeck init`.
+type Options struct {
+	// Source is the name of the source of the starter connection
+	Source string
+	// Port is the port of the local server events are forwarded to
+	Port string
+	// Path is the path events are forwarded to
+	Path string
+
+	Transformation bool
+	Workflow       bool
+}
+
+// File is a file of the scaffold.
+type File struct {
+	Path    string
+	Content string
+	// Lines files, like .gitignore, get the missing lines of Content
+	// appended instead of being created
+	Lines bool
+}
+
+// Files returns the files of the scaffold.
+func Files(opts Options) []File {
+	files := []File{
+		{
+			Path: filepath.Join(".hookdeck", "config.toml"),
+			Content: fmt.Sprintf(`# Defaults of "hookdeck listen" in this project, see "hookdeck config list"
+[listen]
+port = %q
+source = %q
+path = %q
+`, opts.Port, opts.Source, opts.Path),
+		},
+		{
+			Path: ".gitignore",
+			// The local config can hold the API key of a project, set with
+			// "hookdeck config set api_key --local"
+			Content: "# Hookdeck CLI\n.hookdeck/config.toml\nhookdeck.log\n",
+			Lines:   true,
+		},
+	}
+
+	if opts.Transformation {
+		files = append(files, File{
+			Path: filepath.Join("hookdeck", "transformations", opts.Source+".js"),
+			Content: `// Transformation of the events of the ` + opts.Source + ` source. Paste it in the
+// transformation editor of the Hookdeck dashboard and attach it to a
+// connection rule.
+addHandler("transform", (request, context) => {
+  // request has the headers, body, query and path of the event, and can be
+  // modified before the event is delivered to its destination
+  request.headers["x-transformed-by"] = "hookdeck";
+
+  return request;
+});
+`,
+		})
+	}
+
+	if opts.Workflow {
+		files = append(files, File{
+			Path: filepath.Join(".github", "workflows", "hookdeck.yml"),
+			Content: `name: Hookdeck
+
+on:
+  pull_request:
+  workflow_dispatch:
+
+jobs:
+  webhooks:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - uses: actions/setup-node@v4
+        with:
+          node-version: 20
+      - name: Install the Hookdeck CLI
+        run: npm install -g hookdeck-cli
+      - name: Authenticate the Hookdeck CLI
+        run: hookdeck ci --api-key "$HOOKDECK_API_KEY"
+        env:
+          HOOKDECK_API_KEY: ${{ secrets.HOOKDECK_API_KEY }}
+      - name: Forward events to the app under test
+        run: hookdeck listen ` + opts.Port + ` ` + opts.Source + ` --path ` + opts.Path + ` --output json > hookdeck.log &
+      # Start your app on port ` + opts.Port + ` and run your tests here
+`,
+		})
+	}
+
+	return files
+}
+
+// Write writes the files of the scaffold in dir and returns the paths of the
+// files written. Existing files are only overwritten with force, other files
+// are skipped.
+func Write(dir string, files []File, force bool) ([]string, error) {
+	written := []string{}
+
+	for _, file := range files {
+		path := filepath.Join(dir, file.Path)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return written, err
+		}
+
+		if file.Lines {
+			changed, err := appendLines(path, file.Content)
+			if err != nil {
+				return written, err
+			}
+			if changed {
+				written = append(written, file.Path)
+			}
+			continue
+		}
+
+		if _, err := os.Stat(path); err == nil && !force {
+			continue
+		}
+		if err := ioutil.WriteFile(path, []byte(file.Content), 0644); err != nil {
+			return written, err
+		}
+		written = append(written, file.Path)
+	}
+
+	return written, nil
+}
+
+// appendLines appends the lines of content missing from the file.
+func appendLines(path string, content string) (bool, error) {
+	existing, err := ioutil.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return false, err
+	}
+
+	present := map[string]bool{}
+	for _, line := range strings.Split(string(existing), "\n") {
+		present[strings.TrimSpace(line)] = true
+	}
+
+	missing := []string{}
+	entries := 0
+	for _, line := range strings.Split(strings.TrimSpace(content), "\n") {
+		if !present[line] {
+			missing = append(missing, line)
+			if !strings.HasPrefix(line, "#") {
+				entries++
+			}
+		}
+	}
+	// Comments are only added along with entries
+	if entries == 0 {
+		return false, nil
+	}
+
+	added := strings.Join(missing, "\n") + "\n"
+	if len(existing) > 0 {
+		if !strings.HasSuffix(string(existing), "\n") {
+			added = "\n" + added
+		}
+		added = "\n" + added
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	_, err = f.WriteString(added)
+	return true, err
+}
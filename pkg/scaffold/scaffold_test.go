@@ -0,0 +1,46 @@
+package scaffold
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrite(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, ".gitignore"), []byte("node_modules\nhookdeck.log"), 0644))
+
+	opts := Options{Source: "stripe", Port: "3000", Path: "/webhooks", Transformation: true, Workflow: true}
+	written, err := Write(dir, Files(opts), false)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		filepath.Join(".hookdeck", "config.toml"),
+		".gitignore",
+		filepath.Join("hookdeck", "transformations", "stripe.js"),
+		filepath.Join(".github", "workflows", "hookdeck.yml"),
+	}, written)
+
+	gitignore, err := ioutil.ReadFile(filepath.Join(dir, ".gitignore"))
+	require.NoError(t, err)
+	require.Equal(t, "node_modules\nhookdeck.log\n\n# Hookdeck CLI\n.hookdeck/config.toml\n", string(gitignore))
+
+	config, err := ioutil.ReadFile(filepath.Join(dir, ".hookdeck", "config.toml"))
+	require.NoError(t, err)
+	require.Contains(t, string(config), "source = \"stripe\"\n")
+
+	// Existing files are kept unless forced
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".hookdeck", "config.toml"), []byte("custom"), 0644))
+	written, err = Write(dir, Files(opts), false)
+	require.NoError(t, err)
+	require.Empty(t, written)
+
+	written, err = Write(dir, Files(Options{Source: "github", Port: "8080", Path: "/"}), true)
+	require.NoError(t, err)
+	require.Equal(t, []string{filepath.Join(".hookdeck", "config.toml")}, written)
+	config, err = ioutil.ReadFile(filepath.Join(dir, ".hookdeck", "config.toml"))
+	require.NoError(t, err)
+	require.Contains(t, string(config), "port = \"8080\"\n")
+}
@@ -0,0 +1,172 @@
+// Package schema infers a JSON Schema from sample JSON documents, such as the
+// payloads of the events received by a source.
+package schema
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// Draft is the JSON Schema version of the generated schemas.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// JSON types, as named by JSON Schema.
+const (
+	typeNull    = "null"
+	typeBoolean = "boolean"
+	typeInteger = "integer"
+	typeNumber  = "number"
+	typeString  = "string"
+	typeArray   = "array"
+	typeObject  = "object"
+)
+
+// Schema is the subset of JSON Schema generated from samples.
+type Schema struct {
+	SchemaURI  string             `json:"$schema,omitempty"`
+	Title      string             `json:"title,omitempty"`
+	Types      []string           `json:"-"`
+	Properties map[string]*Schema `json:"properties,omitempty"`
+	Required   []string           `json:"required,omitempty"`
+	Items      *Schema            `json:"items,omitempty"`
+
+	// Number of object samples merged into the schema, used to infer which
+	// properties are required
+	objects int
+	// Number of times each property was seen in object samples
+	seen map[string]int
+}
+
+// MarshalJSON writes the type as a string when there is a single one, and as
+// an array otherwise.
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	type alias Schema
+	out := struct {
+		*alias
+		Type interface{} `json:"type,omitempty"`
+	}{alias: (*alias)(s)}
+
+	switch len(s.Types) {
+	case 0:
+	case 1:
+		out.Type = s.Types[0]
+	default:
+		out.Type = s.Types
+	}
+
+	return json.Marshal(out)
+}
+
+// Infer returns the schema matching all the samples. Samples are the result
+// of decoding JSON documents into interface{} values.
+func Infer(title string, samples []interface{}) *Schema {
+	s := &Schema{}
+	for _, sample := range samples {
+		s.add(sample)
+	}
+	s.finalize()
+
+	s.SchemaURI = Draft
+	s.Title = title
+
+	return s
+}
+
+func (s *Schema) add(value interface{}) {
+	switch v := value.(type) {
+	case nil:
+		s.addType(typeNull)
+	case bool:
+		s.addType(typeBoolean)
+	case float64:
+		if v == float64(int64(v)) {
+			s.addType(typeInteger)
+		} else {
+			s.addType(typeNumber)
+		}
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			s.addType(typeInteger)
+		} else {
+			s.addType(typeNumber)
+		}
+	case string:
+		s.addType(typeString)
+	case []interface{}:
+		s.addType(typeArray)
+		if s.Items == nil {
+			s.Items = &Schema{}
+		}
+		for _, item := range v {
+			s.Items.add(item)
+		}
+	case map[string]interface{}:
+		s.addType(typeObject)
+		if s.Properties == nil {
+			s.Properties = map[string]*Schema{}
+			s.seen = map[string]int{}
+		}
+		s.objects++
+		for key, property := range v {
+			if s.Properties[key] == nil {
+				s.Properties[key] = &Schema{}
+			}
+			s.Properties[key].add(property)
+			s.seen[key]++
+		}
+	}
+}
+
+func (s *Schema) addType(t string) {
+	for _, existing := range s.Types {
+		if existing == t {
+			return
+		}
+	}
+
+	// An integer sample doesn't narrow down a number type
+	if t == typeInteger && s.hasType(typeNumber) {
+		return
+	}
+	if t == typeNumber {
+		s.removeType(typeInteger)
+	}
+
+	s.Types = append(s.Types, t)
+	sort.Strings(s.Types)
+}
+
+func (s *Schema) hasType(t string) bool {
+	for _, existing := range s.Types {
+		if existing == t {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Schema) removeType(t string) {
+	types := s.Types[:0]
+	for _, existing := range s.Types {
+		if existing != t {
+			types = append(types, existing)
+		}
+	}
+	s.Types = types
+}
+
+// finalize sets the required properties, the ones present in every object
+// sample.
+func (s *Schema) finalize() {
+	for key, property := range s.Properties {
+		if s.seen[key] == s.objects {
+			s.Required = append(s.Required, key)
+		}
+		property.finalize()
+	}
+	sort.Strings(s.Required)
+
+	if s.Items != nil {
+		s.Items.finalize()
+	}
+}
@@ -0,0 +1,60 @@
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func decode(t *testing.T, documents ...string) []interface{} {
+	samples := make([]interface{}, len(documents))
+	for i, document := range documents {
+		require.NoError(t, json.Unmarshal([]byte(document), &samples[i]))
+	}
+	return samples
+}
+
+func TestInfer(t *testing.T) {
+	s := Infer("Event", decode(t,
+		`{"id":"evt_1","amount":100,"tags":["a"],"customer":{"email":"a@b.c"}}`,
+		`{"id":"evt_2","amount":10.5,"tags":[],"customer":null,"livemode":false}`,
+	))
+
+	data, err := json.Marshal(s)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"title": "Event",
+		"type": "object",
+		"required": ["amount", "customer", "id", "tags"],
+		"properties": {
+			"id": {"type": "string"},
+			"amount": {"type": "number"},
+			"tags": {"type": "array", "items": {"type": "string"}},
+			"customer": {
+				"type": ["null", "object"],
+				"required": ["email"],
+				"properties": {"email": {"type": "string"}}
+			},
+			"livemode": {"type": "boolean"}
+		}
+	}`, string(data))
+}
+
+func TestTypeScript(t *testing.T) {
+	s := Infer("Event", decode(t,
+		`{"id":"evt_1","data-type":"charge","items":[{"qty":1}],"meta":{}}`,
+		`{"id":"evt_2","items":[]}`,
+	))
+
+	require.Equal(t, `export type Event = {
+  "data-type"?: string;
+  id: string;
+  items: {
+    qty: number;
+  }[];
+  meta?: Record<string, unknown>;
+};
+`, TypeScript("Event", s))
+}
@@ -0,0 +1,78 @@
+package schema
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var identifierRegexp = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// TypeScript returns the declaration of a TypeScript type named name that
+// matches the schema.
+func TypeScript(name string, s *Schema) string {
+	return fmt.Sprintf("export type %s = %s;\n", name, typeScriptType(s, ""))
+}
+
+func typeScriptType(s *Schema, indent string) string {
+	if s == nil || len(s.Types) == 0 {
+		return "unknown"
+	}
+
+	types := make([]string, 0, len(s.Types))
+	for _, t := range s.Types {
+		switch t {
+		case typeNull:
+			types = append(types, "null")
+		case typeBoolean:
+			types = append(types, "boolean")
+		case typeInteger, typeNumber:
+			types = append(types, "number")
+		case typeString:
+			types = append(types, "string")
+		case typeArray:
+			item := typeScriptType(s.Items, indent)
+			if s.Items != nil && len(s.Items.Types) > 1 {
+				item = "(" + item + ")"
+			}
+			types = append(types, item+"[]")
+		case typeObject:
+			types = append(types, typeScriptObject(s, indent))
+		}
+	}
+
+	return strings.Join(types, " | ")
+}
+
+func typeScriptObject(s *Schema, indent string) string {
+	if len(s.Properties) == 0 {
+		return "Record<string, unknown>"
+	}
+
+	keys := make([]string, 0, len(s.Properties))
+	for key := range s.Properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString("{\n")
+	for _, key := range keys {
+		name := key
+		if !identifierRegexp.MatchString(key) {
+			name = fmt.Sprintf("%q", key)
+		}
+		optional := "?"
+		for _, required := range s.Required {
+			if required == key {
+				optional = ""
+				break
+			}
+		}
+		fmt.Fprintf(&b, "%s  %s%s: %s;\n", indent, name, optional, typeScriptType(s.Properties[key], indent+"  "))
+	}
+	b.WriteString(indent + "}")
+
+	return b.String()
+}
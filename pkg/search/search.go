@@ -0,0 +1,287 @@
+// Package search finds the resources of a project referencing a term, across
+// connections, sources, destinations and transformations.
+package search
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	hookdecksdk "github.com/hookdeck/hookdeck-go-sdk"
+	hookdeckclient "github.com/hookdeck/hookdeck-go-sdk/client"
+)
+
+// Types of the resources searched.
+const (
+	TypeConnection     = "connection"
+	TypeSource         = "source"
+	TypeDestination    = "destination"
+	TypeTransformation = "transformation"
+)
+
+// Types are all the types of resources searched, in the order of the results.
+var Types = []string{TypeConnection, TypeSource, TypeDestination, TypeTransformation}
+
+// pageSize is the number of resources listed per request.
+const pageSize = 250
+
+// maxValueLength is the length above which the matched values are
+// truncated around the term.
+const maxValueLength = 80
+
+// Result is a resource matching the term.
+type Result struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Field is the field of the resource the term was found in, e.g. url
+	Field string `json:"field"`
+	// Value is the excerpt of the field around the term
+	Value string `json:"value"`
+}
+
+// field is a searchable field of a resource.
+type field struct {
+	name  string
+	value string
+}
+
+// Search lists the resources of the given types, all of them when types is
+// empty, and returns those with a field containing the term, ignoring case.
+// The API only filters by exact name, so the matching is done client side.
+func Search(ctx context.Context, client *hookdeckclient.Client, term string, types []string) ([]Result, error) {
+	if len(types) == 0 {
+		types = Types
+	}
+	selected := map[string]bool{}
+	for _, t := range types {
+		selected[t] = true
+	}
+
+	results := []Result{}
+	for _, t := range Types {
+		if !selected[t] {
+			continue
+		}
+
+		var found []Result
+		var err error
+		switch t {
+		case TypeConnection:
+			found, err = searchConnections(ctx, client, term)
+		case TypeSource:
+			found, err = searchSources(ctx, client, term)
+		case TypeDestination:
+			found, err = searchDestinations(ctx, client, term)
+		case TypeTransformation:
+			found, err = searchTransformations(ctx, client, term)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("searching %ss: %w", t, err)
+		}
+		results = append(results, found...)
+	}
+
+	return results, nil
+}
+
+// ValidateTypes checks that the types are supported.
+func ValidateTypes(types []string) error {
+	for _, t := range types {
+		supported := false
+		for _, s := range Types {
+			supported = supported || s == t
+		}
+		if !supported {
+			return fmt.Errorf("unsupported type %q. Expected one of %s", t, strings.Join(Types, ", "))
+		}
+	}
+	return nil
+}
+
+func searchConnections(ctx context.Context, client *hookdeckclient.Client, term string) ([]Result, error) {
+	var results []Result
+	request := &hookdecksdk.ConnectionListRequest{Limit: hookdecksdk.Int(pageSize)}
+	for {
+		page, err := client.Connection.List(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		for _, connection := range page.Models {
+			results = append(results, matchConnection(connection, term)...)
+		}
+		if page.Pagination == nil || page.Pagination.Next == nil || *page.Pagination.Next == "" {
+			return results, nil
+		}
+		request.Next = page.Pagination.Next
+	}
+}
+
+func searchSources(ctx context.Context, client *hookdeckclient.Client, term string) ([]Result, error) {
+	var results []Result
+	request := &hookdecksdk.SourceListRequest{Limit: hookdecksdk.Int(pageSize)}
+	for {
+		page, err := client.Source.List(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		for _, source := range page.Models {
+			results = append(results, matchSource(source, term)...)
+		}
+		if page.Pagination == nil || page.Pagination.Next == nil || *page.Pagination.Next == "" {
+			return results, nil
+		}
+		request.Next = page.Pagination.Next
+	}
+}
+
+func searchDestinations(ctx context.Context, client *hookdeckclient.Client, term string) ([]Result, error) {
+	var results []Result
+	request := &hookdecksdk.DestinationListRequest{Limit: hookdecksdk.Int(pageSize)}
+	for {
+		page, err := client.Destination.List(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		for _, destination := range page.Models {
+			results = append(results, matchDestination(destination, term)...)
+		}
+		if page.Pagination == nil || page.Pagination.Next == nil || *page.Pagination.Next == "" {
+			return results, nil
+		}
+		request.Next = page.Pagination.Next
+	}
+}
+
+func searchTransformations(ctx context.Context, client *hookdeckclient.Client, term string) ([]Result, error) {
+	var results []Result
+	request := &hookdecksdk.TransformationListRequest{Limit: hookdecksdk.Int(pageSize)}
+	for {
+		page, err := client.Transformation.List(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		for _, transformation := range page.Models {
+			results = append(results, matchTransformation(transformation, term)...)
+		}
+		if page.Pagination == nil || page.Pagination.Next == nil || *page.Pagination.Next == "" {
+			return results, nil
+		}
+		request.Next = page.Pagination.Next
+	}
+}
+
+// matchConnection matches the connection itself and the URL of its
+// destination, so that searching a URL finds the connections delivering to
+// it.
+func matchConnection(connection *hookdecksdk.Connection, term string) []Result {
+	fields := []field{
+		{"name", deref(connection.Name)},
+		{"full_name", deref(connection.FullName)},
+		{"description", deref(connection.Description)},
+	}
+	if connection.Source != nil {
+		fields = append(fields, field{"source.url", connection.Source.Url})
+	}
+	if connection.Destination != nil {
+		fields = append(fields,
+			field{"destination.url", deref(connection.Destination.Url)},
+			field{"destination.cli_path", deref(connection.Destination.CliPath)},
+		)
+	}
+
+	name := deref(connection.FullName)
+	if name == "" {
+		name = deref(connection.Name)
+	}
+	return match(TypeConnection, connection.Id, name, fields, term)
+}
+
+func matchSource(source *hookdecksdk.Source, term string) []Result {
+	return match(TypeSource, source.Id, source.Name, []field{
+		{"name", source.Name},
+		{"description", deref(source.Description)},
+		{"url", source.Url},
+	}, term)
+}
+
+func matchDestination(destination *hookdecksdk.Destination, term string) []Result {
+	return match(TypeDestination, destination.Id, destination.Name, []field{
+		{"name", destination.Name},
+		{"description", deref(destination.Description)},
+		{"url", deref(destination.Url)},
+		{"cli_path", deref(destination.CliPath)},
+	}, term)
+}
+
+// matchTransformation matches the code of the transformation, which can
+// reference URLs, as it doesn't have a description.
+func matchTransformation(transformation *hookdecksdk.Transformation, term string) []Result {
+	return match(TypeTransformation, transformation.Id, transformation.Name, []field{
+		{"name", transformation.Name},
+		{"code", transformation.Code},
+	}, term)
+}
+
+// match returns a result for the first field of a resource containing the
+// term, or none.
+func match(resourceType string, id string, name string, fields []field, term string) []Result {
+	lowerTerm := strings.ToLower(term)
+	for _, f := range fields {
+		index := strings.Index(strings.ToLower(f.value), lowerTerm)
+		if index == -1 {
+			continue
+		}
+		return []Result{{
+			Type:  resourceType,
+			ID:    id,
+			Name:  name,
+			Field: f.name,
+			Value: excerpt(f.value, index, len(term)),
+		}}
+	}
+	return nil
+}
+
+// excerpt returns the line of value containing the match at index, trimmed
+// and truncated around the match when too long.
+func excerpt(value string, index int, length int) string {
+	// Lowercasing can change the length of some characters
+	if index > len(value) {
+		index = len(value)
+	}
+	start := strings.LastIndex(value[:index], "\n") + 1
+	end := len(value)
+	if i := strings.Index(value[index:], "\n"); i != -1 {
+		end = index + i
+	}
+
+	if end-start > maxValueLength {
+		from := index - (maxValueLength-length)/2
+		if from < start {
+			from = start
+		}
+		to := from + maxValueLength
+		if to > end {
+			to = end
+			from = end - maxValueLength
+		}
+		line := value[from:to]
+		if from > start {
+			line = "…" + line
+		}
+		if to < end {
+			line += "…"
+		}
+		return strings.TrimSpace(line)
+	}
+
+	return strings.TrimSpace(value[start:end])
+}
+
+func deref(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
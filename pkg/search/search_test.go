@@ -0,0 +1,53 @@
+package search
+
+import (
+	"strings"
+	"testing"
+
+	hookdecksdk "github.com/hookdeck/hookdeck-go-sdk"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchConnectionByDestinationURL(t *testing.T) {
+	connection := &hookdecksdk.Connection{
+		Id:       "web_123",
+		Name:     hookdecksdk.String("orders"),
+		FullName: hookdecksdk.String("shopify -> orders"),
+		Destination: &hookdecksdk.Destination{
+			Url: hookdecksdk.String("https://api.example.com/webhooks/orders"),
+		},
+	}
+
+	results := matchConnection(connection, "API.example.com")
+	require.Equal(t, []Result{{
+		Type:  TypeConnection,
+		ID:    "web_123",
+		Name:  "shopify -> orders",
+		Field: "destination.url",
+		Value: "https://api.example.com/webhooks/orders",
+	}}, results)
+
+	require.Empty(t, matchConnection(connection, "stripe"))
+}
+
+func TestMatchTransformationCodeExcerpt(t *testing.T) {
+	transformation := &hookdecksdk.Transformation{
+		Id:   "trs_123",
+		Name: "enrich",
+		Code: "addHandler('transform', (request) => {\n  request.headers['x-origin'] = 'https://old.example.com';\n  return request;\n});",
+	}
+
+	results := matchTransformation(transformation, "old.example.com")
+	require.Len(t, results, 1)
+	require.Equal(t, "code", results[0].Field)
+	require.Equal(t, "request.headers['x-origin'] = 'https://old.example.com';", results[0].Value)
+}
+
+func TestExcerptTruncatesLongLines(t *testing.T) {
+	value := strings.Repeat("a", 100) + "needle" + strings.Repeat("b", 100)
+
+	result := excerpt(value, 100, len("needle"))
+	require.True(t, strings.HasPrefix(result, "…"))
+	require.True(t, strings.HasSuffix(result, "…"))
+	require.Contains(t, result, "needle")
+}
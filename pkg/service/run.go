@@ -0,0 +1,12 @@
+package service
+
+// RunOptions configures the command run by a Windows service.
+type RunOptions struct {
+	// Directory the command runs in
+	WorkingDirectory string
+	// ConfigHome is set as XDG_CONFIG_HOME for the command to use the
+	// config of the user who installed the service
+	ConfigHome string
+	// File the output of the command is appended to
+	OutputFile string
+}
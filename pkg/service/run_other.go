@@ -0,0 +1,13 @@
+//go:build !windows
+// +build !windows
+
+package service
+
+import (
+	"errors"
+)
+
+// Run runs command as a Windows service. It's only supported on Windows.
+func Run(command []string, options RunOptions) error {
+	return errors.New("services are only run this way on Windows")
+}
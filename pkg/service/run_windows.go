@@ -0,0 +1,73 @@
+//go:build windows
+// +build windows
+
+package service
+
+import (
+	"os"
+	"os/exec"
+
+	"golang.org/x/sys/windows/svc"
+)
+
+// Run runs command as a Windows service, until the service is stopped or
+// the command exits. It's called by the Windows service manager through
+// `hookdeck service run`.
+func Run(command []string, options RunOptions) error {
+	return svc.Run("", &runner{command: command, options: options})
+}
+
+type runner struct {
+	command []string
+	options RunOptions
+}
+
+// Execute starts the command and reports its state to the Windows service
+// manager. A command exiting with an error stops the service with an error,
+// for the service manager to restart it.
+func (r *runner) Execute(args []string, requests <-chan svc.ChangeRequest, status chan<- svc.Status) (bool, uint32) {
+	status <- svc.Status{State: svc.StartPending}
+
+	cmd := exec.Command(r.command[0], r.command[1:]...)
+	cmd.Dir = r.options.WorkingDirectory
+	cmd.Env = os.Environ()
+	if r.options.ConfigHome != "" {
+		cmd.Env = append(cmd.Env, "XDG_CONFIG_HOME="+r.options.ConfigHome)
+	}
+	if r.options.OutputFile != "" {
+		output, err := os.OpenFile(r.options.OutputFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return true, 1
+		}
+		defer output.Close()
+		cmd.Stdout = output
+		cmd.Stderr = output
+	}
+
+	if err := cmd.Start(); err != nil {
+		return true, 1
+	}
+	exited := make(chan error, 1)
+	go func() { exited <- cmd.Wait() }()
+
+	status <- svc.Status{State: svc.Running, Accepts: svc.AcceptStop | svc.AcceptShutdown}
+	for {
+		select {
+		case err := <-exited:
+			if err != nil {
+				return true, 1
+			}
+			return false, 0
+		case request := <-requests:
+			switch request.Cmd {
+			case svc.Interrogate:
+				status <- request.CurrentStatus
+			case svc.Stop, svc.Shutdown:
+				status <- svc.Status{State: svc.StopPending}
+				cmd.Process.Kill()
+				<-exited
+				return false, 0
+			}
+		}
+	}
+}
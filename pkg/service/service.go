@@ -0,0 +1,310 @@
+// Package service generates the definitions used to run `hookdeck listen`
+// as a background service: systemd user units on Linux, launchd agents on
+// macOS and Windows services, registered by a PowerShell script.
+package service
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"text/template"
+)
+
+// Service describes a `hookdeck listen` process run as a service.
+type Service struct {
+	// Name of the service, used in the unit or agent name
+	Name string
+	// Absolute path to the hookdeck executable
+	Executable string
+	// Arguments passed to the executable, e.g. listen 3000 shopify
+	Args []string
+	// File the output is written to. If empty, the service manager default
+	// is used (the journal for systemd).
+	LogFile string
+	// Directory the service runs in
+	WorkingDirectory string
+	// ConfigHome is the directory of the hookdeck config of the user, for
+	// Windows services which run as the LocalSystem account
+	ConfigHome string
+	// Operating system to generate the service for, defaults to the
+	// current one
+	OS string
+}
+
+func (s *Service) os() string {
+	if s.OS != "" {
+		return s.OS
+	}
+	return runtime.GOOS
+}
+
+// Command returns the command line run by the service.
+func (s *Service) Command() []string {
+	return append([]string{s.Executable}, s.Args...)
+}
+
+// WindowsCommand returns the command line of a Windows service. The Windows
+// service manager starts `hookdeck service run`, which runs Command.
+func (s *Service) WindowsCommand() []string {
+	command := []string{s.Executable, "service", "run"}
+	if s.WorkingDirectory != "" {
+		command = append(command, "--working-directory", s.WorkingDirectory)
+	}
+	if s.ConfigHome != "" {
+		command = append(command, "--config-home", s.ConfigHome)
+	}
+	if s.LogFile != "" {
+		command = append(command, "--output-file", s.LogFile)
+	}
+	return append(append(command, "--"), s.Command()...)
+}
+
+// Label returns the identifier of the service for the service manager.
+func (s *Service) Label() string {
+	if s.os() == "darwin" {
+		return "com.hookdeck.cli." + s.Name
+	}
+	return "hookdeck-" + s.Name
+}
+
+// Path returns the path of the service definition file.
+func (s *Service) Path() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	switch s.os() {
+	case "linux":
+		configHome := os.Getenv("XDG_CONFIG_HOME")
+		if configHome == "" {
+			configHome = filepath.Join(home, ".config")
+		}
+		return filepath.Join(configHome, "systemd", "user", s.Label()+".service"), nil
+	case "darwin":
+		return filepath.Join(home, "Library", "LaunchAgents", s.Label()+".plist"), nil
+	case "windows":
+		return filepath.Join(home, "AppData", "Local", "Hookdeck", "services", s.Label()+".ps1"), nil
+	default:
+		return "", fmt.Errorf("services are not supported on %s", s.os())
+	}
+}
+
+// Definition renders the service definition for the current platform.
+func (s *Service) Definition() (string, error) {
+	switch s.os() {
+	case "linux":
+		return render(systemdTemplate, s)
+	case "darwin":
+		return render(launchdTemplate, s)
+	case "windows":
+		return render(windowsTemplate, s)
+	default:
+		_, err := s.Path()
+		return "", err
+	}
+}
+
+// Install writes the service definition and returns its path.
+func (s *Service) Install() (string, error) {
+	path, err := s.Path()
+	if err != nil {
+		return "", err
+	}
+
+	definition, err := s.Definition()
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", err
+	}
+	if s.LogFile != "" {
+		if err := os.MkdirAll(filepath.Dir(s.LogFile), 0755); err != nil {
+			return "", err
+		}
+	}
+
+	return path, ioutil.WriteFile(path, []byte(definition), 0644)
+}
+
+// Uninstall removes the service definition and returns its path.
+func (s *Service) Uninstall() (string, error) {
+	path, err := s.Path()
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.Remove(path); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", fmt.Errorf("service %q is not installed", s.Name)
+		}
+		return "", err
+	}
+
+	return path, nil
+}
+
+// StartCommands returns the commands to run to start the installed service.
+func (s *Service) StartCommands(path string) []string {
+	switch s.os() {
+	case "darwin":
+		return []string{"launchctl load -w " + path}
+	case "windows":
+		// From an administrator prompt
+		return []string{
+			"powershell -ExecutionPolicy Bypass -File " + windowsQuote(path),
+			"sc.exe start " + s.Label(),
+		}
+	}
+	return []string{
+		"systemctl --user daemon-reload",
+		"systemctl --user enable --now " + s.Label(),
+	}
+}
+
+// StopCommands returns the commands to run to stop the service before
+// uninstalling it.
+func (s *Service) StopCommands(path string) []string {
+	switch s.os() {
+	case "darwin":
+		return []string{"launchctl unload -w " + path}
+	case "windows":
+		return []string{
+			"sc.exe stop " + s.Label(),
+			"sc.exe delete " + s.Label(),
+		}
+	}
+	return []string{"systemctl --user disable --now " + s.Label()}
+}
+
+func render(tmpl *template.Template, s *Service) (string, error) {
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, s); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+var funcs = template.FuncMap{
+	"systemdQuote": func(args []string) string {
+		quoted := make([]string, len(args))
+		for i, arg := range args {
+			if strings.ContainsAny(arg, " \t\"'\\$%") {
+				arg = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "$", "$$", "%", "%%").Replace(arg)
+				arg = `"` + arg + `"`
+			}
+			quoted[i] = arg
+		}
+		return strings.Join(quoted, " ")
+	},
+	"windowsCommandLine": func(args []string) string {
+		quoted := make([]string, len(args))
+		for i, arg := range args {
+			quoted[i] = windowsQuote(arg)
+		}
+		return strings.Join(quoted, " ")
+	},
+	"powershell": func(value string) string {
+		return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+	},
+	"xml": func(value string) string {
+		return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;", "'", "&apos;").Replace(value)
+	},
+}
+
+// windowsQuote quotes an argument of a Windows command line, as parsed by
+// CommandLineToArgvW.
+func windowsQuote(arg string) string {
+	if arg != "" && !strings.ContainsAny(arg, " \t\"") {
+		return arg
+	}
+
+	var b strings.Builder
+	b.WriteByte('"')
+	backslashes := 0
+	for i := 0; i < len(arg); i++ {
+		switch arg[i] {
+		case '\\':
+			backslashes++
+			continue
+		case '"':
+			// The backslashes preceding a quote, and the quote, are escaped
+			b.WriteString(strings.Repeat(`\`, 2*backslashes+1))
+		default:
+			b.WriteString(strings.Repeat(`\`, backslashes))
+		}
+		backslashes = 0
+		b.WriteByte(arg[i])
+	}
+	b.WriteString(strings.Repeat(`\`, 2*backslashes))
+	b.WriteByte('"')
+	return b.String()
+}
+
+var systemdTemplate = template.Must(template.New("systemd").Funcs(funcs).Parse(`[Unit]
+Description=Hookdeck CLI listener ({{ .Name }})
+After=network-online.target
+Wants=network-online.target
+
+[Service]
+ExecStart={{ systemdQuote .Command }}
+{{- if .WorkingDirectory }}
+WorkingDirectory={{ .WorkingDirectory }}
+{{- end }}
+Restart=always
+RestartSec=5
+{{- if .LogFile }}
+StandardOutput=append:{{ .LogFile }}
+StandardError=append:{{ .LogFile }}
+{{- end }}
+
+[Install]
+WantedBy=default.target
+`))
+
+var launchdTemplate = template.Must(template.New("launchd").Funcs(funcs).Parse(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>Label</key>
+	<string>{{ xml .Label }}</string>
+	<key>ProgramArguments</key>
+	<array>
+		<string>{{ xml .Executable }}</string>
+{{- range .Args }}
+		<string>{{ xml . }}</string>
+{{- end }}
+	</array>
+{{- if .WorkingDirectory }}
+	<key>WorkingDirectory</key>
+	<string>{{ xml .WorkingDirectory }}</string>
+{{- end }}
+	<key>RunAtLoad</key>
+	<true/>
+	<key>KeepAlive</key>
+	<true/>
+{{- if .LogFile }}
+	<key>StandardOutPath</key>
+	<string>{{ xml .LogFile }}</string>
+	<key>StandardErrorPath</key>
+	<string>{{ xml .LogFile }}</string>
+{{- end }}
+</dict>
+</plist>
+`))
+
+// windowsTemplate registers the service, restarted by the Windows service
+// manager when the listener exits with an error.
+var windowsTemplate = template.Must(template.New("windows").Funcs(funcs).Parse(`# Registers the Hookdeck CLI listener ({{ .Name }}) as a Windows service.
+# Run it from an administrator PowerShell.
+New-Service -Name {{ powershell .Label }} -DisplayName {{ powershell (printf "Hookdeck CLI listener (%s)" .Name) }} -StartupType Automatic -BinaryPathName {{ powershell (windowsCommandLine .WindowsCommand) }}
+sc.exe failure {{ powershell .Label }} reset= 86400 actions= restart/5000
+sc.exe failureflag {{ powershell .Label }} 1
+`))
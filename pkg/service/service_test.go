@@ -0,0 +1,75 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefinition_Systemd(t *testing.T) {
+	s := &Service{
+		Name:       "shopify",
+		Executable: "/usr/local/bin/hookdeck",
+		Args:       []string{"listen", "3000", "my shop", "--path", "/webhooks"},
+		LogFile:    "/var/log/hookdeck.log",
+		OS:         "linux",
+	}
+
+	definition, err := s.Definition()
+	require.NoError(t, err)
+	require.Contains(t, definition, "ExecStart=/usr/local/bin/hookdeck listen 3000 \"my shop\" --path /webhooks\n")
+	require.Contains(t, definition, "StandardOutput=append:/var/log/hookdeck.log\n")
+	require.NotContains(t, definition, "WorkingDirectory")
+	require.Equal(t, "hookdeck-shopify", s.Label())
+}
+
+func TestDefinition_Launchd(t *testing.T) {
+	s := &Service{
+		Name:             "stripe",
+		Executable:       "/opt/homebrew/bin/hookdeck",
+		Args:             []string{"listen", "4242", "stripe&co"},
+		WorkingDirectory: "/Users/dev/app",
+		OS:               "darwin",
+	}
+
+	definition, err := s.Definition()
+	require.NoError(t, err)
+	require.Contains(t, definition, "<string>com.hookdeck.cli.stripe</string>")
+	require.Contains(t, definition, "\t\t<string>/opt/homebrew/bin/hookdeck</string>\n\t\t<string>listen</string>\n\t\t<string>4242</string>\n\t\t<string>stripe&amp;co</string>\n")
+	require.Contains(t, definition, "<key>WorkingDirectory</key>\n\t<string>/Users/dev/app</string>")
+	require.NotContains(t, definition, "StandardOutPath")
+}
+
+func TestDefinition_Windows(t *testing.T) {
+	s := &Service{
+		Name:             "shopify",
+		Executable:       `C:\Program Files\Hookdeck\hookdeck.exe`,
+		Args:             []string{"--profile", "default", "listen", "3000", "Bob's shop"},
+		LogFile:          `C:\Users\dev\AppData\Local\Hookdeck\Logs\shopify.log`,
+		WorkingDirectory: `C:\Users\dev\app`,
+		ConfigHome:       `C:\Users\dev\.config`,
+		OS:               "windows",
+	}
+
+	definition, err := s.Definition()
+	require.NoError(t, err)
+	require.Contains(t, definition, `New-Service -Name 'hookdeck-shopify' -DisplayName 'Hookdeck CLI listener (shopify)' -StartupType Automatic -BinaryPathName '"C:\Program Files\Hookdeck\hookdeck.exe" service run --working-directory C:\Users\dev\app --config-home C:\Users\dev\.config --output-file C:\Users\dev\AppData\Local\Hookdeck\Logs\shopify.log -- "C:\Program Files\Hookdeck\hookdeck.exe" --profile default listen 3000 "Bob''s shop"'`+"\n")
+	require.Contains(t, definition, "sc.exe failure 'hookdeck-shopify' reset= 86400 actions= restart/5000\n")
+	require.Equal(t, []string{"sc.exe stop hookdeck-shopify", "sc.exe delete hookdeck-shopify"}, s.StopCommands(""))
+}
+
+func TestWindowsQuote(t *testing.T) {
+	require.Equal(t, `listen`, windowsQuote(`listen`))
+	require.Equal(t, `""`, windowsQuote(``))
+	require.Equal(t, `"my shop"`, windowsQuote(`my shop`))
+	require.Equal(t, `"say \"hi\""`, windowsQuote(`say "hi"`))
+	require.Equal(t, `"C:\my dir\\"`, windowsQuote(`C:\my dir\`))
+	require.Equal(t, `"a\\\"b"`, windowsQuote(`a\"b`))
+}
+
+func TestDefinition_Unsupported(t *testing.T) {
+	s := &Service{Name: "test", OS: "freebsd"}
+
+	_, err := s.Definition()
+	require.Error(t, err)
+}
@@ -0,0 +1,14 @@
+package signature
+
+// samples are minimal payloads resembling what each provider sends.
+var samples = map[string]string{
+	"hookdeck": `{"type":"sample.event","data":{"id":"sample_123"}}`,
+	"stripe":   `{"id":"evt_sample","object":"event","type":"payment_intent.succeeded","data":{"object":{"id":"pi_sample","object":"payment_intent","amount":2000,"currency":"usd"}}}`,
+	"github":   `{"zen":"Keep it logically awesome.","hook_id":123456,"hook":{"type":"Repository","active":true,"events":["push"]}}`,
+	"shopify":  `{"id":820982911946154508,"email":"jon@example.com","total_price":"199.00","currency":"USD"}`,
+}
+
+// SampleBody returns a sample payload for the provider.
+func SampleBody(provider string) string {
+	return samples[provider]
+}
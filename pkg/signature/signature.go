@@ -0,0 +1,126 @@
+package signature
+
+import (
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// signer adds the provider specific signature headers for a body.
+type signer func(secret string, body string, timestamp time.Time) http.Header
+
+var signers = map[string]signer{
+	"hookdeck": signHookdeck,
+	"stripe":   signStripe,
+	"github":   signGitHub,
+	"shopify":  signShopify,
+}
+
+// Providers returns the sorted list of supported source types.
+func Providers() []string {
+	providers := make([]string, 0, len(signers))
+	for name := range signers {
+		providers = append(providers, name)
+	}
+	sort.Strings(providers)
+	return providers
+}
+
+// Sign returns the headers a provider would send along with body, signed
+// with secret.
+func Sign(provider string, secret string, body string, timestamp time.Time) (http.Header, error) {
+	sign, ok := signers[provider]
+	if !ok {
+		return nil, fmt.Errorf("unsupported source type %q. Supported types: %v", provider, Providers())
+	}
+
+	header := sign(secret, body, timestamp)
+	header.Set("Content-Type", "application/json")
+
+	return header, nil
+}
+
+// Hookdeck returns the base64 encoded HMAC SHA256 of body, matching the
+// x-hookdeck-signature header.
+func Hookdeck(secret string, body string) string {
+	return base64.StdEncoding.EncodeToString(hmacSHA256(secret, body))
+}
+
+func signHookdeck(secret string, body string, timestamp time.Time) http.Header {
+	header := http.Header{}
+	header.Set("X-Hookdeck-Signature", Hookdeck(secret, body))
+	return header
+}
+
+func signStripe(secret string, body string, timestamp time.Time) http.Header {
+	ts := strconv.FormatInt(timestamp.Unix(), 10)
+	mac := hex.EncodeToString(hmacSHA256(secret, ts+"."+body))
+
+	header := http.Header{}
+	header.Set("Stripe-Signature", fmt.Sprintf("t=%s,v1=%s", ts, mac))
+	return header
+}
+
+func signGitHub(secret string, body string, timestamp time.Time) http.Header {
+	header := http.Header{}
+	header.Set("X-GitHub-Event", "ping")
+	header.Set("X-Hub-Signature-256", "sha256="+hex.EncodeToString(hmacSHA256(secret, body)))
+	return header
+}
+
+func signShopify(secret string, body string, timestamp time.Time) http.Header {
+	header := http.Header{}
+	header.Set("X-Shopify-Topic", "orders/create")
+	header.Set("X-Shopify-Hmac-Sha256", base64.StdEncoding.EncodeToString(hmacSHA256(secret, body)))
+	return header
+}
+
+// HMAC returns the HMAC of body for a generic HMAC verification, using the
+// given algorithm (md5, sha1, sha256, sha512) and encoding (base64,
+// base64url, hex).
+func HMAC(secret string, body string, algorithm string, encoding string) (string, error) {
+	var h func() hash.Hash
+	switch algorithm {
+	case "md5":
+		h = md5.New
+	case "sha1":
+		h = sha1.New
+	case "sha256", "":
+		h = sha256.New
+	case "sha512":
+		h = sha512.New
+	default:
+		return "", fmt.Errorf("unsupported HMAC algorithm %q", algorithm)
+	}
+
+	mac := hmac.New(h, []byte(secret))
+	mac.Write([]byte(body))
+	sum := mac.Sum(nil)
+
+	switch encoding {
+	case "base64", "":
+		return base64.StdEncoding.EncodeToString(sum), nil
+	case "base64url":
+		return base64.URLEncoding.EncodeToString(sum), nil
+	case "hex":
+		return hex.EncodeToString(sum), nil
+	default:
+		return "", fmt.Errorf("unsupported HMAC encoding %q", encoding)
+	}
+}
+
+func hmacSHA256(secret string, body string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return mac.Sum(nil)
+}
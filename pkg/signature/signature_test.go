@@ -0,0 +1,33 @@
+package signature
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSign(t *testing.T) {
+	timestamp := time.Unix(1700000000, 0)
+
+	header, err := Sign("stripe", "whsec_test", `{"id":"evt_1"}`, timestamp)
+	require.NoError(t, err)
+	require.Equal(t, "t=1700000000,v1=c89214b5b5da833daed6f0b8c5bb6bd58cea9022bd80ccc78230f3942d632925", header.Get("Stripe-Signature"))
+	require.Equal(t, "application/json", header.Get("Content-Type"))
+
+	_, err = Sign("unknown", "secret", "", timestamp)
+	require.Error(t, err)
+}
+
+func TestHookdeck(t *testing.T) {
+	require.Equal(t, "JnetPnwJCy+iwPsTAg1m1UIIebgxbrNWotYPuQc7x3g=", Hookdeck("secret", `{"hello":"world"}`))
+}
+
+func TestHMAC(t *testing.T) {
+	value, err := HMAC("secret", `{"hello":"world"}`, "sha256", "base64")
+	require.NoError(t, err)
+	require.Equal(t, Hookdeck("secret", `{"hello":"world"}`), value)
+
+	_, err = HMAC("secret", "", "sha3", "hex")
+	require.Error(t, err)
+}
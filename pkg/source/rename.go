@@ -0,0 +1,78 @@
+package source
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	hookdecksdk "github.com/hookdeck/hookdeck-go-sdk"
+	hookdeckclient "github.com/hookdeck/hookdeck-go-sdk/client"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/validators"
+)
+
+// Rename is the renaming of a source.
+type Rename struct {
+	Source *hookdecksdk.Source
+	Name   string
+	// Connections are the connections of the source, whose full name
+	// starts with the name of the source
+	Connections []*hookdecksdk.Connection
+}
+
+// PlanRename checks that no other source is named name, and returns the
+// renaming of the source with the connections it affects.
+func PlanRename(client *hookdeckclient.Client, source *hookdecksdk.Source, name string) (*Rename, error) {
+	if err := validators.ResourceName(name); err != nil {
+		return nil, err
+	}
+	if source.Name == name {
+		return nil, fmt.Errorf("the source is already named %q", name)
+	}
+
+	existing, err := client.Source.List(context.Background(), &hookdecksdk.SourceListRequest{
+		Name: &name,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, other := range existing.Models {
+		if other.Id != source.Id {
+			return nil, fmt.Errorf("a source named %q already exists: %s", name, other.Id)
+		}
+	}
+
+	rename := &Rename{Source: source, Name: name}
+	request := &hookdecksdk.ConnectionListRequest{
+		SourceId: []*string{&source.Id},
+		Limit:    hookdecksdk.Int(250),
+	}
+	for {
+		page, err := client.Connection.List(context.Background(), request)
+		if err != nil {
+			return nil, err
+		}
+		rename.Connections = append(rename.Connections, page.Models...)
+		if page.Pagination == nil || page.Pagination.Next == nil || *page.Pagination.Next == "" {
+			return rename, nil
+		}
+		request.Next = page.Pagination.Next
+	}
+}
+
+// FullName returns the full name of a connection of the source once
+// renamed, which starts with the name of the source.
+func (r *Rename) FullName(connection *hookdecksdk.Connection) string {
+	if connection.FullName == nil {
+		return ""
+	}
+	return r.Name + strings.TrimPrefix(*connection.FullName, r.Source.Name)
+}
+
+// Apply renames the source, leaving its other settings unchanged. Hookdeck
+// updates the full names of its connections.
+func (r *Rename) Apply(client *hookdeckclient.Client) (*hookdecksdk.Source, error) {
+	return client.Source.Update(context.Background(), r.Source.Id, &hookdecksdk.SourceUpdateRequest{
+		Name: hookdecksdk.Optional(r.Name),
+	})
+}
@@ -0,0 +1,57 @@
+package source
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	hookdecksdk "github.com/hookdeck/hookdeck-go-sdk"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/hookdeck"
+	"github.com/hookdeck/hookdeck-cli/pkg/mockapi"
+)
+
+func TestRename(t *testing.T) {
+	ts := httptest.NewServer(mockapi.New())
+	defer ts.Close()
+
+	client := hookdeck.CreateSDKClient(hookdeck.SDKClientInit{
+		APIBaseURL: ts.URL,
+		APIKey:     mockapi.APIKey,
+	})
+
+	stripe, err := client.Source.Create(context.Background(), &hookdecksdk.SourceCreateRequest{Name: "stripe"})
+	require.NoError(t, err)
+	_, err = client.Source.Create(context.Background(), &hookdecksdk.SourceCreateRequest{Name: "shopify"})
+	require.NoError(t, err)
+	connectionName := "stripe_to_cli"
+	_, err = client.Connection.Create(context.Background(), &hookdecksdk.ConnectionCreateRequest{
+		Name:        hookdecksdk.OptionalOrNull(&connectionName),
+		SourceId:    hookdecksdk.OptionalOrNull(&stripe.Id),
+		Destination: hookdecksdk.OptionalOrNull(&hookdecksdk.ConnectionCreateRequestDestination{Name: "cli"}),
+	})
+	require.NoError(t, err)
+
+	_, err = PlanRename(client, stripe, "shopify")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "already exists")
+	_, err = PlanRename(client, stripe, "stripe eu")
+	require.Error(t, err)
+
+	rename, err := PlanRename(client, stripe, "stripe-eu")
+	require.NoError(t, err)
+	require.Len(t, rename.Connections, 1)
+	require.Equal(t, "stripe-eu -> cli", rename.FullName(rename.Connections[0]))
+
+	renamed, err := rename.Apply(client)
+	require.NoError(t, err)
+	require.Equal(t, "stripe-eu", renamed.Name)
+	require.Equal(t, stripe.Url, renamed.Url)
+
+	connections, err := client.Connection.List(context.Background(), &hookdecksdk.ConnectionListRequest{
+		SourceId: []*string{&stripe.Id},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "stripe-eu -> cli", *connections.Models[0].FullName)
+}
@@ -0,0 +1,46 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+
+	hookdecksdk "github.com/hookdeck/hookdeck-go-sdk"
+	hookdeckclient "github.com/hookdeck/hookdeck-go-sdk/client"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/hookdeck"
+)
+
+// SampleBodies returns the decoded JSON bodies of the most recent requests
+// received by the source. Bodies that aren't JSON are skipped.
+func SampleBodies(client *hookdeckclient.Client, source *hookdecksdk.Source, limit int) ([]interface{}, error) {
+	requests, err := client.Request.List(context.Background(), &hookdecksdk.RequestListRequest{
+		SourceId: []*string{&source.Id},
+		Limit:    &limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	bodies, err := hookdeck.Batch(context.Background(), requests.Models, hookdeck.BatchOptions{}, func(ctx context.Context, request *hookdecksdk.Request) (*hookdecksdk.RawBody, error) {
+		return client.Request.RetrieveBody(ctx, request.Id)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	samples := []interface{}{}
+	for i, raw := range bodies {
+		var sample interface{}
+		if err := json.Unmarshal([]byte(raw.Body), &sample); err != nil {
+			log.WithFields(log.Fields{
+				"prefix":  "source.SampleBodies",
+				"request": requests.Models[i].Id,
+			}).Debug("Skipping request with a non-JSON body")
+			continue
+		}
+		samples = append(samples, sample)
+	}
+
+	return samples, nil
+}
@@ -0,0 +1,83 @@
+package source
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	hookdecksdk "github.com/hookdeck/hookdeck-go-sdk"
+	hookdeckclient "github.com/hookdeck/hookdeck-go-sdk/client"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/hookdeck/hookdeck-cli/pkg/signature"
+)
+
+// Get finds a source by name, including its verification configs.
+func Get(client *hookdeckclient.Client, name string) (*hookdecksdk.Source, error) {
+	sources, err := client.Source.List(context.Background(), &hookdecksdk.SourceListRequest{
+		Name: &name,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(sources.Models) == 0 {
+		return nil, fmt.Errorf("source %q not found", name)
+	}
+
+	include := "verification.configs"
+	return client.Source.Retrieve(context.Background(), sources.Models[0].Id, &hookdecksdk.SourceRetrieveRequest{
+		Include: &include,
+	})
+}
+
+// Authenticate adds the headers required by the source's verification
+// config to the request, so that it is accepted by Hookdeck.
+func Authenticate(source *hookdecksdk.Source, req *http.Request, body string) error {
+	if source.Verification == nil || source.Verification.VerificationConfig == nil {
+		return nil
+	}
+
+	config := source.Verification.VerificationConfig
+
+	switch {
+	case config.Hmac != nil && config.Hmac.Configs != nil:
+		c := config.Hmac.Configs
+		value, err := signature.HMAC(c.WebhookSecretKey, body, string(c.Algorithm), string(c.Encoding))
+		if err != nil {
+			return err
+		}
+		req.Header.Set(c.HeaderKey, value)
+	case config.BasicAuth != nil && config.BasicAuth.Configs != nil:
+		req.SetBasicAuth(config.BasicAuth.Configs.Username, config.BasicAuth.Configs.Password)
+	case config.ApiKey != nil && config.ApiKey.Configs != nil:
+		req.Header.Set(config.ApiKey.Configs.HeaderKey, config.ApiKey.Configs.ApiKey)
+	case config.Stripe != nil && config.Stripe.Configs != nil:
+		return sign(req, "stripe", config.Stripe.Configs.WebhookSecretKey, body)
+	case config.Github != nil && config.Github.Configs != nil:
+		return sign(req, "github", config.Github.Configs.WebhookSecretKey, body)
+	case config.Shopify != nil && config.Shopify.Configs != nil:
+		return sign(req, "shopify", config.Shopify.Configs.WebhookSecretKey, body)
+	default:
+		log.WithFields(log.Fields{
+			"prefix": "source.Authenticate",
+		}).Warnf("Signing requests for %s verification is not supported, the request will be sent unsigned", config.Type)
+	}
+
+	return nil
+}
+
+func sign(req *http.Request, provider string, secret string, body string) error {
+	header, err := signature.Sign(provider, secret, body, time.Now())
+	if err != nil {
+		return err
+	}
+
+	for name := range header {
+		if req.Header.Get(name) == "" {
+			req.Header.Set(name, header.Get(name))
+		}
+	}
+
+	return nil
+}
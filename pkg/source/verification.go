@@ -0,0 +1,90 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	hookdecksdk "github.com/hookdeck/hookdeck-go-sdk"
+	hookdeckclient "github.com/hookdeck/hookdeck-go-sdk/client"
+)
+
+// VerificationOptions are the settings of a source verification. Secret is
+// the webhook secret of HMAC and provider verifications, the password of
+// basic auth, or the API key of API key verification.
+type VerificationOptions struct {
+	Type      string
+	Secret    string
+	Username  string
+	HeaderKey string
+	Algorithm string
+	Encoding  string
+}
+
+// NewVerification returns the verification config of the options. Providers
+// other than hmac, basic_auth and api_key are set up with their webhook
+// secret, use ParseVerification for the providers needing other settings.
+func NewVerification(opts VerificationOptions) (*hookdecksdk.VerificationConfig, error) {
+	configs := map[string]string{}
+	switch opts.Type {
+	case "hmac":
+		if opts.HeaderKey == "" {
+			return nil, fmt.Errorf("hmac verification requires a header key")
+		}
+		configs["webhook_secret_key"] = opts.Secret
+		configs["header_key"] = opts.HeaderKey
+		configs["algorithm"] = opts.Algorithm
+		if configs["algorithm"] == "" {
+			configs["algorithm"] = "sha256"
+		}
+		configs["encoding"] = opts.Encoding
+		if configs["encoding"] == "" {
+			configs["encoding"] = "base64"
+		}
+	case "basic_auth":
+		if opts.Username == "" {
+			return nil, fmt.Errorf("basic_auth verification requires a username")
+		}
+		configs["username"] = opts.Username
+		configs["password"] = opts.Secret
+	case "api_key":
+		if opts.HeaderKey == "" {
+			return nil, fmt.Errorf("api_key verification requires a header key")
+		}
+		configs["header_key"] = opts.HeaderKey
+		configs["api_key"] = opts.Secret
+	default:
+		configs["webhook_secret_key"] = opts.Secret
+	}
+
+	data, err := json.Marshal(map[string]interface{}{"type": opts.Type, "configs": configs})
+	if err != nil {
+		return nil, err
+	}
+	return ParseVerification(data)
+}
+
+// ParseVerification parses a verification config in the format of the API,
+// e.g. {"type": "stripe", "configs": {"webhook_secret_key": "whsec_..."}}.
+func ParseVerification(data []byte) (*hookdecksdk.VerificationConfig, error) {
+	config := &hookdecksdk.VerificationConfig{}
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("invalid verification config: %w", err)
+	}
+	if config.Type == "" {
+		return nil, fmt.Errorf("invalid verification config: missing type")
+	}
+	// Unknown types are only detected when encoding the config
+	if _, err := json.Marshal(config); err != nil {
+		return nil, fmt.Errorf("unsupported verification type %q", config.Type)
+	}
+	return config, nil
+}
+
+// SetVerification replaces the verification of a source, leaving its other
+// settings and its connections unchanged.
+func SetVerification(client *hookdeckclient.Client, source *hookdecksdk.Source, config *hookdecksdk.VerificationConfig) (*hookdecksdk.Source, error) {
+	return client.Source.Update(context.Background(), source.Id, &hookdecksdk.SourceUpdateRequest{
+		Verification: hookdecksdk.Optional(*config),
+	})
+}
@@ -0,0 +1,39 @@
+package source
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewVerification(t *testing.T) {
+	config, err := NewVerification(VerificationOptions{Type: "hmac", Secret: "secret", HeaderKey: "X-Signature"})
+	require.NoError(t, err)
+	data, err := json.Marshal(config)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"type":"hmac","configs":{"webhook_secret_key":"secret","header_key":"X-Signature","algorithm":"sha256","encoding":"base64"}}`, string(data))
+
+	config, err = NewVerification(VerificationOptions{Type: "stripe", Secret: "whsec_123"})
+	require.NoError(t, err)
+	require.Equal(t, "stripe", config.Type)
+	require.Equal(t, "whsec_123", config.Stripe.Configs.WebhookSecretKey)
+
+	_, err = NewVerification(VerificationOptions{Type: "hmac", Secret: "secret"})
+	require.EqualError(t, err, "hmac verification requires a header key")
+
+	_, err = NewVerification(VerificationOptions{Type: "basic_auth", Secret: "password"})
+	require.EqualError(t, err, "basic_auth verification requires a username")
+}
+
+func TestParseVerification(t *testing.T) {
+	config, err := ParseVerification([]byte(`{"type":"github","configs":{"webhook_secret_key":"secret"}}`))
+	require.NoError(t, err)
+	require.Equal(t, "github", config.Type)
+
+	_, err = ParseVerification([]byte(`{"configs":{"webhook_secret_key":"secret"}}`))
+	require.EqualError(t, err, "invalid verification config: missing type")
+
+	_, err = ParseVerification([]byte(`{"type":"carrier_pigeon","configs":{}}`))
+	require.EqualError(t, err, `unsupported verification type "carrier_pigeon"`)
+}
@@ -0,0 +1,258 @@
+// Package upgrade replaces the running CLI binary with the latest release
+// published on GitHub.
+package upgrade
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v28/github"
+)
+
+const (
+	owner   = "hookdeck"
+	repo    = "hookdeck-cli"
+	project = "hookdeck"
+)
+
+// Release is a published release of the CLI.
+type Release struct {
+	Version string
+	assets  map[string]string
+}
+
+// LatestRelease returns the latest release published on GitHub.
+func LatestRelease(ctx context.Context) (*Release, error) {
+	client := github.NewClient(nil)
+	rep, _, err := client.Repositories.GetLatestRelease(ctx, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	release := &Release{
+		Version: strings.TrimPrefix(rep.GetTagName(), "v"),
+		assets:  map[string]string{},
+	}
+	for _, asset := range rep.Assets {
+		release.assets[asset.GetName()] = asset.GetBrowserDownloadURL()
+	}
+
+	return release, nil
+}
+
+// ArchiveName returns the name of the release archive for a platform, as
+// published by GoReleaser.
+func ArchiveName(version, goos, goarch string) string {
+	ext := ".tar.gz"
+	if goos == "windows" {
+		ext = ".zip"
+	}
+	return fmt.Sprintf("%s_%s_%s_%s%s", project, strings.TrimPrefix(version, "v"), goos, goarch, ext)
+}
+
+// ChecksumsName returns the name of the checksums file covering the archives
+// of a platform. Each platform is released separately and has its own file.
+func ChecksumsName(goos string) string {
+	switch goos {
+	case "darwin":
+		return project + "-checksums.txt"
+	default:
+		return fmt.Sprintf("%s-%s-checksums.txt", project, goos)
+	}
+}
+
+// VerifyChecksum checks the SHA-256 checksum of the named file against the
+// checksums file of the release.
+func VerifyChecksum(data []byte, checksums []byte, name string) error {
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 || fields[1] != name {
+			continue
+		}
+
+		sum := sha256.Sum256(data)
+		if hex.EncodeToString(sum[:]) != fields[0] {
+			return fmt.Errorf("checksum mismatch for %s", name)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("no checksum found for %s", name)
+}
+
+// ExtractBinary returns the CLI binary contained in a release archive.
+func ExtractBinary(archive []byte, goos string) ([]byte, error) {
+	binary := project
+	if goos == "windows" {
+		binary += ".exe"
+		return extractZip(archive, binary)
+	}
+	return extractTarGz(archive, binary)
+}
+
+func extractTarGz(archive []byte, binary string) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag == tar.TypeReg && filepath.Base(header.Name) == binary {
+			return ioutil.ReadAll(tr)
+		}
+	}
+
+	return nil, fmt.Errorf("%s not found in the release archive", binary)
+}
+
+func extractZip(archive []byte, binary string) ([]byte, error) {
+	zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, file := range zr.File {
+		if filepath.Base(file.Name) != binary {
+			continue
+		}
+		rc, err := file.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer rc.Close()
+		return ioutil.ReadAll(rc)
+	}
+
+	return nil, fmt.Errorf("%s not found in the release archive", binary)
+}
+
+// Download fetches the archive of the release for a platform and returns the
+// CLI binary it contains, after verifying the archive checksum. The checksums
+// come from the same release, so this checks integrity but not authenticity.
+func (r *Release) Download(ctx context.Context, goos, goarch string) ([]byte, error) {
+	archiveName := ArchiveName(r.Version, goos, goarch)
+	archiveURL, ok := r.assets[archiveName]
+	if !ok {
+		return nil, fmt.Errorf("release %s has no archive for %s/%s", r.Version, goos, goarch)
+	}
+	checksumsURL, ok := r.assets[ChecksumsName(goos)]
+	if !ok {
+		return nil, fmt.Errorf("release %s has no checksums for %s", r.Version, goos)
+	}
+
+	checksums, err := download(ctx, checksumsURL)
+	if err != nil {
+		return nil, err
+	}
+	archive, err := download(ctx, archiveURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := VerifyChecksum(archive, checksums, archiveName); err != nil {
+		return nil, err
+	}
+
+	return ExtractBinary(archive, goos)
+}
+
+func download(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &http.Client{Timeout: 5 * time.Minute}
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected http status code downloading %s: %d", url, res.StatusCode)
+	}
+
+	return ioutil.ReadAll(res.Body)
+}
+
+// ErrPackageManager is returned by CheckExecutable when the CLI was installed
+// with a package manager, which should be used to upgrade it instead.
+var ErrPackageManager = errors.New("the CLI was installed with a package manager")
+
+// CheckExecutable returns the path of the running executable, or an error
+// when it can't be replaced in place.
+func CheckExecutable() (string, error) {
+	path, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	path, err = filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", err
+	}
+
+	for _, dir := range []string{"Cellar", "node_modules", "scoop"} {
+		if strings.Contains(path, string(filepath.Separator)+dir+string(filepath.Separator)) {
+			return "", fmt.Errorf("%w (%s), use it to upgrade", ErrPackageManager, path)
+		}
+	}
+
+	return path, nil
+}
+
+// ReplaceExecutable replaces the file at path with binary. The new binary is
+// written next to the old one first so that a failed write leaves the
+// current installation untouched.
+func ReplaceExecutable(path string, binary []byte) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	newPath := path + ".new"
+	if err := ioutil.WriteFile(newPath, binary, info.Mode()); err != nil {
+		return err
+	}
+
+	// A running executable can't be overwritten on Windows, but it can be
+	// renamed.
+	oldPath := path + ".old"
+	os.Remove(oldPath)
+	if err := os.Rename(path, oldPath); err != nil {
+		os.Remove(newPath)
+		return err
+	}
+	if err := os.Rename(newPath, path); err != nil {
+		os.Rename(oldPath, path)
+		return err
+	}
+	os.Remove(oldPath)
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package upgrade
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveName(t *testing.T) {
+	require.Equal(t, "hookdeck_0.9.1_linux_amd64.tar.gz", ArchiveName("v0.9.1", "linux", "amd64"))
+	require.Equal(t, "hookdeck_0.9.1_windows_amd64.zip", ArchiveName("0.9.1", "windows", "amd64"))
+	require.Equal(t, "hookdeck-checksums.txt", ChecksumsName("darwin"))
+	require.Equal(t, "hookdeck-linux-checksums.txt", ChecksumsName("linux"))
+}
+
+func TestVerifyChecksum(t *testing.T) {
+	data := []byte("archive")
+	sum := sha256.Sum256(data)
+	checksums := []byte(hex.EncodeToString(sum[:]) + "  hookdeck_0.9.1_linux_amd64.tar.gz\n")
+
+	require.NoError(t, VerifyChecksum(data, checksums, "hookdeck_0.9.1_linux_amd64.tar.gz"))
+	require.EqualError(t, VerifyChecksum([]byte("tampered"), checksums, "hookdeck_0.9.1_linux_amd64.tar.gz"), "checksum mismatch for hookdeck_0.9.1_linux_amd64.tar.gz")
+	require.EqualError(t, VerifyChecksum(data, checksums, "hookdeck_0.9.1_linux_arm64.tar.gz"), "no checksum found for hookdeck_0.9.1_linux_arm64.tar.gz")
+}
+
+func TestExtractBinary(t *testing.T) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range map[string]string{"README.md": "readme", "hookdeck": "binary"} {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0755, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+
+	binary, err := ExtractBinary(buf.Bytes(), "linux")
+	require.NoError(t, err)
+	require.Equal(t, "binary", string(binary))
+}
@@ -142,3 +142,20 @@ func StatusCodeType(code string) error {
 
 	return nil
 }
+
+// ResourceName validates that a string is an acceptable name for a source or
+// a connection, which Hookdeck limits to letters, numbers, dashes and
+// underscores.
+func ResourceName(name string) error {
+	if name == "" {
+		return errors.New("the name can't be empty")
+	}
+
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+			return fmt.Errorf("%s is not an acceptable name, only letters, numbers, dashes and underscores are allowed", name)
+		}
+	}
+
+	return nil
+}
@@ -3,8 +3,10 @@ package version
 import (
 	"context"
 	"fmt"
-	"os"
+	"io/ioutil"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/google/go-github/v28/github"
 	log "github.com/sirupsen/logrus"
@@ -26,21 +28,105 @@ var Template = fmt.Sprintf("hookdeck version %s\n", Version)
 func CheckLatestVersion() {
 	// main is the dev version, we don't want to check against that every time
 	if Version != "main" {
-		s := ansi.StartNewSpinner("Checking for new versions...", os.Stdout)
+		s := ansi.StartNewSpinner("Checking for new versions...", ansi.StatusWriter)
 		latest := getLatestVersion()
 
-		ansi.StopSpinner(s, "", os.Stdout)
+		ansi.StopSpinner(s, "", ansi.StatusWriter)
 
 		if needsToUpgrade(Version, latest) {
-			fmt.Println(ansi.Italic("A newer version of the Hookdeck CLI is available, please update to:"), ansi.Italic(latest))
+			fmt.Fprintln(ansi.StatusWriter, ansi.Italic("A newer version of the Hookdeck CLI is available, please update to:"), ansi.Italic(latest))
 		}
 	}
 }
 
 func needsToUpgrade(version, latest string) bool {
+	if cmp, err := Compare(version, latest); err == nil {
+		return cmp < 0
+	}
 	return latest != "" && (strings.TrimPrefix(latest, "v") != strings.TrimPrefix(version, "v"))
 }
 
+// IsDev returns whether the version isn't a release version, e.g. main for the
+// versions built from source.
+func IsDev(version string) bool {
+	_, _, err := parse(version)
+	return err != nil
+}
+
+// Compare compares two release versions, with or without the v prefix. It
+// returns -1 if a is older than b, 0 if they are the same and 1 if a is newer
+// than b. A pre-release, e.g. 1.2.0-beta.1, is older than its release.
+func Compare(a, b string) (int, error) {
+	aNumbers, aPre, err := parse(a)
+	if err != nil {
+		return 0, err
+	}
+	bNumbers, bPre, err := parse(b)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := 0; i < len(aNumbers) || i < len(bNumbers); i++ {
+		var x, y int
+		if i < len(aNumbers) {
+			x = aNumbers[i]
+		}
+		if i < len(bNumbers) {
+			y = bNumbers[i]
+		}
+		if x != y {
+			return sign(x - y), nil
+		}
+	}
+
+	switch {
+	case aPre == bPre:
+		return 0, nil
+	case aPre == "":
+		return 1, nil
+	case bPre == "":
+		return -1, nil
+	default:
+		return sign(strings.Compare(aPre, bPre)), nil
+	}
+}
+
+// parse splits a version into its numbers and its pre-release suffix.
+func parse(version string) ([]int, string, error) {
+	v := strings.TrimPrefix(version, "v")
+	// Build metadata doesn't affect the order
+	if i := strings.Index(v, "+"); i != -1 {
+		v = v[:i]
+	}
+	pre := ""
+	if i := strings.Index(v, "-"); i != -1 {
+		v, pre = v[:i], v[i+1:]
+	}
+
+	parts := strings.Split(v, ".")
+	numbers := make([]int, len(parts))
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return nil, "", fmt.Errorf("%q is not a release version", version)
+		}
+		numbers[i] = n
+	}
+
+	return numbers, pre, nil
+}
+
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
 func getLatestVersion() string {
 	client := github.NewClient(nil)
 	rep, _, err := client.Repositories.GetLatestRelease(context.Background(), "hookdeck", "hookdeck-cli")
@@ -56,3 +142,35 @@ func getLatestVersion() string {
 
 	return *rep.TagName
 }
+
+// NoticeInterval is the minimum duration between two checks for the new
+// version notice.
+const NoticeInterval = 24 * time.Hour
+
+// NewVersionNotice returns a notice when a newer version of the CLI is
+// available. GitHub is checked at most once per NoticeInterval, the time of
+// the last check being stored in stateFile. An empty string is returned when
+// no check is due or the CLI is up to date.
+func NewVersionNotice(stateFile string) string {
+	if Version == "main" {
+		return ""
+	}
+
+	if data, err := ioutil.ReadFile(stateFile); err == nil {
+		last, err := time.Parse(time.RFC3339, strings.TrimSpace(string(data)))
+		if err == nil && time.Since(last) < NoticeInterval {
+			return ""
+		}
+	}
+	if err := ioutil.WriteFile(stateFile, []byte(time.Now().Format(time.RFC3339)), 0600); err != nil {
+		log.Debug(err)
+		return ""
+	}
+
+	latest := getLatestVersion()
+	if !needsToUpgrade(Version, latest) {
+		return ""
+	}
+
+	return fmt.Sprintf("A newer version of the Hookdeck CLI is available (%s), run `hookdeck upgrade` to update.", latest)
+}
@@ -12,4 +12,35 @@ func TestNeedsToUpgrade(t *testing.T) {
 	require.True(t, needsToUpgrade("4.2.4.2", "4.2.4.3"))
 	require.True(t, needsToUpgrade("4.2.4.2", "v4.2.4.3"))
 	require.True(t, needsToUpgrade("v4.2.4.2", "v4.2.4.3"))
+	require.False(t, needsToUpgrade("4.2.5", "v4.2.4"))
+}
+
+func TestCompare(t *testing.T) {
+	for _, c := range []struct {
+		a, b string
+		want int
+	}{
+		{"1.2.3", "v1.2.3", 0},
+		{"1.2.3", "1.2.4", -1},
+		{"1.10.0", "1.9.0", 1},
+		{"1.2", "1.2.0", 0},
+		{"1.2.0-beta.1", "1.2.0", -1},
+		{"1.2.0", "1.2.0-beta.1", 1},
+		{"1.2.0-beta.1", "1.2.0-beta.2", -1},
+	} {
+		got, err := Compare(c.a, c.b)
+		require.NoError(t, err)
+		require.Equal(t, c.want, got, "Compare(%q, %q)", c.a, c.b)
+	}
+
+	_, err := Compare("main", "1.2.3")
+	require.Error(t, err)
+}
+
+func TestIsDev(t *testing.T) {
+	require.True(t, IsDev("main"))
+	require.True(t, IsDev("master"))
+	require.True(t, IsDev(""))
+	require.False(t, IsDev("v1.2.3"))
+	require.False(t, IsDev("1.2.3-beta.1"))
 }
@@ -2,6 +2,7 @@ package websocket
 
 import (
 	"encoding/json"
+	"time"
 )
 
 type AttemptRequest struct {
@@ -22,6 +23,8 @@ type AttemptBody struct {
 type Attempt struct {
 	Event string      `json:"type"`
 	Body  AttemptBody `json:"body"`
+	// ReceivedAt is when the client received the attempt
+	ReceivedAt time.Time `json:"-"`
 }
 
 type AttemptResponseBody struct {
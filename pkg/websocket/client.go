@@ -8,6 +8,7 @@ import (
 	"io/ioutil"
 	"net"
 	"net/http"
+	"net/url"
 	"os"
 	"strings"
 	"sync"
@@ -41,6 +42,10 @@ type Config struct {
 	WriteWait time.Duration
 
 	EventHandler EventHandler
+
+	// Proxy returns the proxy to connect through, the proxy environment
+	// variables are used when nil. It's ignored when a Dialer is set.
+	Proxy func(*http.Request) (*url.URL, error)
 }
 
 // EventHandler handles an event.
@@ -248,6 +253,11 @@ func (c *Client) connect(ctx context.Context) error {
 
 	defer resp.Body.Close()
 
+	c.cfg.Log.WithFields(log.Fields{
+		"prefix":     "websocket.Client.connect",
+		"extensions": resp.Header.Get("Sec-WebSocket-Extensions"),
+	}).Debug("Negotiated websocket extensions")
+
 	c.changeConnection(conn)
 	c.isConnected = true
 
@@ -347,6 +357,9 @@ func (c *Client) readPump() {
 			continue
 		}
 
+		if msg.Attempt != nil {
+			msg.Attempt.ReceivedAt = time.Now()
+		}
 		go c.cfg.EventHandler.ProcessEvent(msg)
 	}
 }
@@ -443,7 +456,7 @@ func NewClient(url string, webSocketID string, CLIKey string, teamID string, cfg
 	}
 
 	if cfg.Dialer == nil {
-		cfg.Dialer = newWebSocketDialer(os.Getenv("HOOKDECK_CLI_UNIX_SOCKET"))
+		cfg.Dialer = newWebSocketDialer(os.Getenv("HOOKDECK_CLI_UNIX_SOCKET"), cfg.Proxy)
 	}
 
 	if cfg.Log == nil {
@@ -505,7 +518,7 @@ var nullEventHandler = EventHandlerFunc(func(IncomingMessage) {})
 // Private functions
 //
 
-func newWebSocketDialer(unixSocket string) *ws.Dialer {
+func newWebSocketDialer(unixSocket string, proxy func(*http.Request) (*url.URL, error)) *ws.Dialer {
 	var dialer *ws.Dialer
 
 	if unixSocket != "" {
@@ -516,12 +529,19 @@ func newWebSocketDialer(unixSocket string) *ws.Dialer {
 			HandshakeTimeout: 10 * time.Second,
 			NetDial:          dialFunc,
 			Subprotocols:     subprotocols[:],
+			// Negotiate permessage-deflate, messages are sent uncompressed
+			// if Hookdeck doesn't support it
+			EnableCompression: true,
 		}
 	} else {
+		if proxy == nil {
+			proxy = http.ProxyFromEnvironment
+		}
 		dialer = &ws.Dialer{
-			HandshakeTimeout: 10 * time.Second,
-			Proxy:            http.ProxyFromEnvironment,
-			Subprotocols:     subprotocols[:],
+			HandshakeTimeout:  10 * time.Second,
+			Proxy:             proxy,
+			Subprotocols:      subprotocols[:],
+			EnableCompression: true,
 		}
 	}
 